@@ -17,41 +17,40 @@ import (
 	"github.com/petri-board-arena/internal/runtime/buildinfo"
 
 	createarena "github.com/petri-board-arena/internal/application/command"
-	"github.com/petri-board-arena/internal/application/port/repository"
+	"github.com/petri-board-arena/internal/application/query"
+	"github.com/petri-board-arena/internal/application/ticker"
 	"github.com/petri-board-arena/internal/infrastructure/adapter"
+	"github.com/petri-board-arena/internal/infrastructure/config"
 	pg "github.com/petri-board-arena/internal/infrastructure/persistence/postgres"
+	pgoutbox "github.com/petri-board-arena/internal/infrastructure/persistence/postgres/outbox"
 	pgwrite "github.com/petri-board-arena/internal/infrastructure/persistence/postgres/write"
+	infraredis "github.com/petri-board-arena/internal/infrastructure/persistence/redis"
+	"github.com/petri-board-arena/internal/runtime/requestctx"
 )
 
 func main() {
+	cfg, err := config.Load(config.ServiceAPI, os.Args[1:])
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	banner.Print(banner.Info{
 		AppName:       "petri-arena",
-		Env:           os.Getenv("APP_ENV"),
-		Port:          os.Getenv("PORT"),
-		WriteDBURL:    os.Getenv("WRITE_DATABASE_URL"),
-		ReadDBURL:     os.Getenv("READ_DATABASE_URL"),
+		Env:           cfg.App.Env,
+		Port:          cfg.App.Port,
+		WriteDBURL:    cfg.Postgres.URL,
+		ReadDBURL:     cfg.Redis.URL,
 		GitCommit:     buildinfo.GitCommit,
 		BuildTime:     buildinfo.BuildTime,
 		MigrationsDir: "migrations",
 	})
+	log.Printf("[api] effective config:")
+	cfg.Report(log.Writer())
 
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
-	}
+	port := cfg.App.Port
 
 	// ✅ CQRS: write = Postgres
-	writeDSN := os.Getenv("WRITE_DATABASE_URL")
-	if writeDSN == "" {
-		log.Fatal("WRITE_DATABASE_URL not set")
-	}
-
-	// ✅ CQRS: read = Redis
-	readDSN := os.Getenv("READ_DATABASE_URL")
-	if readDSN == "" {
-		log.Fatal("READ_DATABASE_URL not set")
-	}
-	_ = readDSN
+	writeDSN := cfg.Postgres.URL
 
 	db, err := sql.Open("postgres", writeDSN)
 	if err != nil {
@@ -63,26 +62,62 @@ func main() {
 		log.Fatalf("ping write db: %v", err)
 	}
 
+	// ✅ CQRS: read = Redis (projetado pelo worker)
+	rdb, err := infraredis.NewRedisClient(cfg.Redis.URL)
+	if err != nil {
+		log.Fatalf("redis: %v", err)
+	}
+	defer rdb.Close()
+	readRepo := infraredis.NewArenaReadRepo(rdb)
+
 	// Infra (write side)
 	uow := pg.NewUnitOfWork(db)
-	var writeRepo repository.ArenaWriteRepository = pgwrite.NewArenaRepo(db)
+	// postgres.arenaStore: row (tabelas) ou event (arena_event + snapshots)
+	writeRepo := pgwrite.NewArenaRepository(db, cfg.Postgres)
 
 	clock := adapter.RealClock{}
 	ids := adapter.UUIDGen{}
-	pub := adapter.NopArenaPublisher{}
+	// tokens de sessão assinados: identificam o jogador nas mutations (Authorization: Bearer)
+	tokens := adapter.NewSignedSessionTokens(cfg.Session.Secret, cfg.Session.TTL)
+	// eventos de domínio vão para o outbox na mesma tx do aggregate; o relay publica no Kafka
+	outboxStore := pgoutbox.NewStore(db)
+	pub := adapter.NewOutboxArenaPublisher(outboxStore, cfg.Kafka.Topic, 0)
 
 	// Application handler (command side)
 	createArenaHandler := createarena.NewHandler(uow, writeRepo, ids, clock, pub)
 
+	// ticks: arenas RUNNING avançam a cada tickMillis (ticker.interval 0 desabilita)
+	if cfg.Ticker.Interval > 0 {
+		advance := createarena.NewAdvanceTickHandler(uow, writeRepo, pgwrite.NewArenaWorldRepo(db), clock, pub)
+		go ticker.NewDriver(readRepo, advance, clock).Run(context.Background(), cfg.Ticker.Interval)
+	}
+
 	// GraphQL resolver (composition root)
 	resolver := graph.NewResolver(graph.ResolverDeps{
-		CreateArenaHandler: createArenaHandler,
+		CreateArenaHandler:    createArenaHandler,
+		StartArenaHandler:     createarena.NewStartArenaHandler(uow, writeRepo, clock, pub),
+		PauseArenaHandler:     createarena.NewPauseArenaHandler(uow, writeRepo, clock, pub),
+		ResumeArenaHandler:    createarena.NewResumeArenaHandler(uow, writeRepo, clock, pub),
+		StopArenaHandler:      createarena.NewStopArenaHandler(uow, writeRepo, clock, pub),
+		JoinArenaHandler:      createarena.NewJoinArenaHandler(uow, writeRepo, ids, tokens, clock, pub),
+		LeaveArenaHandler:     createarena.NewLeaveArenaHandler(uow, writeRepo, clock, pub),
+		SubmitActionHandler:   createarena.NewSubmitActionHandler(uow, writeRepo, ids, clock, pub),
+		SetArenaConfigHandler: createarena.NewSetArenaConfigHandler(uow, writeRepo, clock, pub),
+
+		GetArenaHandler:   query.NewGetArenaHandler(readRepo),
+		ListArenasHandler: query.NewListArenasHandler(readRepo),
+
+		ListDeadLettersHandler:    query.NewListDeadLettersHandler(outboxStore),
+		RequeueDeadLettersHandler: createarena.NewRequeueDeadLettersHandler(outboxStore),
+		PurgeDeadLettersHandler:   createarena.NewPurgeDeadLettersHandler(outboxStore),
+		AdminToken:                cfg.Admin.APIToken,
 	})
 
 	srv := handler.NewDefaultServer(graph.NewExecutableSchema(graph.Config{Resolvers: resolver}))
+	srv.SetErrorPresenter(graph.ErrorPresenter)
 
 	http.Handle("/", playground.Handler("GraphQL", "/query"))
-	http.Handle("/query", srv)
+	http.Handle("/query", requestctx.Middleware(tokens, srv))
 
 	log.Printf("GraphQL running at http://localhost:%s/", port)
 	log.Fatal(http.ListenAndServe(":"+port, nil))
@@ -0,0 +1,299 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/petri-board-arena/internal/infrastructure/config"
+	"github.com/petri-board-arena/internal/infrastructure/messaging"
+	kafkadlq "github.com/petri-board-arena/internal/infrastructure/messaging/kafka"
+	infraredis "github.com/petri-board-arena/internal/infrastructure/persistence/redis"
+	"github.com/petri-board-arena/internal/infrastructure/projector"
+)
+
+const (
+	modeRepublish = "republish"
+	modeApply     = "apply"
+)
+
+type options struct {
+	brokers  []string
+	dlqTopic string
+	mode     string
+	dryRun   bool
+	limit    int
+
+	// republish
+	targetTopic string
+	// apply
+	redisURL string
+
+	// filtros (vazios = todos)
+	errorContains  string
+	eventType      string
+	classification string
+	from, to       *time.Time
+}
+
+type report struct {
+	Scanned     int `json:"scanned"`
+	Matched     int `json:"matched"`
+	Replayed    int `json:"replayed"`
+	Failed      int `json:"failed"`
+	Undecodable int `json:"undecodable"`
+}
+
+// dlq-replay lê o tópico de DLQ do worker de projeção e reprocessa as mensagens
+// selecionadas: republica o value original no tópico de origem (mode=republish)
+// ou aplica direto no read model via Projector (mode=apply).
+func main() {
+	opts, err := parseFlags(os.Args[1:])
+	if err != nil {
+		log.Fatalf("dlq-replay: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	replay, closeFn, err := newReplayer(opts)
+	if err != nil {
+		log.Fatalf("dlq-replay: %v", err)
+	}
+	defer closeFn()
+
+	var rep report
+	out := json.NewEncoder(os.Stdout)
+
+	err = kafkadlq.ReadDLQ(ctx, opts.brokers, opts.dlqTopic, func(m kafkadlq.DLQMessage) error {
+		rep.Scanned++
+		if m.DecodeErr != nil {
+			rep.Undecodable++
+			log.Printf("[dlq-replay] skip %d/%d: invalid dlq record: %v", m.Partition, m.Offset, m.DecodeErr)
+			return nil
+		}
+
+		ev, evErr := decodeEnvelope(m.Record.ContentType, m.Record.OriginalValue())
+		if !opts.matches(m.Record, ev, evErr) {
+			return nil
+		}
+		rep.Matched++
+
+		line := map[string]any{
+			"dlqPartition":      m.Partition,
+			"dlqOffset":         m.Offset,
+			"originalTopic":     m.Record.OriginalTopic,
+			"originalPartition": m.Record.OriginalPartition,
+			"originalOffset":    m.Record.OriginalOffset,
+			"eventId":           ev.EventID,
+			"eventType":         ev.EventType,
+			"aggregateId":       ev.AggregateID,
+			"error":             m.Record.Error,
+			"classification":    m.Record.Classification,
+			"attempts":          m.Record.Attempts,
+			"dryRun":            opts.dryRun,
+		}
+
+		if !opts.dryRun {
+			if err := replay(ctx, m, ev, evErr); err != nil {
+				rep.Failed++
+				line["replayError"] = err.Error()
+			} else {
+				rep.Replayed++
+			}
+		}
+		if err := out.Encode(line); err != nil {
+			return err
+		}
+
+		if opts.limit > 0 && rep.Matched >= opts.limit {
+			return errLimitReached
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errLimitReached) {
+		log.Printf("[dlq-replay] stopped: %v", err)
+	}
+
+	b, _ := json.Marshal(rep)
+	log.Printf("[dlq-replay] mode=%s dryRun=%t report=%s", opts.mode, opts.dryRun, b)
+	if rep.Failed > 0 || (err != nil && !errors.Is(err, errLimitReached)) {
+		os.Exit(1)
+	}
+}
+
+var errLimitReached = errors.New("limit reached")
+
+type replayFunc func(ctx context.Context, m kafkadlq.DLQMessage, ev messaging.EventEnvelope, evErr error) error
+
+func newReplayer(opts options) (replayFunc, func(), error) {
+	if opts.dryRun {
+		return nil, func() {}, nil
+	}
+
+	switch opts.mode {
+	case modeRepublish:
+		w := &kafka.Writer{
+			Addr:         kafka.TCP(opts.brokers...),
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+			BatchTimeout: 10 * time.Millisecond,
+		}
+		fn := func(ctx context.Context, m kafkadlq.DLQMessage, _ messaging.EventEnvelope, _ error) error {
+			topic := opts.targetTopic
+			if topic == "" {
+				topic = m.Record.OriginalTopic
+			}
+			if topic == "" {
+				return fmt.Errorf("no target topic (originalTopic empty; use -target-topic)")
+			}
+			return w.WriteMessages(ctx, kafka.Message{
+				Topic: topic,
+				Key:   m.Key,
+				Value: m.Record.OriginalValue(),
+				Headers: []kafka.Header{
+					{Key: kafkadlq.HeaderReplayedFromDLQ, Value: []byte(fmt.Sprintf("%s/%d/%d", opts.dlqTopic, m.Partition, m.Offset))},
+					{Key: messaging.HeaderContentType, Value: []byte(m.Record.ContentType)},
+				},
+			})
+		}
+		return fn, func() { _ = w.Close() }, nil
+
+	case modeApply:
+		rdb, err := infraredis.NewRedisClient(opts.redisURL)
+		if err != nil {
+			return nil, nil, fmt.Errorf("redis: %w", err)
+		}
+		proj := projector.NewProjector(rdb, config.Worker{})
+		fn := func(ctx context.Context, _ kafkadlq.DLQMessage, ev messaging.EventEnvelope, evErr error) error {
+			if evErr != nil {
+				return fmt.Errorf("decode envelope: %w", evErr)
+			}
+			return proj.Reapply(ctx, ev)
+		}
+		return fn, func() { _ = rdb.Close() }, nil
+	}
+	return nil, nil, fmt.Errorf("invalid mode %q (%s|%s)", opts.mode, modeRepublish, modeApply)
+}
+
+func decodeEnvelope(contentType string, value []byte) (messaging.EventEnvelope, error) {
+	ev, err := messaging.DecodeEnvelope(contentType, value)
+	if err != nil {
+		return messaging.EventEnvelope{}, err
+	}
+	if ev.OccurredAt.IsZero() {
+		ev.OccurredAt = time.Now().UTC()
+	}
+	return ev, nil
+}
+
+// matches aplica os filtros; from/to usam o instante em que a mensagem foi para o DLQ.
+func (o options) matches(r kafkadlq.DLQRecord, ev messaging.EventEnvelope, evErr error) bool {
+	if o.errorContains != "" && !strings.Contains(strings.ToLower(r.Error), strings.ToLower(o.errorContains)) {
+		return false
+	}
+	if o.classification != "" && r.Classification != o.classification {
+		return false
+	}
+	if o.eventType != "" && (evErr != nil || ev.EventType != o.eventType) {
+		return false
+	}
+	if o.from != nil || o.to != nil {
+		ts, err := r.Time()
+		if err != nil {
+			return false
+		}
+		if o.from != nil && ts.Before(*o.from) {
+			return false
+		}
+		if o.to != nil && !ts.Before(*o.to) {
+			return false
+		}
+	}
+	return true
+}
+
+func parseFlags(args []string) (options, error) {
+	fs := flag.NewFlagSet("dlq-replay", flag.ContinueOnError)
+
+	brokers := fs.String("brokers", env("KAFKA_BROKERS", "localhost:9092"), "kafka brokers (csv)")
+	dlqTopic := fs.String("dlq-topic", env("KAFKA_DLQ_TOPIC", ""), "tópico de DLQ a ler")
+	mode := fs.String("mode", modeRepublish, "republish | apply")
+	dryRun := fs.Bool("dry-run", false, "só lista o que seria reprocessado")
+	limit := fs.Int("limit", 0, "máximo de mensagens reprocessadas (0 = todas)")
+	targetTopic := fs.String("target-topic", "", "republish: sobrescreve o originalTopic")
+	redisURL := fs.String("redis-url", env("REDIS_URL", ""), "apply: read model (Redis)")
+	errorContains := fs.String("error-contains", "", "filtra por trecho do erro (case-insensitive)")
+	eventType := fs.String("event-type", "", "filtra por eventType do envelope")
+	classification := fs.String("classification", "", "filtra por classificação do erro (permanent|transient)")
+	from := fs.String("from", "", "filtra ts do DLQ >= from (RFC3339)")
+	to := fs.String("to", "", "filtra ts do DLQ < to (RFC3339)")
+
+	if err := fs.Parse(args); err != nil {
+		return options{}, err
+	}
+
+	opts := options{
+		dlqTopic:      *dlqTopic,
+		mode:          *mode,
+		dryRun:        *dryRun,
+		limit:         *limit,
+		targetTopic:   *targetTopic,
+		redisURL:      *redisURL,
+		errorContains: *errorContains,
+		eventType:     *eventType,
+
+		classification: *classification,
+	}
+	for _, b := range strings.Split(*brokers, ",") {
+		if b = strings.TrimSpace(b); b != "" {
+			opts.brokers = append(opts.brokers, b)
+		}
+	}
+
+	var err error
+	if opts.from, err = parseTime("from", *from); err != nil {
+		return options{}, err
+	}
+	if opts.to, err = parseTime("to", *to); err != nil {
+		return options{}, err
+	}
+
+	switch {
+	case len(opts.brokers) == 0 || opts.dlqTopic == "":
+		return options{}, fmt.Errorf("-brokers and -dlq-topic (or KAFKA_BROKERS/KAFKA_DLQ_TOPIC) are required")
+	case opts.mode != modeRepublish && opts.mode != modeApply:
+		return options{}, fmt.Errorf("invalid -mode %q (%s|%s)", opts.mode, modeRepublish, modeApply)
+	case opts.mode == modeApply && !opts.dryRun && opts.redisURL == "":
+		return options{}, fmt.Errorf("-mode=apply requires -redis-url (or REDIS_URL)")
+	}
+	return opts, nil
+}
+
+func parseTime(name, v string) (*time.Time, error) {
+	if v == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return nil, fmt.Errorf("-%s: %w", name, err)
+	}
+	return &t, nil
+}
+
+func env(k, def string) string {
+	if v := strings.TrimSpace(os.Getenv(k)); v != "" {
+		return v
+	}
+	return def
+}
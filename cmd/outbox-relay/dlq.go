@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/user"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/petri-board-arena/internal/application/port"
+	pgoutbox "github.com/petri-board-arena/internal/infrastructure/persistence/postgres/outbox"
+)
+
+const dlqUsage = `usage: outbox-relay dlq <list|requeue|purge> [flags]
+
+  -ids          ids separados por vírgula
+  -aggregate-id filtra por aggregate_id
+  -event-type   filtra por event_type
+  -from, -to    janela de created_at (RFC3339; from inclusivo, to exclusivo)
+  -limit        máximo de dead letters (list/requeue/purge)
+  -offset       paginação (list)
+  -actor        quem executa (auditoria; padrão: usuário do SO)
+  -reason       motivo (auditoria)
+`
+
+// runDLQ administra outbox_dead_letter pela linha de comando (mesmas regras e auditoria do GraphQL).
+func runDLQ(ctx context.Context, dsn string, args []string) error {
+	if len(args) == 0 {
+		return errors.New(dlqUsage)
+	}
+	action, args := args[0], args[1:]
+
+	fs := flag.NewFlagSet("dlq "+action, flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, dlqUsage) }
+	var (
+		ids         = fs.String("ids", "", "")
+		aggregateID = fs.String("aggregate-id", "", "")
+		eventType   = fs.String("event-type", "", "")
+		from        = fs.String("from", "", "")
+		to          = fs.String("to", "", "")
+		limit       = fs.Int("limit", 0, "")
+		offset      = fs.Int("offset", 0, "")
+		actor       = fs.String("actor", defaultActor(), "")
+		reason      = fs.String("reason", "", "")
+	)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	f := port.OutboxDeadLetterFilter{Limit: *limit, Offset: *offset}
+	for _, s := range strings.Split(*ids, ",") {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+		id, err := uuid.Parse(s)
+		if err != nil {
+			return fmt.Errorf("-ids: %w", err)
+		}
+		f.IDs = append(f.IDs, id)
+	}
+	if *aggregateID != "" {
+		f.AggregateID = aggregateID
+	}
+	if *eventType != "" {
+		f.EventType = eventType
+	}
+	var err error
+	if f.From, err = parseTimeFlag("from", *from); err != nil {
+		return err
+	}
+	if f.To, err = parseTimeFlag("to", *to); err != nil {
+		return err
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return fmt.Errorf("open write db: %w", err)
+	}
+	defer db.Close()
+	store := pgoutbox.NewStore(db)
+
+	switch action {
+	case "list":
+		items, err := store.ListDeadLetters(ctx, f)
+		if err != nil {
+			return err
+		}
+		enc := json.NewEncoder(os.Stdout)
+		for _, dl := range items {
+			if err := enc.Encode(dl); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "requeue":
+		n, err := store.RequeueDeadLetters(ctx, f, "cli:"+*actor, *reason)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("requeued %d dead letter(s)\n", n)
+		return nil
+	case "purge":
+		n, err := store.PurgeDeadLetters(ctx, f, "cli:"+*actor, *reason)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("purged %d dead letter(s)\n", n)
+		return nil
+	default:
+		return fmt.Errorf("unknown dlq action %q\n%s", action, dlqUsage)
+	}
+}
+
+func parseTimeFlag(name, v string) (*time.Time, error) {
+	if v == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return nil, fmt.Errorf("-%s: %w", name, err)
+	}
+	return &t, nil
+}
+
+func defaultActor() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	return "unknown"
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"expvar"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	_ "github.com/lib/pq"
+
+	"github.com/petri-board-arena/internal/infrastructure/config"
+	"github.com/petri-board-arena/internal/infrastructure/messaging"
+	kafkaproducer "github.com/petri-board-arena/internal/infrastructure/messaging/kafka"
+	pgoutbox "github.com/petri-board-arena/internal/infrastructure/persistence/postgres/outbox"
+	"github.com/petri-board-arena/internal/infrastructure/relay"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// outbox-relay dlq <list|requeue|purge> ...: admin de dead letters, não sobe o relay
+	// (as flags são do subcomando; a config vem de env/CONFIG_FILE)
+	if len(os.Args) > 1 && os.Args[1] == "dlq" {
+		cfg, err := config.Load(config.ServiceRelay, nil)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := runDLQ(ctx, cfg.Postgres.URL, os.Args[2:]); err != nil {
+			log.Fatalf("dlq: %v", err)
+		}
+		return
+	}
+
+	cfg, err := config.Load(config.ServiceRelay, os.Args[1:])
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("[outbox-relay] effective config:")
+	cfg.Report(log.Writer())
+
+	db, err := sql.Open("postgres", cfg.Postgres.URL)
+	if err != nil {
+		log.Fatalf("open write db: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.PingContext(ctx); err != nil {
+		log.Fatalf("ping write db: %v", err)
+	}
+
+	codec, err := messaging.NewEnvelopeCodec(cfg.Kafka.Encoding)
+	if err != nil {
+		log.Fatal(err)
+	}
+	producer := kafkaproducer.NewProducer(kafkaproducer.ProducerConfig{
+		Brokers:      cfg.Kafka.Brokers,
+		BatchTimeout: cfg.Kafka.BatchTimeout,
+		WriteTimeout: cfg.Kafka.WriteTimeout,
+		Codec:        codec,
+	})
+	defer producer.Close()
+
+	r := relay.New(pgoutbox.NewStore(db), producer, relay.Config{
+		WorkerID:     cfg.Relay.WorkerID,
+		BatchSize:    cfg.Relay.BatchSize,
+		LockTTL:      cfg.Relay.LockTTL,
+		PollInterval: cfg.Relay.PollInterval,
+		BaseBackoff:  cfg.Relay.BaseBackoff,
+	})
+
+	if cfg.Relay.Listen {
+		listener, err := pgoutbox.NewListener(cfg.Postgres.URL, cfg.Relay.ListenMinReconnect, cfg.Relay.ListenMaxReconnect)
+		if err != nil {
+			log.Printf("[outbox-relay] listen %s: %v; using interval polling only", pgoutbox.NotifyChannel, err)
+		} else {
+			defer listener.Close()
+			r.WakeOn(listener.Wakeups())
+		}
+	}
+
+	if cfg.Janitor.Interval > 0 {
+		janitor, err := pgoutbox.NewJanitor(db, pgoutbox.JanitorConfig{
+			Mode:             pgoutbox.JanitorMode(cfg.Janitor.Mode),
+			Retention:        cfg.Janitor.Retention,
+			BatchSize:        cfg.Janitor.BatchSize,
+			MaxBatches:       cfg.Janitor.MaxBatches,
+			ArchiveRetention: cfg.Janitor.ArchiveRetention,
+		})
+		if err != nil {
+			log.Fatalf("config(janitor): %v", err)
+		}
+		go relay.RunJanitor(ctx, janitor, cfg.Janitor.Interval)
+	}
+
+	if cfg.Metrics.Addr != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/debug/vars", expvar.Handler())
+		go func() {
+			log.Printf("[outbox-relay] metrics at http://%s/debug/vars", cfg.Metrics.Addr)
+			if err := http.ListenAndServe(cfg.Metrics.Addr, mux); err != nil {
+				log.Printf("[outbox-relay] metrics server: %v", err)
+			}
+		}()
+	}
+
+	if err := r.Run(ctx); err != nil {
+		log.Fatalf("outbox relay stopped with error: %v", err)
+	}
+}
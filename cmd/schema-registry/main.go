@@ -0,0 +1,27 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/petri-board-arena/internal/infrastructure/messaging"
+	"github.com/petri-board-arena/internal/infrastructure/messaging/schemaregistry"
+)
+
+// schema-registry confere os codecs de messaging.ArenaCodecs contra o registry em
+// arquivos (-dir). Sem -write falha se algum schema mudou de forma incompatível ou
+// não foi registrado; com -write registra versões novas e mudanças compatíveis.
+func main() {
+	fs := flag.NewFlagSet("schema-registry", flag.ExitOnError)
+	dir := fs.String("dir", "schemas/events", "diretório do registry")
+	write := fs.Bool("write", false, "registra versões novas e atualiza as compatíveis")
+	_ = fs.Parse(os.Args[1:])
+
+	reg := schemaregistry.NewFileRegistry(*dir)
+	if err := reg.CheckCodecs(messaging.ArenaCodecs(), *write); err != nil {
+		log.Fatalf("[schema-registry] %v", err)
+	}
+	log.Printf("[schema-registry] %d event schema(s) compatible with %s (write=%t)",
+		len(messaging.ArenaCodecs().Codecs()), *dir, *write)
+}
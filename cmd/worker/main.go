@@ -7,35 +7,34 @@ import (
 	"os/signal"
 	"syscall"
 
-	workerConfig "github.com/petri-board-arena/internal/infrastructure/config"
+	"github.com/petri-board-arena/internal/infrastructure/config"
 	kafkaconsumer "github.com/petri-board-arena/internal/infrastructure/messaging/kafka"
 	infraredis "github.com/petri-board-arena/internal/infrastructure/persistence/redis"
 	"github.com/petri-board-arena/internal/infrastructure/projector"
 )
 
 func main() {
-	workerCfg, err := workerConfig.LoadConfig()
+	cfg, err := config.Load(config.ServiceWorker, os.Args[1:])
 	if err != nil {
-		log.Fatalf("config(worker): %v", err)
-	}
-
-	consumerCfg, err := kafkaconsumer.LoadConsumerConfig()
-	if err != nil {
-		log.Fatalf("config(consumer): %v", err)
+		log.Fatal(err)
 	}
+	log.Printf("[worker] effective config:")
+	cfg.Report(log.Writer())
 
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
-	rdb, err := infraredis.NewRedisClient(workerCfg.RedisURL)
+	rdb, err := infraredis.NewRedisClient(cfg.Redis.URL)
 	if err != nil {
 		log.Fatalf("redis: %v", err)
 	}
 	defer rdb.Close()
 
-	proj := projector.NewProjector(rdb, workerCfg)
+	proj := projector.NewProjector(rdb, cfg.Worker)
 
-	consumer := kafkaconsumer.NewConsumer(consumerCfg, proj)
+	// ordem por arena nos tiers de retry: eventos seguintes esperam o que está em retry
+	holds := kafkaconsumer.NewRetryHolds(rdb, cfg.Kafka.RetryTiers)
+	consumer := kafkaconsumer.NewConsumer(kafkaconsumer.NewConsumerConfig(cfg.Kafka), proj, holds)
 	defer consumer.Close()
 
 	if err := consumer.Run(ctx); err != nil {
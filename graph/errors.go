@@ -0,0 +1,133 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"github.com/petri-board-arena/internal/application/port"
+	"github.com/petri-board-arena/internal/application/port/repository"
+	"github.com/petri-board-arena/internal/domain/arena"
+	"github.com/petri-board-arena/internal/runtime/requestctx"
+)
+
+// Códigos estáveis expostos em extensions.code.
+const (
+	CodeUnauthenticated = "UNAUTHENTICATED"
+	CodeNotFound        = "NOT_FOUND"
+	CodeForbidden       = "FORBIDDEN"
+	CodeConflict        = "CONFLICT"
+	CodeInvalidArgument = "INVALID_ARGUMENT"
+	CodeInternal        = "INTERNAL"
+)
+
+// ErrAdminRequired: operação de ops sem X-Admin-Token válido (ou admin desabilitado).
+var ErrAdminRequired = errors.New("admin token required")
+
+// ErrUnauthenticated: operação de jogador sem token de sessão válido (Authorization: Bearer).
+var ErrUnauthenticated = errors.New("session token required")
+
+var errorCodes = []struct {
+	err  error
+	code string
+}{
+	{ErrUnauthenticated, CodeUnauthenticated},
+
+	{repository.ErrArenaNotFound, CodeNotFound},
+	{arena.ErrPlayerNotFound, CodeNotFound},
+
+	{arena.ErrPermissionDenied, CodeForbidden},
+	{ErrAdminRequired, CodeForbidden},
+
+	{repository.ErrConcurrencyConflict, CodeConflict},
+	{arena.ErrArenaNotRunning, CodeConflict},
+	{arena.ErrArenaNotPaused, CodeConflict},
+	{arena.ErrArenaNotPending, CodeConflict},
+	{arena.ErrArenaFinished, CodeConflict},
+	{arena.ErrPlayerAlreadyJoined, CodeConflict},
+	{arena.ErrArenaFull, CodeConflict},
+	{arena.ErrActionQuotaExceeded, CodeConflict},
+	{arena.ErrConfigChangeLocked, CodeConflict},
+
+	{arena.ErrInvalidName, CodeInvalidArgument},
+	{arena.ErrInvalidConfig, CodeInvalidArgument},
+	{arena.ErrInvalidDisplayName, CodeInvalidArgument},
+	{arena.ErrInvalidAction, CodeInvalidArgument},
+	{arena.ErrApplyAtTickTooOld, CodeInvalidArgument},
+	{port.ErrEmptyDeadLetterFilter, CodeInvalidArgument},
+}
+
+// Motivos estáveis de SubmitActionPayload.reason (accepted=false): o texto do erro
+// carrega detalhes internos (wrapping do comando, payload) e não vai para o cliente.
+const (
+	ReasonInvalidAction       = "INVALID_ACTION"
+	ReasonApplyAtTickTooOld   = "APPLY_AT_TICK_TOO_OLD"
+	ReasonActionQuotaExceeded = "ACTION_QUOTA_EXCEEDED"
+)
+
+var actionRejectReasons = []struct {
+	err    error
+	reason string
+}{
+	{arena.ErrInvalidAction, ReasonInvalidAction},
+	{arena.ErrApplyAtTickTooOld, ReasonApplyAtTickTooOld},
+	{arena.ErrActionQuotaExceeded, ReasonActionQuotaExceeded},
+}
+
+// actionRejectReason diz se err rejeita a ação em si (accepted=false) e com qual motivo.
+func actionRejectReason(err error) (string, bool) {
+	for _, r := range actionRejectReasons {
+		if errors.Is(err, r.err) {
+			return r.reason, true
+		}
+	}
+	return "", false
+}
+
+func errorCode(err error) string {
+	for _, ec := range errorCodes {
+		if errors.Is(err, ec.err) {
+			return ec.code
+		}
+	}
+	return CodeInternal
+}
+
+// ErrorPresenter é instalado no handler do gqlgen (srv.SetErrorPresenter).
+// Os resolvers retornam os erros da aplicação como estão; aqui eles são
+// desembrulhados com errors.Is e ganham extensions.code estável e, para erros
+// de validação de config, extensions.field com o caminho do campo no input.
+// Erros sem código conhecido (INTERNAL) não expõem a mensagem original.
+func ErrorPresenter(ctx context.Context, err error) *gqlerror.Error {
+	gerr := graphql.DefaultErrorPresenter(ctx, err)
+	if gerr.Extensions == nil {
+		gerr.Extensions = map[string]any{}
+	}
+	// erros do próprio gqlgen (parse/validation) já vêm com code
+	if _, ok := gerr.Extensions["code"]; ok {
+		return gerr
+	}
+
+	code := errorCode(err)
+	gerr.Extensions["code"] = code
+
+	// erro inesperado (pq, Redis, ...): o texto fica no log, o cliente recebe só o requestId
+	if code == CodeInternal {
+		requestID, _ := requestctx.CausationIDFrom(ctx)
+		log.Printf("[graphql] internal error request=%s path=%v: %v", requestID, gerr.Path, err)
+		gerr.Message = "internal error"
+		if requestID != "" {
+			gerr.Extensions["requestId"] = requestID
+		}
+		return gerr
+	}
+
+	var fe *arena.FieldError
+	if errors.As(err, &fe) {
+		gerr.Extensions["field"] = "config." + fe.Field
+	}
+	return gerr
+}
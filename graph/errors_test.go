@@ -0,0 +1,88 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/petri-board-arena/internal/domain/arena"
+	"github.com/petri-board-arena/internal/runtime/requestctx"
+)
+
+func TestErrorPresenter(t *testing.T) {
+	ctx := requestctx.WithCausationID(context.Background(), "req-1")
+
+	cases := map[string]struct {
+		err       error
+		code      string
+		message   string
+		requestID any
+	}{
+		"domain": {
+			err:     fmt.Errorf("start: %w", arena.ErrPermissionDenied),
+			code:    CodeForbidden,
+			message: "start: " + arena.ErrPermissionDenied.Error(),
+		},
+		"unauthenticated": {
+			err:     ErrUnauthenticated,
+			code:    CodeUnauthenticated,
+			message: ErrUnauthenticated.Error(),
+		},
+		"internal": {
+			err:       errors.New(`pq: relation "arena" does not exist`),
+			code:      CodeInternal,
+			message:   "internal error",
+			requestID: "req-1",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			gerr := ErrorPresenter(ctx, tc.err)
+			if gerr.Message != tc.message {
+				t.Errorf("message = %q, want %q", gerr.Message, tc.message)
+			}
+			if gerr.Extensions["code"] != tc.code {
+				t.Errorf("code = %v, want %s", gerr.Extensions["code"], tc.code)
+			}
+			if gerr.Extensions["requestId"] != tc.requestID {
+				t.Errorf("requestId = %v, want %v", gerr.Extensions["requestId"], tc.requestID)
+			}
+		})
+	}
+}
+
+func TestActionRejectReason(t *testing.T) {
+	cases := map[string]struct {
+		err    error
+		reason string
+		ok     bool
+	}{
+		"invalid payload": {
+			err:    fmt.Errorf("submit_action: domain reject: %w: area out of bounds (9,0 2x1) for grid 10x10", arena.ErrInvalidAction),
+			reason: ReasonInvalidAction,
+			ok:     true,
+		},
+		"past tick": {
+			err:    fmt.Errorf("submit_action: domain reject: %w", arena.ErrApplyAtTickTooOld),
+			reason: ReasonApplyAtTickTooOld,
+			ok:     true,
+		},
+		"quota": {
+			err:    fmt.Errorf("submit_action: domain reject: %w", arena.ErrActionQuotaExceeded),
+			reason: ReasonActionQuotaExceeded,
+			ok:     true,
+		},
+		"not a rejection": {
+			err: fmt.Errorf("submit_action: domain reject: %w", arena.ErrArenaNotRunning),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			reason, ok := actionRejectReason(tc.err)
+			if reason != tc.reason || ok != tc.ok {
+				t.Errorf("reason = %q, %v, want %q, %v", reason, ok, tc.reason, tc.ok)
+			}
+		})
+	}
+}
@@ -93,7 +93,10 @@ type ComplexityRoot struct {
 	ArenaConfig struct {
 		DiffusionRate      func(childComplexity int) int
 		Height             func(childComplexity int) int
+		MaxActionsPerTick  func(childComplexity int) int
 		MaxOrganisms       func(childComplexity int) int
+		MaxPlayers         func(childComplexity int) int
+		MaxTicks           func(childComplexity int) int
 		MutationRate       func(childComplexity int) int
 		SnapshotEveryTicks func(childComplexity int) int
 		Temperature        func(childComplexity int) int
@@ -224,15 +227,17 @@ type ComplexityRoot struct {
 	}
 
 	Mutation struct {
-		CreateArena    func(childComplexity int, input model.CreateArenaInput) int
-		JoinArena      func(childComplexity int, input model.JoinArenaInput) int
-		LeaveArena     func(childComplexity int, input model.LeaveArenaInput) int
-		PauseArena     func(childComplexity int, input model.PauseArenaInput) int
-		ResumeArena    func(childComplexity int, input model.ResumeArenaInput) int
-		SetArenaConfig func(childComplexity int, input model.SetArenaConfigInput) int
-		StartArena     func(childComplexity int, input model.StartArenaInput) int
-		StopArena      func(childComplexity int, input model.StopArenaInput) int
-		SubmitAction   func(childComplexity int, input model.SubmitActionInput) int
+		CreateArena              func(childComplexity int, input model.CreateArenaInput) int
+		JoinArena                func(childComplexity int, input model.JoinArenaInput) int
+		LeaveArena               func(childComplexity int, input model.LeaveArenaInput) int
+		PauseArena               func(childComplexity int, input model.PauseArenaInput) int
+		PurgeOutboxDeadLetters   func(childComplexity int, input model.OutboxDeadLetterOpInput) int
+		RequeueOutboxDeadLetters func(childComplexity int, input model.OutboxDeadLetterOpInput) int
+		ResumeArena              func(childComplexity int, input model.ResumeArenaInput) int
+		SetArenaConfig           func(childComplexity int, input model.SetArenaConfigInput) int
+		StartArena               func(childComplexity int, input model.StartArenaInput) int
+		StopArena                func(childComplexity int, input model.StopArenaInput) int
+		SubmitAction             func(childComplexity int, input model.SubmitActionInput) int
 	}
 
 	Organism struct {
@@ -247,6 +252,27 @@ type ComplexityRoot struct {
 		Position  func(childComplexity int) int
 	}
 
+	OutboxDeadLetter struct {
+		AggregateID    func(childComplexity int) int
+		AggregateType  func(childComplexity int) int
+		Attempts       func(childComplexity int) int
+		CausationID    func(childComplexity int) int
+		CorrelationID  func(childComplexity int) int
+		CreatedAt      func(childComplexity int) int
+		EventType      func(childComplexity int) int
+		Headers        func(childComplexity int) int
+		ID             func(childComplexity int) int
+		IdempotencyKey func(childComplexity int) int
+		LastError      func(childComplexity int) int
+		OutboxEventID  func(childComplexity int) int
+		Payload        func(childComplexity int) int
+		Topic          func(childComplexity int) int
+	}
+
+	OutboxDeadLetterOpPayload struct {
+		Affected func(childComplexity int) int
+	}
+
 	PauseArenaPayload struct {
 		Arena func(childComplexity int) int
 		Ok    func(childComplexity int) int
@@ -275,15 +301,16 @@ type ComplexityRoot struct {
 	}
 
 	Query struct {
-		Arena         func(childComplexity int, id uuid.UUID) int
-		ArenaHistory  func(childComplexity int, arenaID uuid.UUID, fromTick int64, toTick int64, mode *model.DiffMode) int
-		ArenaSnapshot func(childComplexity int, arenaID uuid.UUID, atTick int64) int
-		Arenas        func(childComplexity int, filter *model.ArenaFilter, page *model.PageInput) int
-		Genome        func(childComplexity int, id uuid.UUID) int
-		Health        func(childComplexity int) int
-		Leaderboard   func(childComplexity int, arenaID uuid.UUID, top *int32) int
-		Metrics       func(childComplexity int, arenaID uuid.UUID, windowSeconds *int32) int
-		Organism      func(childComplexity int, id uuid.UUID) int
+		Arena             func(childComplexity int, id uuid.UUID) int
+		ArenaHistory      func(childComplexity int, arenaID uuid.UUID, fromTick int64, toTick int64, mode *model.DiffMode) int
+		ArenaSnapshot     func(childComplexity int, arenaID uuid.UUID, atTick int64) int
+		Arenas            func(childComplexity int, filter *model.ArenaFilter, page *model.PageInput) int
+		Genome            func(childComplexity int, id uuid.UUID) int
+		Health            func(childComplexity int) int
+		Leaderboard       func(childComplexity int, arenaID uuid.UUID, top *int32) int
+		Metrics           func(childComplexity int, arenaID uuid.UUID, windowSeconds *int32) int
+		Organism          func(childComplexity int, id uuid.UUID) int
+		OutboxDeadLetters func(childComplexity int, filter *model.OutboxDeadLetterFilter, page *model.PageInput) int
 	}
 
 	ResumeArenaPayload struct {
@@ -372,6 +399,8 @@ type MutationResolver interface {
 	LeaveArena(ctx context.Context, input model.LeaveArenaInput) (*model.LeaveArenaPayload, error)
 	SubmitAction(ctx context.Context, input model.SubmitActionInput) (*model.SubmitActionPayload, error)
 	SetArenaConfig(ctx context.Context, input model.SetArenaConfigInput) (*model.SetArenaConfigPayload, error)
+	RequeueOutboxDeadLetters(ctx context.Context, input model.OutboxDeadLetterOpInput) (*model.OutboxDeadLetterOpPayload, error)
+	PurgeOutboxDeadLetters(ctx context.Context, input model.OutboxDeadLetterOpInput) (*model.OutboxDeadLetterOpPayload, error)
 }
 type QueryResolver interface {
 	Health(ctx context.Context) (*model.Health, error)
@@ -383,6 +412,7 @@ type QueryResolver interface {
 	Metrics(ctx context.Context, arenaID uuid.UUID, windowSeconds *int32) (*model.ArenaMetrics, error)
 	Organism(ctx context.Context, id uuid.UUID) (*model.Organism, error)
 	Genome(ctx context.Context, id uuid.UUID) (*model.Genome, error)
+	OutboxDeadLetters(ctx context.Context, filter *model.OutboxDeadLetterFilter, page *model.PageInput) ([]*model.OutboxDeadLetter, error)
 }
 type SubscriptionResolver interface {
 	ArenaEvents(ctx context.Context, arenaID uuid.UUID) (<-chan model.ArenaEvent, error)
@@ -576,12 +606,30 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.ArenaConfig.Height(childComplexity), true
+	case "ArenaConfig.maxActionsPerTick":
+		if e.complexity.ArenaConfig.MaxActionsPerTick == nil {
+			break
+		}
+
+		return e.complexity.ArenaConfig.MaxActionsPerTick(childComplexity), true
 	case "ArenaConfig.maxOrganisms":
 		if e.complexity.ArenaConfig.MaxOrganisms == nil {
 			break
 		}
 
 		return e.complexity.ArenaConfig.MaxOrganisms(childComplexity), true
+	case "ArenaConfig.maxPlayers":
+		if e.complexity.ArenaConfig.MaxPlayers == nil {
+			break
+		}
+
+		return e.complexity.ArenaConfig.MaxPlayers(childComplexity), true
+	case "ArenaConfig.maxTicks":
+		if e.complexity.ArenaConfig.MaxTicks == nil {
+			break
+		}
+
+		return e.complexity.ArenaConfig.MaxTicks(childComplexity), true
 	case "ArenaConfig.mutationRate":
 		if e.complexity.ArenaConfig.MutationRate == nil {
 			break
@@ -1100,6 +1148,28 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.Mutation.PauseArena(childComplexity, args["input"].(model.PauseArenaInput)), true
+	case "Mutation.purgeOutboxDeadLetters":
+		if e.complexity.Mutation.PurgeOutboxDeadLetters == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_purgeOutboxDeadLetters_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.PurgeOutboxDeadLetters(childComplexity, args["input"].(model.OutboxDeadLetterOpInput)), true
+	case "Mutation.requeueOutboxDeadLetters":
+		if e.complexity.Mutation.RequeueOutboxDeadLetters == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_requeueOutboxDeadLetters_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.RequeueOutboxDeadLetters(childComplexity, args["input"].(model.OutboxDeadLetterOpInput)), true
 	case "Mutation.resumeArena":
 		if e.complexity.Mutation.ResumeArena == nil {
 			break
@@ -1211,6 +1281,98 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.complexity.Organism.Position(childComplexity), true
 
+	case "OutboxDeadLetter.aggregateId":
+		if e.complexity.OutboxDeadLetter.AggregateID == nil {
+			break
+		}
+
+		return e.complexity.OutboxDeadLetter.AggregateID(childComplexity), true
+	case "OutboxDeadLetter.aggregateType":
+		if e.complexity.OutboxDeadLetter.AggregateType == nil {
+			break
+		}
+
+		return e.complexity.OutboxDeadLetter.AggregateType(childComplexity), true
+	case "OutboxDeadLetter.attempts":
+		if e.complexity.OutboxDeadLetter.Attempts == nil {
+			break
+		}
+
+		return e.complexity.OutboxDeadLetter.Attempts(childComplexity), true
+	case "OutboxDeadLetter.causationId":
+		if e.complexity.OutboxDeadLetter.CausationID == nil {
+			break
+		}
+
+		return e.complexity.OutboxDeadLetter.CausationID(childComplexity), true
+	case "OutboxDeadLetter.correlationId":
+		if e.complexity.OutboxDeadLetter.CorrelationID == nil {
+			break
+		}
+
+		return e.complexity.OutboxDeadLetter.CorrelationID(childComplexity), true
+	case "OutboxDeadLetter.createdAt":
+		if e.complexity.OutboxDeadLetter.CreatedAt == nil {
+			break
+		}
+
+		return e.complexity.OutboxDeadLetter.CreatedAt(childComplexity), true
+	case "OutboxDeadLetter.eventType":
+		if e.complexity.OutboxDeadLetter.EventType == nil {
+			break
+		}
+
+		return e.complexity.OutboxDeadLetter.EventType(childComplexity), true
+	case "OutboxDeadLetter.headers":
+		if e.complexity.OutboxDeadLetter.Headers == nil {
+			break
+		}
+
+		return e.complexity.OutboxDeadLetter.Headers(childComplexity), true
+	case "OutboxDeadLetter.id":
+		if e.complexity.OutboxDeadLetter.ID == nil {
+			break
+		}
+
+		return e.complexity.OutboxDeadLetter.ID(childComplexity), true
+	case "OutboxDeadLetter.idempotencyKey":
+		if e.complexity.OutboxDeadLetter.IdempotencyKey == nil {
+			break
+		}
+
+		return e.complexity.OutboxDeadLetter.IdempotencyKey(childComplexity), true
+	case "OutboxDeadLetter.lastError":
+		if e.complexity.OutboxDeadLetter.LastError == nil {
+			break
+		}
+
+		return e.complexity.OutboxDeadLetter.LastError(childComplexity), true
+	case "OutboxDeadLetter.outboxEventId":
+		if e.complexity.OutboxDeadLetter.OutboxEventID == nil {
+			break
+		}
+
+		return e.complexity.OutboxDeadLetter.OutboxEventID(childComplexity), true
+	case "OutboxDeadLetter.payload":
+		if e.complexity.OutboxDeadLetter.Payload == nil {
+			break
+		}
+
+		return e.complexity.OutboxDeadLetter.Payload(childComplexity), true
+	case "OutboxDeadLetter.topic":
+		if e.complexity.OutboxDeadLetter.Topic == nil {
+			break
+		}
+
+		return e.complexity.OutboxDeadLetter.Topic(childComplexity), true
+
+	case "OutboxDeadLetterOpPayload.affected":
+		if e.complexity.OutboxDeadLetterOpPayload.Affected == nil {
+			break
+		}
+
+		return e.complexity.OutboxDeadLetterOpPayload.Affected(childComplexity), true
+
 	case "PauseArenaPayload.arena":
 		if e.complexity.PauseArenaPayload.Arena == nil {
 			break
@@ -1399,6 +1561,17 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.Query.Organism(childComplexity, args["id"].(uuid.UUID)), true
+	case "Query.outboxDeadLetters":
+		if e.complexity.Query.OutboxDeadLetters == nil {
+			break
+		}
+
+		args, err := ec.field_Query_outboxDeadLetters_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.OutboxDeadLetters(childComplexity, args["filter"].(*model.OutboxDeadLetterFilter), args["page"].(*model.PageInput)), true
 
 	case "ResumeArenaPayload.arena":
 		if e.complexity.ResumeArenaPayload.Arena == nil {
@@ -1660,6 +1833,8 @@ func (e *executableSchema) Exec(ctx context.Context) graphql.ResponseHandler {
 		ec.unmarshalInputDropAntibioticInput,
 		ec.unmarshalInputJoinArenaInput,
 		ec.unmarshalInputLeaveArenaInput,
+		ec.unmarshalInputOutboxDeadLetterFilter,
+		ec.unmarshalInputOutboxDeadLetterOpInput,
 		ec.unmarshalInputPageInput,
 		ec.unmarshalInputPauseArenaInput,
 		ec.unmarshalInputPointInput,
@@ -1784,7 +1959,7 @@ func (ec *executionContext) introspectType(name string) (*introspection.Type, er
 	return introspection.WrapTypeFromDef(ec.Schema(), ec.Schema().Types[name]), nil
 }
 
-//go:embed "schema.events.graphqls" "schema.inputs.graphqls" "schema.root.graphqls" "schema.scalars_enums.graphqls" "schema.types.graphqls"
+//go:embed "schema.admin.graphqls" "schema.events.graphqls" "schema.inputs.graphqls" "schema.root.graphqls" "schema.scalars_enums.graphqls" "schema.types.graphqls"
 var sourcesFS embed.FS
 
 func sourceData(filename string) string {
@@ -1796,6 +1971,7 @@ func sourceData(filename string) string {
 }
 
 var sources = []*ast.Source{
+	{Name: "schema.admin.graphqls", Input: sourceData("schema.admin.graphqls"), BuiltIn: false},
 	{Name: "schema.events.graphqls", Input: sourceData("schema.events.graphqls"), BuiltIn: false},
 	{Name: "schema.inputs.graphqls", Input: sourceData("schema.inputs.graphqls"), BuiltIn: false},
 	{Name: "schema.root.graphqls", Input: sourceData("schema.root.graphqls"), BuiltIn: false},
@@ -1852,6 +2028,28 @@ func (ec *executionContext) field_Mutation_pauseArena_args(ctx context.Context,
 	return args, nil
 }
 
+func (ec *executionContext) field_Mutation_purgeOutboxDeadLetters_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "input", ec.unmarshalNOutboxDeadLetterOpInput2githubᚗcomᚋpetriᚑboardᚑarenaᚋgraphᚋmodelᚐOutboxDeadLetterOpInput)
+	if err != nil {
+		return nil, err
+	}
+	args["input"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Mutation_requeueOutboxDeadLetters_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "input", ec.unmarshalNOutboxDeadLetterOpInput2githubᚗcomᚋpetriᚑboardᚑarenaᚋgraphᚋmodelᚐOutboxDeadLetterOpInput)
+	if err != nil {
+		return nil, err
+	}
+	args["input"] = arg0
+	return args, nil
+}
+
 func (ec *executionContext) field_Mutation_resumeArena_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
@@ -2041,6 +2239,22 @@ func (ec *executionContext) field_Query_organism_args(ctx context.Context, rawAr
 	return args, nil
 }
 
+func (ec *executionContext) field_Query_outboxDeadLetters_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "filter", ec.unmarshalOOutboxDeadLetterFilter2ᚖgithubᚗcomᚋpetriᚑboardᚑarenaᚋgraphᚋmodelᚐOutboxDeadLetterFilter)
+	if err != nil {
+		return nil, err
+	}
+	args["filter"] = arg0
+	arg1, err := graphql.ProcessArgField(ctx, rawArgs, "page", ec.unmarshalOPageInput2ᚖgithubᚗcomᚋpetriᚑboardᚑarenaᚋgraphᚋmodelᚐPageInput)
+	if err != nil {
+		return nil, err
+	}
+	args["page"] = arg1
+	return args, nil
+}
+
 func (ec *executionContext) field_Subscription_arenaEvents_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
@@ -2811,6 +3025,12 @@ func (ec *executionContext) fieldContext_Arena_config(_ context.Context, field g
 				return ec.fieldContext_ArenaConfig_snapshotEveryTicks(ctx, field)
 			case "temperature":
 				return ec.fieldContext_ArenaConfig_temperature(ctx, field)
+			case "maxTicks":
+				return ec.fieldContext_ArenaConfig_maxTicks(ctx, field)
+			case "maxPlayers":
+				return ec.fieldContext_ArenaConfig_maxPlayers(ctx, field)
+			case "maxActionsPerTick":
+				return ec.fieldContext_ArenaConfig_maxActionsPerTick(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type ArenaConfig", field.Name)
 		},
@@ -3177,6 +3397,93 @@ func (ec *executionContext) fieldContext_ArenaConfig_temperature(_ context.Conte
 	return fc, nil
 }
 
+func (ec *executionContext) _ArenaConfig_maxTicks(ctx context.Context, field graphql.CollectedField, obj *model.ArenaConfig) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_ArenaConfig_maxTicks,
+		func(ctx context.Context) (any, error) {
+			return obj.MaxTicks, nil
+		},
+		nil,
+		ec.marshalNLong2int64,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_ArenaConfig_maxTicks(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ArenaConfig",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Long does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ArenaConfig_maxPlayers(ctx context.Context, field graphql.CollectedField, obj *model.ArenaConfig) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_ArenaConfig_maxPlayers,
+		func(ctx context.Context) (any, error) {
+			return obj.MaxPlayers, nil
+		},
+		nil,
+		ec.marshalNInt2int32,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_ArenaConfig_maxPlayers(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ArenaConfig",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ArenaConfig_maxActionsPerTick(ctx context.Context, field graphql.CollectedField, obj *model.ArenaConfig) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_ArenaConfig_maxActionsPerTick,
+		func(ctx context.Context) (any, error) {
+			return obj.MaxActionsPerTick, nil
+		},
+		nil,
+		ec.marshalNInt2int32,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_ArenaConfig_maxActionsPerTick(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ArenaConfig",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _ArenaLifecycleEvent_arenaId(ctx context.Context, field graphql.CollectedField, obj *model.ArenaLifecycleEvent) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
@@ -5412,6 +5719,10 @@ func (ec *executionContext) fieldContext_Lineage_mutations(_ context.Context, fi
 				return ec.fieldContext_Mutation_submitAction(ctx, field)
 			case "setArenaConfig":
 				return ec.fieldContext_Mutation_setArenaConfig(ctx, field)
+			case "requeueOutboxDeadLetters":
+				return ec.fieldContext_Mutation_requeueOutboxDeadLetters(ctx, field)
+			case "purgeOutboxDeadLetters":
+				return ec.fieldContext_Mutation_purgeOutboxDeadLetters(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type Mutation", field.Name)
 		},
@@ -5842,101 +6153,391 @@ func (ec *executionContext) fieldContext_Mutation_setArenaConfig(ctx context.Con
 	return fc, nil
 }
 
-func (ec *executionContext) _Organism_id(ctx context.Context, field graphql.CollectedField, obj *model.Organism) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_requeueOutboxDeadLetters(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Organism_id,
+		ec.fieldContext_Mutation_requeueOutboxDeadLetters,
 		func(ctx context.Context) (any, error) {
-			return obj.ID, nil
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().RequeueOutboxDeadLetters(ctx, fc.Args["input"].(model.OutboxDeadLetterOpInput))
 		},
 		nil,
-		ec.marshalNUUID2githubᚗcomᚋgoogleᚋuuidᚐUUID,
+		ec.marshalNOutboxDeadLetterOpPayload2ᚖgithubᚗcomᚋpetriᚑboardᚑarenaᚋgraphᚋmodelᚐOutboxDeadLetterOpPayload,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Organism_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_requeueOutboxDeadLetters(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Organism",
+		Object:     "Mutation",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type UUID does not have child fields")
+			switch field.Name {
+			case "affected":
+				return ec.fieldContext_OutboxDeadLetterOpPayload_affected(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type OutboxDeadLetterOpPayload", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_requeueOutboxDeadLetters_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Organism_kind(ctx context.Context, field graphql.CollectedField, obj *model.Organism) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_purgeOutboxDeadLetters(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Organism_kind,
+		ec.fieldContext_Mutation_purgeOutboxDeadLetters,
 		func(ctx context.Context) (any, error) {
-			return obj.Kind, nil
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().PurgeOutboxDeadLetters(ctx, fc.Args["input"].(model.OutboxDeadLetterOpInput))
 		},
 		nil,
-		ec.marshalNOrganismKind2githubᚗcomᚋpetriᚑboardᚑarenaᚋgraphᚋmodelᚐOrganismKind,
+		ec.marshalNOutboxDeadLetterOpPayload2ᚖgithubᚗcomᚋpetriᚑboardᚑarenaᚋgraphᚋmodelᚐOutboxDeadLetterOpPayload,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Organism_kind(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_purgeOutboxDeadLetters(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Organism",
+		Object:     "Mutation",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type OrganismKind does not have child fields")
+			switch field.Name {
+			case "affected":
+				return ec.fieldContext_OutboxDeadLetterOpPayload_affected(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type OutboxDeadLetterOpPayload", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_purgeOutboxDeadLetters_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Organism_createdAt(ctx context.Context, field graphql.CollectedField, obj *model.Organism) (ret graphql.Marshaler) {
+func (ec *executionContext) _Organism_id(ctx context.Context, field graphql.CollectedField, obj *model.Organism) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Organism_createdAt,
+		ec.fieldContext_Organism_id,
 		func(ctx context.Context) (any, error) {
-			return obj.CreatedAt, nil
+			return obj.ID, nil
 		},
 		nil,
-		ec.marshalNTime2timeᚐTime,
+		ec.marshalNUUID2githubᚗcomᚋgoogleᚋuuidᚐUUID,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Organism_createdAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Organism_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Organism",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Time does not have child fields")
+			return nil, errors.New("field of type UUID does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Organism_arenaId(ctx context.Context, field graphql.CollectedField, obj *model.Organism) (ret graphql.Marshaler) {
+func (ec *executionContext) _Organism_kind(ctx context.Context, field graphql.CollectedField, obj *model.Organism) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Organism_arenaId,
+		ec.fieldContext_Organism_kind,
 		func(ctx context.Context) (any, error) {
-			return obj.ArenaID, nil
+			return obj.Kind, nil
+		},
+		nil,
+		ec.marshalNOrganismKind2githubᚗcomᚋpetriᚑboardᚑarenaᚋgraphᚋmodelᚐOrganismKind,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Organism_kind(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Organism",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type OrganismKind does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Organism_createdAt(ctx context.Context, field graphql.CollectedField, obj *model.Organism) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Organism_createdAt,
+		func(ctx context.Context) (any, error) {
+			return obj.CreatedAt, nil
+		},
+		nil,
+		ec.marshalNTime2timeᚐTime,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Organism_createdAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Organism",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Time does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Organism_arenaId(ctx context.Context, field graphql.CollectedField, obj *model.Organism) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Organism_arenaId,
+		func(ctx context.Context) (any, error) {
+			return obj.ArenaID, nil
+		},
+		nil,
+		ec.marshalNUUID2githubᚗcomᚋgoogleᚋuuidᚐUUID,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Organism_arenaId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Organism",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type UUID does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Organism_position(ctx context.Context, field graphql.CollectedField, obj *model.Organism) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Organism_position,
+		func(ctx context.Context) (any, error) {
+			return obj.Position, nil
+		},
+		nil,
+		ec.marshalNPoint2ᚖgithubᚗcomᚋpetriᚑboardᚑarenaᚋgraphᚋmodelᚐPoint,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Organism_position(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Organism",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "x":
+				return ec.fieldContext_Point_x(ctx, field)
+			case "y":
+				return ec.fieldContext_Point_y(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Point", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Organism_energy(ctx context.Context, field graphql.CollectedField, obj *model.Organism) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Organism_energy,
+		func(ctx context.Context) (any, error) {
+			return obj.Energy, nil
+		},
+		nil,
+		ec.marshalNFloat2float64,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Organism_energy(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Organism",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Float does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Organism_fitness(ctx context.Context, field graphql.CollectedField, obj *model.Organism) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Organism_fitness,
+		func(ctx context.Context) (any, error) {
+			return obj.Fitness, nil
+		},
+		nil,
+		ec.marshalNFloat2float64,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Organism_fitness(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Organism",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Float does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Organism_genome(ctx context.Context, field graphql.CollectedField, obj *model.Organism) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Organism_genome,
+		func(ctx context.Context) (any, error) {
+			return obj.Genome, nil
+		},
+		nil,
+		ec.marshalNGenome2ᚖgithubᚗcomᚋpetriᚑboardᚑarenaᚋgraphᚋmodelᚐGenome,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Organism_genome(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Organism",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Genome_id(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_Genome_createdAt(ctx, field)
+			case "genes":
+				return ec.fieldContext_Genome_genes(ctx, field)
+			case "signature":
+				return ec.fieldContext_Genome_signature(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Genome", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Organism_lineage(ctx context.Context, field graphql.CollectedField, obj *model.Organism) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Organism_lineage,
+		func(ctx context.Context) (any, error) {
+			return obj.Lineage, nil
+		},
+		nil,
+		ec.marshalNLineage2ᚖgithubᚗcomᚋpetriᚑboardᚑarenaᚋgraphᚋmodelᚐLineage,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Organism_lineage(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Organism",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "organismId":
+				return ec.fieldContext_Lineage_organismId(ctx, field)
+			case "parentId":
+				return ec.fieldContext_Lineage_parentId(ctx, field)
+			case "generation":
+				return ec.fieldContext_Lineage_generation(ctx, field)
+			case "mutations":
+				return ec.fieldContext_Lineage_mutations(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Lineage", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _OutboxDeadLetter_id(ctx context.Context, field graphql.CollectedField, obj *model.OutboxDeadLetter) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_OutboxDeadLetter_id,
+		func(ctx context.Context) (any, error) {
+			return obj.ID, nil
 		},
 		nil,
 		ec.marshalNUUID2githubᚗcomᚋgoogleᚋuuidᚐUUID,
@@ -5945,185 +6546,420 @@ func (ec *executionContext) _Organism_arenaId(ctx context.Context, field graphql
 	)
 }
 
-func (ec *executionContext) fieldContext_Organism_arenaId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_OutboxDeadLetter_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "OutboxDeadLetter",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type UUID does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _OutboxDeadLetter_outboxEventId(ctx context.Context, field graphql.CollectedField, obj *model.OutboxDeadLetter) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_OutboxDeadLetter_outboxEventId,
+		func(ctx context.Context) (any, error) {
+			return obj.OutboxEventID, nil
+		},
+		nil,
+		ec.marshalOUUID2ᚖgithubᚗcomᚋgoogleᚋuuidᚐUUID,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_OutboxDeadLetter_outboxEventId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "OutboxDeadLetter",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type UUID does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _OutboxDeadLetter_aggregateType(ctx context.Context, field graphql.CollectedField, obj *model.OutboxDeadLetter) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_OutboxDeadLetter_aggregateType,
+		func(ctx context.Context) (any, error) {
+			return obj.AggregateType, nil
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_OutboxDeadLetter_aggregateType(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "OutboxDeadLetter",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _OutboxDeadLetter_aggregateId(ctx context.Context, field graphql.CollectedField, obj *model.OutboxDeadLetter) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_OutboxDeadLetter_aggregateId,
+		func(ctx context.Context) (any, error) {
+			return obj.AggregateID, nil
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_OutboxDeadLetter_aggregateId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "OutboxDeadLetter",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _OutboxDeadLetter_eventType(ctx context.Context, field graphql.CollectedField, obj *model.OutboxDeadLetter) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_OutboxDeadLetter_eventType,
+		func(ctx context.Context) (any, error) {
+			return obj.EventType, nil
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_OutboxDeadLetter_eventType(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "OutboxDeadLetter",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _OutboxDeadLetter_topic(ctx context.Context, field graphql.CollectedField, obj *model.OutboxDeadLetter) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_OutboxDeadLetter_topic,
+		func(ctx context.Context) (any, error) {
+			return obj.Topic, nil
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_OutboxDeadLetter_topic(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "OutboxDeadLetter",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _OutboxDeadLetter_payload(ctx context.Context, field graphql.CollectedField, obj *model.OutboxDeadLetter) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_OutboxDeadLetter_payload,
+		func(ctx context.Context) (any, error) {
+			return obj.Payload, nil
+		},
+		nil,
+		ec.marshalNJSON2map,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_OutboxDeadLetter_payload(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "OutboxDeadLetter",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type JSON does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _OutboxDeadLetter_headers(ctx context.Context, field graphql.CollectedField, obj *model.OutboxDeadLetter) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_OutboxDeadLetter_headers,
+		func(ctx context.Context) (any, error) {
+			return obj.Headers, nil
+		},
+		nil,
+		ec.marshalNJSON2map,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_OutboxDeadLetter_headers(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Organism",
+		Object:     "OutboxDeadLetter",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type UUID does not have child fields")
+			return nil, errors.New("field of type JSON does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Organism_position(ctx context.Context, field graphql.CollectedField, obj *model.Organism) (ret graphql.Marshaler) {
+func (ec *executionContext) _OutboxDeadLetter_correlationId(ctx context.Context, field graphql.CollectedField, obj *model.OutboxDeadLetter) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Organism_position,
+		ec.fieldContext_OutboxDeadLetter_correlationId,
 		func(ctx context.Context) (any, error) {
-			return obj.Position, nil
+			return obj.CorrelationID, nil
 		},
 		nil,
-		ec.marshalNPoint2ᚖgithubᚗcomᚋpetriᚑboardᚑarenaᚋgraphᚋmodelᚐPoint,
+		ec.marshalOString2ᚖstring,
 		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_OutboxDeadLetter_correlationId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "OutboxDeadLetter",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _OutboxDeadLetter_causationId(ctx context.Context, field graphql.CollectedField, obj *model.OutboxDeadLetter) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_OutboxDeadLetter_causationId,
+		func(ctx context.Context) (any, error) {
+			return obj.CausationID, nil
+		},
+		nil,
+		ec.marshalOString2ᚖstring,
 		true,
+		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_Organism_position(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_OutboxDeadLetter_causationId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Organism",
+		Object:     "OutboxDeadLetter",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "x":
-				return ec.fieldContext_Point_x(ctx, field)
-			case "y":
-				return ec.fieldContext_Point_y(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Point", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Organism_energy(ctx context.Context, field graphql.CollectedField, obj *model.Organism) (ret graphql.Marshaler) {
+func (ec *executionContext) _OutboxDeadLetter_idempotencyKey(ctx context.Context, field graphql.CollectedField, obj *model.OutboxDeadLetter) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Organism_energy,
+		ec.fieldContext_OutboxDeadLetter_idempotencyKey,
 		func(ctx context.Context) (any, error) {
-			return obj.Energy, nil
+			return obj.IdempotencyKey, nil
 		},
 		nil,
-		ec.marshalNFloat2float64,
+		ec.marshalOString2ᚖstring,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_OutboxDeadLetter_idempotencyKey(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "OutboxDeadLetter",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _OutboxDeadLetter_attempts(ctx context.Context, field graphql.CollectedField, obj *model.OutboxDeadLetter) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_OutboxDeadLetter_attempts,
+		func(ctx context.Context) (any, error) {
+			return obj.Attempts, nil
+		},
+		nil,
+		ec.marshalNInt2int32,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Organism_energy(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_OutboxDeadLetter_attempts(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Organism",
+		Object:     "OutboxDeadLetter",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Float does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Organism_fitness(ctx context.Context, field graphql.CollectedField, obj *model.Organism) (ret graphql.Marshaler) {
+func (ec *executionContext) _OutboxDeadLetter_lastError(ctx context.Context, field graphql.CollectedField, obj *model.OutboxDeadLetter) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Organism_fitness,
+		ec.fieldContext_OutboxDeadLetter_lastError,
 		func(ctx context.Context) (any, error) {
-			return obj.Fitness, nil
+			return obj.LastError, nil
 		},
 		nil,
-		ec.marshalNFloat2float64,
+		ec.marshalNString2string,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Organism_fitness(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_OutboxDeadLetter_lastError(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Organism",
+		Object:     "OutboxDeadLetter",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Float does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Organism_genome(ctx context.Context, field graphql.CollectedField, obj *model.Organism) (ret graphql.Marshaler) {
+func (ec *executionContext) _OutboxDeadLetter_createdAt(ctx context.Context, field graphql.CollectedField, obj *model.OutboxDeadLetter) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Organism_genome,
+		ec.fieldContext_OutboxDeadLetter_createdAt,
 		func(ctx context.Context) (any, error) {
-			return obj.Genome, nil
+			return obj.CreatedAt, nil
 		},
 		nil,
-		ec.marshalNGenome2ᚖgithubᚗcomᚋpetriᚑboardᚑarenaᚋgraphᚋmodelᚐGenome,
+		ec.marshalNTime2timeᚐTime,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Organism_genome(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_OutboxDeadLetter_createdAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Organism",
+		Object:     "OutboxDeadLetter",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_Genome_id(ctx, field)
-			case "createdAt":
-				return ec.fieldContext_Genome_createdAt(ctx, field)
-			case "genes":
-				return ec.fieldContext_Genome_genes(ctx, field)
-			case "signature":
-				return ec.fieldContext_Genome_signature(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Genome", field.Name)
+			return nil, errors.New("field of type Time does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Organism_lineage(ctx context.Context, field graphql.CollectedField, obj *model.Organism) (ret graphql.Marshaler) {
+func (ec *executionContext) _OutboxDeadLetterOpPayload_affected(ctx context.Context, field graphql.CollectedField, obj *model.OutboxDeadLetterOpPayload) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Organism_lineage,
+		ec.fieldContext_OutboxDeadLetterOpPayload_affected,
 		func(ctx context.Context) (any, error) {
-			return obj.Lineage, nil
+			return obj.Affected, nil
 		},
 		nil,
-		ec.marshalNLineage2ᚖgithubᚗcomᚋpetriᚑboardᚑarenaᚋgraphᚋmodelᚐLineage,
+		ec.marshalNInt2int32,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Organism_lineage(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_OutboxDeadLetterOpPayload_affected(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Organism",
+		Object:     "OutboxDeadLetterOpPayload",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "organismId":
-				return ec.fieldContext_Lineage_organismId(ctx, field)
-			case "parentId":
-				return ec.fieldContext_Lineage_parentId(ctx, field)
-			case "generation":
-				return ec.fieldContext_Lineage_generation(ctx, field)
-			case "mutations":
-				return ec.fieldContext_Lineage_mutations(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Lineage", field.Name)
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
@@ -7081,6 +7917,77 @@ func (ec *executionContext) fieldContext_Query_genome(ctx context.Context, field
 	return fc, nil
 }
 
+func (ec *executionContext) _Query_outboxDeadLetters(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Query_outboxDeadLetters,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Query().OutboxDeadLetters(ctx, fc.Args["filter"].(*model.OutboxDeadLetterFilter), fc.Args["page"].(*model.PageInput))
+		},
+		nil,
+		ec.marshalNOutboxDeadLetter2ᚕᚖgithubᚗcomᚋpetriᚑboardᚑarenaᚋgraphᚋmodelᚐOutboxDeadLetterᚄ,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Query_outboxDeadLetters(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_OutboxDeadLetter_id(ctx, field)
+			case "outboxEventId":
+				return ec.fieldContext_OutboxDeadLetter_outboxEventId(ctx, field)
+			case "aggregateType":
+				return ec.fieldContext_OutboxDeadLetter_aggregateType(ctx, field)
+			case "aggregateId":
+				return ec.fieldContext_OutboxDeadLetter_aggregateId(ctx, field)
+			case "eventType":
+				return ec.fieldContext_OutboxDeadLetter_eventType(ctx, field)
+			case "topic":
+				return ec.fieldContext_OutboxDeadLetter_topic(ctx, field)
+			case "payload":
+				return ec.fieldContext_OutboxDeadLetter_payload(ctx, field)
+			case "headers":
+				return ec.fieldContext_OutboxDeadLetter_headers(ctx, field)
+			case "correlationId":
+				return ec.fieldContext_OutboxDeadLetter_correlationId(ctx, field)
+			case "causationId":
+				return ec.fieldContext_OutboxDeadLetter_causationId(ctx, field)
+			case "idempotencyKey":
+				return ec.fieldContext_OutboxDeadLetter_idempotencyKey(ctx, field)
+			case "attempts":
+				return ec.fieldContext_OutboxDeadLetter_attempts(ctx, field)
+			case "lastError":
+				return ec.fieldContext_OutboxDeadLetter_lastError(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_OutboxDeadLetter_createdAt(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type OutboxDeadLetter", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_outboxDeadLetters_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _Query___type(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
@@ -9972,8 +10879,17 @@ func (ec *executionContext) unmarshalInputArenaConfigInput(ctx context.Context,
 	if _, present := asMap["temperature"]; !present {
 		asMap["temperature"] = map[string]any{"unit": "C", "value": 37.000000}
 	}
+	if _, present := asMap["maxTicks"]; !present {
+		asMap["maxTicks"] = 0
+	}
+	if _, present := asMap["maxPlayers"]; !present {
+		asMap["maxPlayers"] = 0
+	}
+	if _, present := asMap["maxActionsPerTick"]; !present {
+		asMap["maxActionsPerTick"] = 0
+	}
 
-	fieldsInOrder := [...]string{"tickMillis", "width", "height", "diffusionRate", "mutationRate", "maxOrganisms", "snapshotEveryTicks", "temperature"}
+	fieldsInOrder := [...]string{"tickMillis", "width", "height", "diffusionRate", "mutationRate", "maxOrganisms", "snapshotEveryTicks", "temperature", "maxTicks", "maxPlayers", "maxActionsPerTick"}
 	for _, k := range fieldsInOrder {
 		v, ok := asMap[k]
 		if !ok {
@@ -10036,6 +10952,27 @@ func (ec *executionContext) unmarshalInputArenaConfigInput(ctx context.Context,
 				return it, err
 			}
 			it.Temperature = data
+		case "maxTicks":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("maxTicks"))
+			data, err := ec.unmarshalNLong2int64(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.MaxTicks = data
+		case "maxPlayers":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("maxPlayers"))
+			data, err := ec.unmarshalNInt2int32(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.MaxPlayers = data
+		case "maxActionsPerTick":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("maxActionsPerTick"))
+			data, err := ec.unmarshalNInt2int32(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.MaxActionsPerTick = data
 		}
 	}
 
@@ -10219,6 +11156,106 @@ func (ec *executionContext) unmarshalInputLeaveArenaInput(ctx context.Context, o
 	return it, nil
 }
 
+func (ec *executionContext) unmarshalInputOutboxDeadLetterFilter(ctx context.Context, obj any) (model.OutboxDeadLetterFilter, error) {
+	var it model.OutboxDeadLetterFilter
+	asMap := map[string]any{}
+	for k, v := range obj.(map[string]any) {
+		asMap[k] = v
+	}
+
+	fieldsInOrder := [...]string{"ids", "aggregateId", "eventType", "from", "to"}
+	for _, k := range fieldsInOrder {
+		v, ok := asMap[k]
+		if !ok {
+			continue
+		}
+		switch k {
+		case "ids":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("ids"))
+			data, err := ec.unmarshalOUUID2ᚕgithubᚗcomᚋgoogleᚋuuidᚐUUIDᚄ(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.Ids = data
+		case "aggregateId":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("aggregateId"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.AggregateID = data
+		case "eventType":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("eventType"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.EventType = data
+		case "from":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("from"))
+			data, err := ec.unmarshalOTime2ᚖtimeᚐTime(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.From = data
+		case "to":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("to"))
+			data, err := ec.unmarshalOTime2ᚖtimeᚐTime(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.To = data
+		}
+	}
+
+	return it, nil
+}
+
+func (ec *executionContext) unmarshalInputOutboxDeadLetterOpInput(ctx context.Context, obj any) (model.OutboxDeadLetterOpInput, error) {
+	var it model.OutboxDeadLetterOpInput
+	asMap := map[string]any{}
+	for k, v := range obj.(map[string]any) {
+		asMap[k] = v
+	}
+
+	if _, present := asMap["limit"]; !present {
+		asMap["limit"] = 100
+	}
+
+	fieldsInOrder := [...]string{"filter", "limit", "reason"}
+	for _, k := range fieldsInOrder {
+		v, ok := asMap[k]
+		if !ok {
+			continue
+		}
+		switch k {
+		case "filter":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("filter"))
+			data, err := ec.unmarshalNOutboxDeadLetterFilter2ᚖgithubᚗcomᚋpetriᚑboardᚑarenaᚋgraphᚋmodelᚐOutboxDeadLetterFilter(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.Filter = data
+		case "limit":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("limit"))
+			data, err := ec.unmarshalOInt2ᚖint32(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.Limit = data
+		case "reason":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("reason"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.Reason = data
+		}
+	}
+
+	return it, nil
+}
+
 func (ec *executionContext) unmarshalInputPageInput(ctx context.Context, obj any) (model.PageInput, error) {
 	var it model.PageInput
 	asMap := map[string]any{}
@@ -11041,6 +12078,21 @@ func (ec *executionContext) _ArenaConfig(ctx context.Context, sel ast.SelectionS
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "maxTicks":
+			out.Values[i] = ec._ArenaConfig_maxTicks(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "maxPlayers":
+			out.Values[i] = ec._ArenaConfig_maxPlayers(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "maxActionsPerTick":
+			out.Values[i] = ec._ArenaConfig_maxActionsPerTick(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -12061,6 +13113,20 @@ func (ec *executionContext) _Mutation(ctx context.Context, sel ast.SelectionSet)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "requeueOutboxDeadLetters":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_requeueOutboxDeadLetters(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "purgeOutboxDeadLetters":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_purgeOutboxDeadLetters(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -12163,6 +13229,137 @@ func (ec *executionContext) _Organism(ctx context.Context, sel ast.SelectionSet,
 	return out
 }
 
+var outboxDeadLetterImplementors = []string{"OutboxDeadLetter"}
+
+func (ec *executionContext) _OutboxDeadLetter(ctx context.Context, sel ast.SelectionSet, obj *model.OutboxDeadLetter) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, outboxDeadLetterImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("OutboxDeadLetter")
+		case "id":
+			out.Values[i] = ec._OutboxDeadLetter_id(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "outboxEventId":
+			out.Values[i] = ec._OutboxDeadLetter_outboxEventId(ctx, field, obj)
+		case "aggregateType":
+			out.Values[i] = ec._OutboxDeadLetter_aggregateType(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "aggregateId":
+			out.Values[i] = ec._OutboxDeadLetter_aggregateId(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "eventType":
+			out.Values[i] = ec._OutboxDeadLetter_eventType(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "topic":
+			out.Values[i] = ec._OutboxDeadLetter_topic(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "payload":
+			out.Values[i] = ec._OutboxDeadLetter_payload(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "headers":
+			out.Values[i] = ec._OutboxDeadLetter_headers(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "correlationId":
+			out.Values[i] = ec._OutboxDeadLetter_correlationId(ctx, field, obj)
+		case "causationId":
+			out.Values[i] = ec._OutboxDeadLetter_causationId(ctx, field, obj)
+		case "idempotencyKey":
+			out.Values[i] = ec._OutboxDeadLetter_idempotencyKey(ctx, field, obj)
+		case "attempts":
+			out.Values[i] = ec._OutboxDeadLetter_attempts(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "lastError":
+			out.Values[i] = ec._OutboxDeadLetter_lastError(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "createdAt":
+			out.Values[i] = ec._OutboxDeadLetter_createdAt(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var outboxDeadLetterOpPayloadImplementors = []string{"OutboxDeadLetterOpPayload"}
+
+func (ec *executionContext) _OutboxDeadLetterOpPayload(ctx context.Context, sel ast.SelectionSet, obj *model.OutboxDeadLetterOpPayload) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, outboxDeadLetterOpPayloadImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("OutboxDeadLetterOpPayload")
+		case "affected":
+			out.Values[i] = ec._OutboxDeadLetterOpPayload_affected(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
 var pauseArenaPayloadImplementors = []string{"PauseArenaPayload"}
 
 func (ec *executionContext) _PauseArenaPayload(ctx context.Context, sel ast.SelectionSet, obj *model.PauseArenaPayload) graphql.Marshaler {
@@ -12578,6 +13775,28 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
 			}
 
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
+		case "outboxDeadLetters":
+			field := field
+
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_outboxDeadLetters(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&fs.Invalids, 1)
+				}
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx,
+					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+			}
+
 			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
 		case "__type":
 			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
@@ -14026,6 +15245,28 @@ func (ec *executionContext) marshalNInt2int32(ctx context.Context, sel ast.Selec
 	return res
 }
 
+func (ec *executionContext) unmarshalNJSON2map(ctx context.Context, v any) (map[string]any, error) {
+	res, err := graphql.UnmarshalMap(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNJSON2map(ctx context.Context, sel ast.SelectionSet, v map[string]any) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	_ = sel
+	res := graphql.MarshalMap(v)
+	if res == graphql.Null {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+	}
+	return res
+}
+
 func (ec *executionContext) unmarshalNJoinArenaInput2githubᚗcomᚋpetriᚑboardᚑarenaᚋgraphᚋmodelᚐJoinArenaInput(ctx context.Context, v any) (model.JoinArenaInput, error) {
 	res, err := ec.unmarshalInputJoinArenaInput(ctx, v)
 	return res, graphql.ErrorOnPath(ctx, err)
@@ -14208,6 +15449,84 @@ func (ec *executionContext) marshalNOrganismKind2githubᚗcomᚋpetriᚑboardᚑ
 	return v
 }
 
+func (ec *executionContext) marshalNOutboxDeadLetter2ᚕᚖgithubᚗcomᚋpetriᚑboardᚑarenaᚋgraphᚋmodelᚐOutboxDeadLetterᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.OutboxDeadLetter) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNOutboxDeadLetter2ᚖgithubᚗcomᚋpetriᚑboardᚑarenaᚋgraphᚋmodelᚐOutboxDeadLetter(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) marshalNOutboxDeadLetter2ᚖgithubᚗcomᚋpetriᚑboardᚑarenaᚋgraphᚋmodelᚐOutboxDeadLetter(ctx context.Context, sel ast.SelectionSet, v *model.OutboxDeadLetter) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._OutboxDeadLetter(ctx, sel, v)
+}
+
+func (ec *executionContext) unmarshalNOutboxDeadLetterFilter2ᚖgithubᚗcomᚋpetriᚑboardᚑarenaᚋgraphᚋmodelᚐOutboxDeadLetterFilter(ctx context.Context, v any) (*model.OutboxDeadLetterFilter, error) {
+	res, err := ec.unmarshalInputOutboxDeadLetterFilter(ctx, v)
+	return &res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) unmarshalNOutboxDeadLetterOpInput2githubᚗcomᚋpetriᚑboardᚑarenaᚋgraphᚋmodelᚐOutboxDeadLetterOpInput(ctx context.Context, v any) (model.OutboxDeadLetterOpInput, error) {
+	res, err := ec.unmarshalInputOutboxDeadLetterOpInput(ctx, v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNOutboxDeadLetterOpPayload2githubᚗcomᚋpetriᚑboardᚑarenaᚋgraphᚋmodelᚐOutboxDeadLetterOpPayload(ctx context.Context, sel ast.SelectionSet, v model.OutboxDeadLetterOpPayload) graphql.Marshaler {
+	return ec._OutboxDeadLetterOpPayload(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNOutboxDeadLetterOpPayload2ᚖgithubᚗcomᚋpetriᚑboardᚑarenaᚋgraphᚋmodelᚐOutboxDeadLetterOpPayload(ctx context.Context, sel ast.SelectionSet, v *model.OutboxDeadLetterOpPayload) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._OutboxDeadLetterOpPayload(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalNPauseArenaInput2githubᚗcomᚋpetriᚑboardᚑarenaᚋgraphᚋmodelᚐPauseArenaInput(ctx context.Context, v any) (model.PauseArenaInput, error) {
 	res, err := ec.unmarshalInputPauseArenaInput(ctx, v)
 	return res, graphql.ErrorOnPath(ctx, err)
@@ -15007,6 +16326,14 @@ func (ec *executionContext) marshalOOrganism2ᚖgithubᚗcomᚋpetriᚑboardᚑa
 	return ec._Organism(ctx, sel, v)
 }
 
+func (ec *executionContext) unmarshalOOutboxDeadLetterFilter2ᚖgithubᚗcomᚋpetriᚑboardᚑarenaᚋgraphᚋmodelᚐOutboxDeadLetterFilter(ctx context.Context, v any) (*model.OutboxDeadLetterFilter, error) {
+	if v == nil {
+		return nil, nil
+	}
+	res, err := ec.unmarshalInputOutboxDeadLetterFilter(ctx, v)
+	return &res, graphql.ErrorOnPath(ctx, err)
+}
+
 func (ec *executionContext) unmarshalOPageInput2ᚖgithubᚗcomᚋpetriᚑboardᚑarenaᚋgraphᚋmodelᚐPageInput(ctx context.Context, v any) (*model.PageInput, error) {
 	if v == nil {
 		return nil, nil
@@ -15067,6 +16394,42 @@ func (ec *executionContext) marshalOTime2ᚖtimeᚐTime(ctx context.Context, sel
 	return res
 }
 
+func (ec *executionContext) unmarshalOUUID2ᚕgithubᚗcomᚋgoogleᚋuuidᚐUUIDᚄ(ctx context.Context, v any) ([]uuid.UUID, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var vSlice []any
+	vSlice = graphql.CoerceList(v)
+	var err error
+	res := make([]uuid.UUID, len(vSlice))
+	for i := range vSlice {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithIndex(i))
+		res[i], err = ec.unmarshalNUUID2githubᚗcomᚋgoogleᚋuuidᚐUUID(ctx, vSlice[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (ec *executionContext) marshalOUUID2ᚕgithubᚗcomᚋgoogleᚋuuidᚐUUIDᚄ(ctx context.Context, sel ast.SelectionSet, v []uuid.UUID) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	ret := make(graphql.Array, len(v))
+	for i := range v {
+		ret[i] = ec.marshalNUUID2githubᚗcomᚋgoogleᚋuuidᚐUUID(ctx, sel, v[i])
+	}
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
 func (ec *executionContext) unmarshalOUUID2ᚖgithubᚗcomᚋgoogleᚋuuidᚐUUID(ctx context.Context, v any) (*uuid.UUID, error) {
 	if v == nil {
 		return nil, nil
@@ -0,0 +1,287 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/petri-board-arena/graph/model"
+	"github.com/petri-board-arena/internal/application/port"
+	"github.com/petri-board-arena/internal/application/query/dto"
+	"github.com/petri-board-arena/internal/domain/arena"
+	"github.com/petri-board-arena/internal/runtime/requestctx"
+)
+
+// actor é o jogador autenticado pelo token de sessão na arena arenaID. Sem sessão a
+// requisição é rejeitada (ErrUnauthenticated); nunca vira uuid.Nil, que o domínio
+// reserva para o sistema (tick driver). Sessão de outra arena é ErrPermissionDenied.
+func actor(ctx context.Context, arenaID arena.ID) (arena.PlayerID, error) {
+	s, ok := requestctx.SessionFrom(ctx)
+	if !ok || s.PlayerID == arena.PlayerID(uuid.Nil) {
+		return arena.PlayerID{}, ErrUnauthenticated
+	}
+	if s.ArenaID != arenaID {
+		return arena.PlayerID{}, fmt.Errorf("%w: session belongs to another arena", arena.ErrPermissionDenied)
+	}
+	return s.PlayerID, nil
+}
+
+// playerActor é o actor de operações sobre o próprio jogador (leave/submit): o
+// playerId do input tem de ser o da sessão.
+func playerActor(ctx context.Context, arenaID arena.ID, playerID uuid.UUID) (arena.PlayerID, error) {
+	id, err := actor(ctx, arenaID)
+	if err != nil {
+		return id, err
+	}
+	if id != arena.PlayerID(playerID) {
+		return arena.PlayerID{}, fmt.Errorf("%w: playerId does not match the session", arena.ErrPermissionDenied)
+	}
+	return id, nil
+}
+
+// ----------------------------
+// domain -> GraphQL
+// ----------------------------
+
+func toModelArena(a *arena.Arena) *model.Arena {
+	players := make([]*model.Player, 0, len(a.Players()))
+	for _, p := range a.Players() {
+		players = append(players, toModelPlayer(p))
+	}
+
+	cfg := a.Config()
+	return &model.Arena{
+		ID:         a.ID(),
+		Name:       a.Name(),
+		Status:     model.ArenaStatus(a.Status()),
+		CreatedAt:  a.CreatedAt(),
+		StartedAt:  a.StartedAt(),
+		FinishedAt: a.FinishedAt(),
+		Tick:       a.Tick(),
+		Config:     toModelConfig(cfg),
+		Players:    players,
+		World:      toModelWorld(cfg),
+	}
+}
+
+// toModelArenaView mapeia o read model projetado no Redis; um id inválido (chave ou
+// payload corrompido) vira erro em vez de derrubar a query.
+func toModelArenaView(v dto.ArenaView) (*model.Arena, error) {
+	id, err := uuid.Parse(v.ID)
+	if err != nil {
+		return nil, fmt.Errorf("arena read model %q: invalid id: %w", v.ID, err)
+	}
+
+	players := make([]*model.Player, 0, len(v.Players))
+	for _, p := range v.Players {
+		players = append(players, toModelPlayer(p))
+	}
+
+	return &model.Arena{
+		ID:         id,
+		Name:       v.Name,
+		Status:     model.ArenaStatus(v.Status),
+		CreatedAt:  v.CreatedAt,
+		StartedAt:  v.StartedAt,
+		FinishedAt: v.FinishedAt,
+		Tick:       v.Tick,
+		Config:     toModelConfig(v.Config),
+		Players:    players,
+		World:      toModelWorld(v.Config),
+	}, nil
+}
+
+func toModelPlayer(p arena.Player) *model.Player {
+	return &model.Player{
+		ID:          uuid.UUID(p.ID),
+		DisplayName: p.DisplayName,
+		JoinedAt:    p.JoinedAt,
+		Role:        model.PlayerType(p.Role),
+	}
+}
+
+func toModelConfig(c arena.Config) *model.ArenaConfig {
+	return &model.ArenaConfig{
+		TickMillis:         int32(c.TickMillis),
+		Width:              int32(c.Width),
+		Height:             int32(c.Height),
+		DiffusionRate:      c.DiffusionRate,
+		MutationRate:       c.MutationRate,
+		MaxOrganisms:       int32(c.MaxOrganisms),
+		SnapshotEveryTicks: int32(c.SnapshotEveryTicks),
+		Temperature: &model.Temperature{
+			Value: c.Temperature.Value,
+			Unit:  model.TemperatureUnit(c.Temperature.Unit),
+		},
+		MaxTicks:          c.MaxTicks,
+		MaxPlayers:        int32(c.PlayerCap()),
+		MaxActionsPerTick: int32(c.ActionQuota()),
+	}
+}
+
+func toModelWorld(c arena.Config) *model.WorldInfo {
+	return &model.WorldInfo{
+		Width:  int32(c.Width),
+		Height: int32(c.Height),
+		Layers: model.AllWorldLayer,
+	}
+}
+
+// ----------------------------
+// GraphQL -> domain
+// ----------------------------
+
+func fromConfigInput(in *model.ArenaConfigInput) arena.Config {
+	if in == nil {
+		return arena.Config{}
+	}
+	cfg := arena.Config{
+		TickMillis:         int(in.TickMillis),
+		Width:              int(in.Width),
+		Height:             int(in.Height),
+		DiffusionRate:      in.DiffusionRate,
+		MutationRate:       in.MutationRate,
+		MaxOrganisms:       int(in.MaxOrganisms),
+		SnapshotEveryTicks: int(in.SnapshotEveryTicks),
+		MaxTicks:           in.MaxTicks,
+		MaxPlayers:         int(in.MaxPlayers),
+		MaxActionsPerTick:  int(in.MaxActionsPerTick),
+	}
+	if in.Temperature != nil {
+		cfg.Temperature = fromTemperatureInput(in.Temperature)
+	}
+	return cfg
+}
+
+func fromTemperatureInput(in *model.TemperatureInput) arena.Temperature {
+	return arena.Temperature{Value: in.Value, Unit: arena.TemperatureUnit(in.Unit)}
+}
+
+func fromAreaInput(in *model.AreaInput) arena.Area {
+	if in == nil {
+		return arena.Area{}
+	}
+	return arena.Area{X: int(in.X), Y: int(in.Y), Width: int(in.Width), Height: int(in.Height)}
+}
+
+// fromSubmitActionInput escolhe o payload conforme o type; o domínio valida a coerência.
+func fromSubmitActionInput(in model.SubmitActionInput) (arena.ActionPayload, error) {
+	switch arena.ActionType(in.Type) {
+	case arena.ActionAddNutrients:
+		if in.AddNutrients == nil {
+			return nil, fmt.Errorf("%w: addNutrients is required for %s", arena.ErrInvalidAction, in.Type)
+		}
+		return arena.AddNutrientsPayload{
+			Area:   fromAreaInput(in.AddNutrients.Area),
+			Amount: int(in.AddNutrients.Amount),
+		}, nil
+	case arena.ActionDropAntibiotic:
+		if in.DropAntibiotic == nil {
+			return nil, fmt.Errorf("%w: dropAntibiotic is required for %s", arena.ErrInvalidAction, in.Type)
+		}
+		return arena.DropAntibioticPayload{
+			Area:          fromAreaInput(in.DropAntibiotic.Area),
+			Kind:          arena.AntibioticKind(in.DropAntibiotic.Kind),
+			Concentration: in.DropAntibiotic.Concentration,
+		}, nil
+	case arena.ActionSetTemperature:
+		if in.SetTemperature == nil || in.SetTemperature.Temperature == nil {
+			return nil, fmt.Errorf("%w: setTemperature is required for %s", arena.ErrInvalidAction, in.Type)
+		}
+		return arena.SetTemperaturePayload{Temperature: fromTemperatureInput(in.SetTemperature.Temperature)}, nil
+	case arena.ActionSpawnOrganism:
+		if in.SpawnOrganism == nil || in.SpawnOrganism.Position == nil {
+			return nil, fmt.Errorf("%w: spawnOrganism is required for %s", arena.ErrInvalidAction, in.Type)
+		}
+		var tpl *string
+		if in.SpawnOrganism.GenomeTemplateID != nil {
+			s := in.SpawnOrganism.GenomeTemplateID.String()
+			tpl = &s
+		}
+		return arena.SpawnOrganismPayload{
+			Kind:             arena.OrganismKind(in.SpawnOrganism.Kind),
+			Position:         arena.Point{X: int(in.SpawnOrganism.Position.X), Y: int(in.SpawnOrganism.Position.Y)},
+			GenomeTemplateID: tpl,
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown action type %q", arena.ErrInvalidAction, in.Type)
+	}
+}
+
+func fromArenaFilter(f *model.ArenaFilter) dto.ArenaFilter {
+	var out dto.ArenaFilter
+	if f == nil {
+		return out
+	}
+	if f.Status != nil {
+		s := string(*f.Status)
+		out.Status = &s
+	}
+	out.NameContains = deref(f.NameContains)
+	return out
+}
+
+// ----------------------------
+// ops: outbox dead letters
+// ----------------------------
+
+func fromDeadLetterFilter(f *model.OutboxDeadLetterFilter) port.OutboxDeadLetterFilter {
+	if f == nil {
+		return port.OutboxDeadLetterFilter{}
+	}
+	return port.OutboxDeadLetterFilter{
+		IDs:         f.Ids,
+		AggregateID: f.AggregateID,
+		EventType:   f.EventType,
+		From:        f.From,
+		To:          f.To,
+	}
+}
+
+func toModelDeadLetter(dl port.OutboxDeadLetter) *model.OutboxDeadLetter {
+	return &model.OutboxDeadLetter{
+		ID:             dl.ID,
+		OutboxEventID:  dl.OutboxEventID,
+		AggregateType:  dl.AggregateType,
+		AggregateID:    dl.AggregateID,
+		EventType:      dl.EventType,
+		Topic:          dl.Topic,
+		Payload:        jsonObject(dl.Payload),
+		Headers:        jsonObject(dl.Headers),
+		CorrelationID:  dl.CorrelationID,
+		CausationID:    dl.CausationID,
+		IdempotencyKey: dl.IdempotencyKey,
+		Attempts:       int32(dl.Attempts),
+		LastError:      dl.LastError,
+		CreatedAt:      dl.CreatedAt,
+	}
+}
+
+// jsonObject decodifica um objeto JSON para o scalar JSON; valores que não são objeto vão em "value".
+func jsonObject(raw json.RawMessage) map[string]any {
+	out := map[string]any{}
+	if len(raw) == 0 {
+		return out
+	}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return map[string]any{"value": string(raw)}
+	}
+	return out
+}
+
+func deadLetterOpFilter(input model.OutboxDeadLetterOpInput) port.OutboxDeadLetterFilter {
+	f := fromDeadLetterFilter(input.Filter)
+	if input.Limit != nil {
+		f.Limit = int(*input.Limit)
+	}
+	return f
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,61 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/petri-board-arena/internal/application/query/dto"
+	"github.com/petri-board-arena/internal/domain/arena"
+	"github.com/petri-board-arena/internal/runtime/requestctx"
+)
+
+func TestPlayerActor(t *testing.T) {
+	arenaID := arena.ID(uuid.New())
+	playerID := uuid.New()
+	session := requestctx.Session{ArenaID: arenaID, PlayerID: arena.PlayerID(playerID)}
+
+	cases := map[string]struct {
+		ctx      context.Context
+		arenaID  arena.ID
+		playerID uuid.UUID
+		wantErr  error
+	}{
+		"session":        {requestctx.WithSession(context.Background(), session), arenaID, playerID, nil},
+		"no session":     {context.Background(), arenaID, playerID, ErrUnauthenticated},
+		"nil player":     {requestctx.WithSession(context.Background(), requestctx.Session{ArenaID: arenaID}), arenaID, uuid.Nil, ErrUnauthenticated},
+		"other arena":    {requestctx.WithSession(context.Background(), session), arena.ID(uuid.New()), playerID, arena.ErrPermissionDenied},
+		"other playerId": {requestctx.WithSession(context.Background(), session), arenaID, uuid.New(), arena.ErrPermissionDenied},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := playerActor(tc.ctx, tc.arenaID, tc.playerID)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("playerActor err = %v, want %v", err, tc.wantErr)
+			}
+			if err == nil && got != arena.PlayerID(playerID) {
+				t.Fatalf("playerActor = %s, want %s", uuid.UUID(got), playerID)
+			}
+			if err != nil && got != arena.PlayerID(uuid.Nil) {
+				t.Fatalf("playerActor returned %s with error", uuid.UUID(got))
+			}
+		})
+	}
+}
+
+func TestToModelArenaViewInvalidID(t *testing.T) {
+	if _, err := toModelArenaView(dto.ArenaView{ID: "not-a-uuid", Name: "x"}); err == nil {
+		t.Fatal("toModelArenaView accepted an invalid id")
+	}
+
+	id := uuid.New()
+	a, err := toModelArenaView(dto.ArenaView{ID: id.String(), Name: "x", Status: "PENDING"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.ID != id || a.Players == nil {
+		t.Fatalf("toModelArenaView = %+v", a)
+	}
+}
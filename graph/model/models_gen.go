@@ -87,6 +87,9 @@ type ArenaConfig struct {
 	MaxOrganisms       int32        `json:"maxOrganisms"`
 	SnapshotEveryTicks int32        `json:"snapshotEveryTicks"`
 	Temperature        *Temperature `json:"temperature"`
+	MaxTicks           int64        `json:"maxTicks"`
+	MaxPlayers         int32        `json:"maxPlayers"`
+	MaxActionsPerTick  int32        `json:"maxActionsPerTick"`
 }
 
 type ArenaConfigInput struct {
@@ -98,6 +101,9 @@ type ArenaConfigInput struct {
 	MaxOrganisms       int32             `json:"maxOrganisms"`
 	SnapshotEveryTicks int32             `json:"snapshotEveryTicks"`
 	Temperature        *TemperatureInput `json:"temperature"`
+	MaxTicks           int64             `json:"maxTicks"`
+	MaxPlayers         int32             `json:"maxPlayers"`
+	MaxActionsPerTick  int32             `json:"maxActionsPerTick"`
 }
 
 type ArenaFilter struct {
@@ -267,6 +273,44 @@ type Organism struct {
 	Lineage   *Lineage     `json:"lineage"`
 }
 
+type OutboxDeadLetter struct {
+	ID             uuid.UUID      `json:"id"`
+	OutboxEventID  *uuid.UUID     `json:"outboxEventId,omitempty"`
+	AggregateType  string         `json:"aggregateType"`
+	AggregateID    string         `json:"aggregateId"`
+	EventType      string         `json:"eventType"`
+	Topic          string         `json:"topic"`
+	Payload        map[string]any `json:"payload"`
+	Headers        map[string]any `json:"headers"`
+	CorrelationID  *string        `json:"correlationId,omitempty"`
+	CausationID    *string        `json:"causationId,omitempty"`
+	IdempotencyKey *string        `json:"idempotencyKey,omitempty"`
+	Attempts       int32          `json:"attempts"`
+	LastError      string         `json:"lastError"`
+	CreatedAt      time.Time      `json:"createdAt"`
+}
+
+type OutboxDeadLetterFilter struct {
+	Ids         []uuid.UUID `json:"ids,omitempty"`
+	AggregateID *string     `json:"aggregateId,omitempty"`
+	EventType   *string     `json:"eventType,omitempty"`
+	// createdAt >= from
+	From *time.Time `json:"from,omitempty"`
+	// createdAt < to
+	To *time.Time `json:"to,omitempty"`
+}
+
+type OutboxDeadLetterOpInput struct {
+	// ao menos um critério é obrigatório
+	Filter *OutboxDeadLetterFilter `json:"filter"`
+	Limit  *int32                  `json:"limit,omitempty"`
+	Reason *string                 `json:"reason,omitempty"`
+}
+
+type OutboxDeadLetterOpPayload struct {
+	Affected int32 `json:"affected"`
+}
+
 type PageInput struct {
 	Limit  int32 `json:"limit"`
 	Offset int32 `json:"offset"`
@@ -1,21 +1,93 @@
 package graph
 
 import (
+	"context"
+	"crypto/subtle"
+
+	"github.com/google/uuid"
+
 	createarena "github.com/petri-board-arena/internal/application/command"
+	"github.com/petri-board-arena/internal/application/query"
+	"github.com/petri-board-arena/internal/runtime/requestctx"
 )
 
 // ResolverDeps: dependências injetadas (composition root)
 type ResolverDeps struct {
-	CreateArenaHandler *createarena.Handler
+	CreateArenaHandler    *createarena.Handler
+	StartArenaHandler     *createarena.StartArenaHandler
+	PauseArenaHandler     *createarena.PauseArenaHandler
+	ResumeArenaHandler    *createarena.ResumeArenaHandler
+	StopArenaHandler      *createarena.StopArenaHandler
+	JoinArenaHandler      *createarena.JoinArenaHandler
+	LeaveArenaHandler     *createarena.LeaveArenaHandler
+	SubmitActionHandler   *createarena.SubmitActionHandler
+	SetArenaConfigHandler *createarena.SetArenaConfigHandler
+
+	// Query side (read model no Redis)
+	GetArenaHandler   *query.GetArenaHandler
+	ListArenasHandler *query.ListArenasHandler
+
+	// Ops (outbox dead letters): AdminToken vazio desabilita as operações
+	ListDeadLettersHandler    *query.ListDeadLettersHandler
+	RequeueDeadLettersHandler *createarena.RequeueDeadLettersHandler
+	PurgeDeadLettersHandler   *createarena.PurgeDeadLettersHandler
+	AdminToken                string
 }
 
 // Resolver: raiz do gqlgen
 type Resolver struct {
-	CreateArenaHandler *createarena.Handler
+	CreateArenaHandler    *createarena.Handler
+	StartArenaHandler     *createarena.StartArenaHandler
+	PauseArenaHandler     *createarena.PauseArenaHandler
+	ResumeArenaHandler    *createarena.ResumeArenaHandler
+	StopArenaHandler      *createarena.StopArenaHandler
+	JoinArenaHandler      *createarena.JoinArenaHandler
+	LeaveArenaHandler     *createarena.LeaveArenaHandler
+	SubmitActionHandler   *createarena.SubmitActionHandler
+	SetArenaConfigHandler *createarena.SetArenaConfigHandler
+
+	// Query side (read model no Redis)
+	GetArenaHandler   *query.GetArenaHandler
+	ListArenasHandler *query.ListArenasHandler
+
+	// Ops (outbox dead letters): AdminToken vazio desabilita as operações
+	ListDeadLettersHandler    *query.ListDeadLettersHandler
+	RequeueDeadLettersHandler *createarena.RequeueDeadLettersHandler
+	PurgeDeadLettersHandler   *createarena.PurgeDeadLettersHandler
+	AdminToken                string
 }
 
 func NewResolver(deps ResolverDeps) *Resolver {
 	return &Resolver{
-		CreateArenaHandler: deps.CreateArenaHandler,
+		CreateArenaHandler:    deps.CreateArenaHandler,
+		StartArenaHandler:     deps.StartArenaHandler,
+		PauseArenaHandler:     deps.PauseArenaHandler,
+		ResumeArenaHandler:    deps.ResumeArenaHandler,
+		StopArenaHandler:      deps.StopArenaHandler,
+		JoinArenaHandler:      deps.JoinArenaHandler,
+		LeaveArenaHandler:     deps.LeaveArenaHandler,
+		SubmitActionHandler:   deps.SubmitActionHandler,
+		SetArenaConfigHandler: deps.SetArenaConfigHandler,
+
+		GetArenaHandler:   deps.GetArenaHandler,
+		ListArenasHandler: deps.ListArenasHandler,
+
+		ListDeadLettersHandler:    deps.ListDeadLettersHandler,
+		RequeueDeadLettersHandler: deps.RequeueDeadLettersHandler,
+		PurgeDeadLettersHandler:   deps.PurgeDeadLettersHandler,
+		AdminToken:                deps.AdminToken,
+	}
+}
+
+// adminActor valida o X-Admin-Token e devolve o actor para a auditoria
+// ("admin" ou "admin:<playerId>" quando houver também um token de sessão válido).
+func (r *Resolver) adminActor(ctx context.Context) (string, error) {
+	token, ok := requestctx.AdminTokenFrom(ctx)
+	if r.AdminToken == "" || !ok || subtle.ConstantTimeCompare([]byte(token), []byte(r.AdminToken)) != 1 {
+		return "", ErrAdminRequired
+	}
+	if s, ok := requestctx.SessionFrom(ctx); ok {
+		return "admin:" + uuid.UUID(s.PlayerID).String(), nil
 	}
+	return "admin", nil
 }
@@ -0,0 +1,71 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.86
+
+import (
+	"context"
+
+	"github.com/petri-board-arena/graph/model"
+	createarena "github.com/petri-board-arena/internal/application/command"
+)
+
+// RequeueOutboxDeadLetters is the resolver for the requeueOutboxDeadLetters field.
+func (r *mutationResolver) RequeueOutboxDeadLetters(ctx context.Context, input model.OutboxDeadLetterOpInput) (*model.OutboxDeadLetterOpPayload, error) {
+	by, err := r.adminActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := r.RequeueDeadLettersHandler.Handle(ctx, createarena.RequeueDeadLettersCommand{
+		Filter: deadLetterOpFilter(input),
+		Actor:  by,
+		Reason: deref(input.Reason),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &model.OutboxDeadLetterOpPayload{Affected: int32(n)}, nil
+}
+
+// PurgeOutboxDeadLetters is the resolver for the purgeOutboxDeadLetters field.
+func (r *mutationResolver) PurgeOutboxDeadLetters(ctx context.Context, input model.OutboxDeadLetterOpInput) (*model.OutboxDeadLetterOpPayload, error) {
+	by, err := r.adminActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := r.PurgeDeadLettersHandler.Handle(ctx, createarena.PurgeDeadLettersCommand{
+		Filter: deadLetterOpFilter(input),
+		Actor:  by,
+		Reason: deref(input.Reason),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &model.OutboxDeadLetterOpPayload{Affected: int32(n)}, nil
+}
+
+// OutboxDeadLetters is the resolver for the outboxDeadLetters field.
+func (r *queryResolver) OutboxDeadLetters(ctx context.Context, filter *model.OutboxDeadLetterFilter, page *model.PageInput) ([]*model.OutboxDeadLetter, error) {
+	if _, err := r.adminActor(ctx); err != nil {
+		return nil, err
+	}
+
+	f := fromDeadLetterFilter(filter)
+	if page != nil {
+		f.Limit, f.Offset = int(page.Limit), int(page.Offset)
+	}
+
+	items, err := r.ListDeadLettersHandler.Handle(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*model.OutboxDeadLetter, 0, len(items))
+	for _, dl := range items {
+		out = append(out, toModelDeadLetter(dl))
+	}
+	return out, nil
+}
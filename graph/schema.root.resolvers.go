@@ -7,55 +7,165 @@ package graph
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/google/uuid"
 	"github.com/petri-board-arena/graph/model"
+	createarena "github.com/petri-board-arena/internal/application/command"
+	"github.com/petri-board-arena/internal/application/port/repository"
+	"github.com/petri-board-arena/internal/domain/arena"
 )
 
 // CreateArena is the resolver for the createArena field.
 func (r *mutationResolver) CreateArena(ctx context.Context, input model.CreateArenaInput) (*model.CreateArenaPayload, error) {
-	panic(fmt.Errorf("not implemented: CreateArena - createArena"))
+	res, err := r.CreateArenaHandler.Handle(ctx, createarena.Command{
+		Name:   input.Name,
+		Config: fromConfigInput(input.Config),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &model.CreateArenaPayload{Arena: toModelArena(res.Arena)}, nil
 }
 
 // StartArena is the resolver for the startArena field.
 func (r *mutationResolver) StartArena(ctx context.Context, input model.StartArenaInput) (*model.StartArenaPayload, error) {
-	panic(fmt.Errorf("not implemented: StartArena - startArena"))
+	by, err := actor(ctx, input.ArenaID)
+	if err != nil {
+		return nil, err
+	}
+	a, err := r.StartArenaHandler.Handle(ctx, createarena.StartArenaCommand{ArenaID: input.ArenaID, By: by})
+	if err != nil {
+		return nil, err
+	}
+	return &model.StartArenaPayload{Ok: true, Arena: toModelArena(a)}, nil
 }
 
 // PauseArena is the resolver for the pauseArena field.
 func (r *mutationResolver) PauseArena(ctx context.Context, input model.PauseArenaInput) (*model.PauseArenaPayload, error) {
-	panic(fmt.Errorf("not implemented: PauseArena - pauseArena"))
+	by, err := actor(ctx, input.ArenaID)
+	if err != nil {
+		return nil, err
+	}
+	a, err := r.PauseArenaHandler.Handle(ctx, createarena.PauseArenaCommand{ArenaID: input.ArenaID, By: by})
+	if err != nil {
+		return nil, err
+	}
+	return &model.PauseArenaPayload{Ok: true, Arena: toModelArena(a)}, nil
 }
 
 // ResumeArena is the resolver for the resumeArena field.
 func (r *mutationResolver) ResumeArena(ctx context.Context, input model.ResumeArenaInput) (*model.ResumeArenaPayload, error) {
-	panic(fmt.Errorf("not implemented: ResumeArena - resumeArena"))
+	by, err := actor(ctx, input.ArenaID)
+	if err != nil {
+		return nil, err
+	}
+	a, err := r.ResumeArenaHandler.Handle(ctx, createarena.ResumeArenaCommand{ArenaID: input.ArenaID, By: by})
+	if err != nil {
+		return nil, err
+	}
+	return &model.ResumeArenaPayload{Ok: true, Arena: toModelArena(a)}, nil
 }
 
 // StopArena is the resolver for the stopArena field.
 func (r *mutationResolver) StopArena(ctx context.Context, input model.StopArenaInput) (*model.StopArenaPayload, error) {
-	panic(fmt.Errorf("not implemented: StopArena - stopArena"))
+	by, err := actor(ctx, input.ArenaID)
+	if err != nil {
+		return nil, err
+	}
+	a, err := r.StopArenaHandler.Handle(ctx, createarena.StopArenaCommand{ArenaID: input.ArenaID, By: by})
+	if err != nil {
+		return nil, err
+	}
+	return &model.StopArenaPayload{Ok: true, Arena: toModelArena(a)}, nil
 }
 
 // JoinArena is the resolver for the joinArena field.
 func (r *mutationResolver) JoinArena(ctx context.Context, input model.JoinArenaInput) (*model.JoinArenaPayload, error) {
-	panic(fmt.Errorf("not implemented: JoinArena - joinArena"))
+	res, err := r.JoinArenaHandler.Handle(ctx, createarena.JoinArenaCommand{
+		ArenaID:     input.ArenaID,
+		DisplayName: input.DisplayName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &model.JoinArenaPayload{Player: toModelPlayer(res.Player), SessionToken: res.SessionToken}, nil
 }
 
 // LeaveArena is the resolver for the leaveArena field.
 func (r *mutationResolver) LeaveArena(ctx context.Context, input model.LeaveArenaInput) (*model.LeaveArenaPayload, error) {
-	panic(fmt.Errorf("not implemented: LeaveArena - leaveArena"))
+	pid, err := playerActor(ctx, input.ArenaID, input.PlayerID)
+	if err != nil {
+		return nil, err
+	}
+	_, err = r.LeaveArenaHandler.Handle(ctx, createarena.LeaveArenaCommand{
+		ArenaID:  input.ArenaID,
+		PlayerID: pid,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &model.LeaveArenaPayload{Ok: true}, nil
 }
 
 // SubmitAction is the resolver for the submitAction field.
 func (r *mutationResolver) SubmitAction(ctx context.Context, input model.SubmitActionInput) (*model.SubmitActionPayload, error) {
-	panic(fmt.Errorf("not implemented: SubmitAction - submitAction"))
+	pid, err := playerActor(ctx, input.ArenaID, input.PlayerID)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := fromSubmitActionInput(input)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := createarena.SubmitActionCommand{
+		ArenaID:  input.ArenaID,
+		PlayerID: pid,
+		Type:     arena.ActionType(input.Type),
+		Payload:  payload,
+	}
+	if input.ApplyAtTick != nil {
+		cmd.ApplyAtTick = *input.ApplyAtTick
+	}
+
+	res, err := r.SubmitActionHandler.Handle(ctx, cmd)
+	if err == nil {
+		return &model.SubmitActionPayload{
+			ActionID:        uuid.UUID(res.Action.ID),
+			Accepted:        true,
+			WillApplyAtTick: res.Action.ApplyAtTick,
+		}, nil
+	}
+	// rejeição da ação em si: responde accepted=false com motivo estável em vez de erro
+	if reason, ok := actionRejectReason(err); ok {
+		return &model.SubmitActionPayload{
+			ActionID:        uuid.UUID(res.Action.ID),
+			Accepted:        false,
+			Reason:          &reason,
+			WillApplyAtTick: res.Action.ApplyAtTick,
+		}, nil
+	}
+	return nil, err
 }
 
 // SetArenaConfig is the resolver for the setArenaConfig field.
 func (r *mutationResolver) SetArenaConfig(ctx context.Context, input model.SetArenaConfigInput) (*model.SetArenaConfigPayload, error) {
-	panic(fmt.Errorf("not implemented: SetArenaConfig - setArenaConfig"))
+	by, err := actor(ctx, input.ArenaID)
+	if err != nil {
+		return nil, err
+	}
+	a, err := r.SetArenaConfigHandler.Handle(ctx, createarena.SetArenaConfigCommand{
+		ArenaID: input.ArenaID,
+		Config:  fromConfigInput(input.Config),
+		By:      by,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &model.SetArenaConfigPayload{Ok: true, Arena: toModelArena(a)}, nil
 }
 
 // Health is the resolver for the health field.
@@ -65,12 +175,43 @@ func (r *queryResolver) Health(ctx context.Context) (*model.Health, error) {
 
 // Arena is the resolver for the arena field.
 func (r *queryResolver) Arena(ctx context.Context, id uuid.UUID) (*model.Arena, error) {
-	panic(fmt.Errorf("not implemented: Arena - arena"))
+	v, err := r.GetArenaHandler.Handle(ctx, id.String())
+	if errors.Is(err, repository.ErrArenaNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toModelArenaView(*v)
 }
 
 // Arenas is the resolver for the arenas field.
 func (r *queryResolver) Arenas(ctx context.Context, filter *model.ArenaFilter, page *model.PageInput) (*model.ArenaPage, error) {
-	panic(fmt.Errorf("not implemented: Arenas - arenas"))
+	f := fromArenaFilter(filter)
+	if page != nil {
+		f.Limit, f.Offset = int(page.Limit), int(page.Offset)
+	}
+
+	res, err := r.ListArenasHandler.Handle(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*model.Arena, 0, len(res.Items))
+	for _, v := range res.Items {
+		a, err := toModelArenaView(v)
+		if err != nil {
+			// linha corrompida no read model: sai da página e é reportada em errors
+			graphql.AddError(ctx, err)
+			continue
+		}
+		items = append(items, a)
+	}
+	return &model.ArenaPage{
+		Items:  items,
+		Total:  int32(res.Total),
+		Limit:  int32(res.Limit),
+		Offset: int32(res.Offset),
+	}, nil
 }
 
 // ArenaSnapshot is the resolver for the arenaSnapshot field.
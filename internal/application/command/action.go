@@ -0,0 +1,61 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/petri-board-arena/internal/application/port"
+	"github.com/petri-board-arena/internal/application/port/repository"
+	"github.com/petri-board-arena/internal/domain/arena"
+)
+
+// ----- SubmitAction
+
+type SubmitActionHandler struct {
+	tx  arenaTx
+	ids ActionIDGenerator
+}
+
+func NewSubmitActionHandler(
+	uow port.UnitOfWork,
+	repo repository.ArenaWriteRepository,
+	ids ActionIDGenerator,
+	clock port.Clock,
+	events EventPublisher,
+) *SubmitActionHandler {
+	return &SubmitActionHandler{
+		tx:  arenaTx{uow: uow, repo: repo, clock: clock, events: events},
+		ids: ids,
+	}
+}
+
+func (h *SubmitActionHandler) Handle(ctx context.Context, cmd SubmitActionCommand) (SubmitActionResult, error) {
+	actionID, err := h.ids.NewActionID(ctx)
+	if err != nil {
+		return SubmitActionResult{}, fmt.Errorf("submit_action: generate id: %w", err)
+	}
+
+	// cada tentativa parte do comando: em conflito a anterior foi desfeita e a arena,
+	// recarregada, pode estar em outro tick (ApplyAtTick 0 = próximo tick dela)
+	newAction := func() arena.PlayerAction {
+		return arena.PlayerAction{
+			ID:          actionID,
+			Type:        cmd.Type,
+			PlayerID:    cmd.PlayerID,
+			ApplyAtTick: cmd.ApplyAtTick,
+			Payload:     cmd.Payload,
+		}
+	}
+
+	var accepted arena.PlayerAction
+	_, err = h.tx.run(ctx, "submit_action", cmd.ArenaID, func(_ context.Context, a *arena.Arena, now time.Time) error {
+		var err error
+		accepted, err = a.SubmitAction(newAction(), now)
+		return err
+	})
+	if err != nil {
+		return SubmitActionResult{Action: newAction()}, err
+	}
+	return SubmitActionResult{Action: accepted}, nil
+}
@@ -0,0 +1,93 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/petri-board-arena/internal/application/port/repository"
+	"github.com/petri-board-arena/internal/domain/arena"
+)
+
+type fakeClock struct{ now time.Time }
+
+func (c fakeClock) Now() time.Time { return c.now }
+
+type fakeUoW struct{}
+
+func (fakeUoW) WithinTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+type fakeActionIDs struct{ id arena.ActionID }
+
+func (f fakeActionIDs) NewActionID(context.Context) (arena.ActionID, error) { return f.id, nil }
+
+type nopEvents struct{}
+
+func (nopEvents) Publish(context.Context, int64, ...arena.Event) error { return nil }
+
+// racingRepo simula outro escritor (o tick driver): o primeiro Save perde a corrida
+// para um tick avançado em paralelo.
+type racingRepo struct {
+	state     arena.RehydrateState
+	conflicts int
+	saved     []arena.PlayerAction
+}
+
+func (r *racingRepo) GetByID(context.Context, arena.ID) (*arena.Arena, error) {
+	return arena.Rehydrate(r.state)
+}
+
+func (r *racingRepo) Save(_ context.Context, a *arena.Arena) error {
+	if r.conflicts > 0 {
+		r.conflicts--
+		r.state.Tick++
+		r.state.Version++
+		return repository.ErrConcurrencyConflict
+	}
+	for _, ev := range a.PendingEvents() {
+		if s, ok := ev.(arena.ActionSubmitted); ok {
+			r.saved = append(r.saved, s.Action)
+		}
+	}
+	return nil
+}
+
+func TestSubmitActionRetriesFromTheCommand(t *testing.T) {
+	at := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	playerID := arena.PlayerID(uuid.New())
+	repo := &racingRepo{conflicts: 1, state: arena.RehydrateState{
+		ID:     arena.ID(uuid.New()),
+		Name:   "petri",
+		Status: arena.StatusRunning,
+		Tick:   7,
+		Config: arena.Config{
+			TickMillis: 100, Width: 10, Height: 10, MaxOrganisms: 10, SnapshotEveryTicks: 10,
+			Temperature: arena.Temperature{Unit: arena.TempC, Value: 20},
+		},
+		Players: []arena.Player{{ID: playerID, DisplayName: "alice", Role: arena.RoleAdmin, JoinedAt: at}},
+		Version: 3,
+	}}
+	actionID := arena.ActionID(uuid.New())
+	h := NewSubmitActionHandler(fakeUoW{}, repo, fakeActionIDs{id: actionID}, fakeClock{now: at}, nopEvents{})
+
+	res, err := h.Handle(context.Background(), SubmitActionCommand{
+		ArenaID:  repo.state.ID,
+		PlayerID: playerID,
+		Type:     arena.ActionAddNutrients,
+		Payload:  arena.AddNutrientsPayload{Area: arena.Area{Width: 1, Height: 1}, Amount: 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// o driver levou a arena ao tick 8 entre as tentativas: a ação vai para o 9
+	if res.Action.ApplyAtTick != 9 || res.Action.ID != actionID {
+		t.Fatalf("result action = tick %d id %v, want tick 9 id %v", res.Action.ApplyAtTick, res.Action.ID, actionID)
+	}
+	if len(repo.saved) != 1 || repo.saved[0].ApplyAtTick != 9 {
+		t.Fatalf("saved actions = %+v, want one at tick 9", repo.saved)
+	}
+}
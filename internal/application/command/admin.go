@@ -0,0 +1,29 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/petri-board-arena/internal/application/port"
+	"github.com/petri-board-arena/internal/application/port/repository"
+	"github.com/petri-board-arena/internal/domain/arena"
+)
+
+// ----- SetArenaConfig
+
+type SetArenaConfigHandler struct{ tx arenaTx }
+
+func NewSetArenaConfigHandler(
+	uow port.UnitOfWork,
+	repo repository.ArenaWriteRepository,
+	clock port.Clock,
+	events EventPublisher,
+) *SetArenaConfigHandler {
+	return &SetArenaConfigHandler{tx: arenaTx{uow: uow, repo: repo, clock: clock, events: events}}
+}
+
+func (h *SetArenaConfigHandler) Handle(ctx context.Context, cmd SetArenaConfigCommand) (*arena.Arena, error) {
+	return h.tx.run(ctx, "set_arena_config", cmd.ArenaID, func(_ context.Context, a *arena.Arena, now time.Time) error {
+		return a.UpdateConfig(cmd.Config, cmd.By, now)
+	})
+}
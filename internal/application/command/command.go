@@ -1,6 +1,11 @@
 package command
 
-import "github.com/petri-board-arena/internal/domain/arena"
+import (
+	"github.com/petri-board-arena/internal/application/port"
+	"github.com/petri-board-arena/internal/domain/arena"
+)
+
+// CreateArena
 
 type Command struct {
 	Name   string
@@ -11,4 +16,91 @@ type Command struct {
 
 type Result struct {
 	ArenaID arena.ID
+	Arena   *arena.Arena
+}
+
+// Lifecycle: By é quem executa (uuid.Nil = sistema)
+
+type StartArenaCommand struct {
+	ArenaID arena.ID
+	By      arena.PlayerID
+}
+
+type PauseArenaCommand struct {
+	ArenaID arena.ID
+	By      arena.PlayerID
+}
+
+type ResumeArenaCommand struct {
+	ArenaID arena.ID
+	By      arena.PlayerID
+}
+
+type StopArenaCommand struct {
+	ArenaID arena.ID
+	By      arena.PlayerID
+}
+
+// Player session
+
+type JoinArenaCommand struct {
+	ArenaID     arena.ID
+	DisplayName string
+}
+
+type JoinArenaResult struct {
+	Arena        *arena.Arena
+	Player       arena.Player
+	SessionToken string
+}
+
+type LeaveArenaCommand struct {
+	ArenaID  arena.ID
+	PlayerID arena.PlayerID
+}
+
+// Gameplay
+
+type SubmitActionCommand struct {
+	ArenaID  arena.ID
+	PlayerID arena.PlayerID
+	Type     arena.ActionType
+	// ApplyAtTick 0 = próximo tick
+	ApplyAtTick int64
+	Payload     arena.ActionPayload
+}
+
+type SubmitActionResult struct {
+	// Action.ID é preenchido mesmo quando a ação é rejeitada.
+	Action arena.PlayerAction
+}
+
+// Simulação: Tick é o tick que a arena deve atingir (o atual + 1); se ela já
+// chegou lá (outro driver, retry), o comando falha com ErrTickAlreadyAdvanced.
+
+type AdvanceTickCommand struct {
+	ArenaID arena.ID
+	Tick    int64
+}
+
+// Admin
+
+type SetArenaConfigCommand struct {
+	ArenaID arena.ID
+	Config  arena.Config
+	By      arena.PlayerID
+}
+
+// Outbox dead letters (ops): Actor é quem executa, registrado na auditoria
+
+type RequeueDeadLettersCommand struct {
+	Filter port.OutboxDeadLetterFilter
+	Actor  string
+	Reason string
+}
+
+type PurgeDeadLettersCommand struct {
+	Filter port.OutboxDeadLetterFilter
+	Actor  string
+	Reason string
 }
@@ -0,0 +1,50 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/petri-board-arena/internal/application/port"
+)
+
+// ----- RequeueDeadLetters
+
+type RequeueDeadLettersHandler struct {
+	store port.OutboxDeadLetterAdmin
+}
+
+func NewRequeueDeadLettersHandler(store port.OutboxDeadLetterAdmin) *RequeueDeadLettersHandler {
+	return &RequeueDeadLettersHandler{store: store}
+}
+
+func (h *RequeueDeadLettersHandler) Handle(ctx context.Context, cmd RequeueDeadLettersCommand) (int, error) {
+	if cmd.Filter.Empty() {
+		return 0, fmt.Errorf("requeue_dead_letters: %w", port.ErrEmptyDeadLetterFilter)
+	}
+	n, err := h.store.RequeueDeadLetters(ctx, cmd.Filter, cmd.Actor, cmd.Reason)
+	if err != nil {
+		return 0, fmt.Errorf("requeue_dead_letters: %w", err)
+	}
+	return n, nil
+}
+
+// ----- PurgeDeadLetters
+
+type PurgeDeadLettersHandler struct {
+	store port.OutboxDeadLetterAdmin
+}
+
+func NewPurgeDeadLettersHandler(store port.OutboxDeadLetterAdmin) *PurgeDeadLettersHandler {
+	return &PurgeDeadLettersHandler{store: store}
+}
+
+func (h *PurgeDeadLettersHandler) Handle(ctx context.Context, cmd PurgeDeadLettersCommand) (int, error) {
+	if cmd.Filter.Empty() {
+		return 0, fmt.Errorf("purge_dead_letters: %w", port.ErrEmptyDeadLetterFilter)
+	}
+	n, err := h.store.PurgeDeadLetters(ctx, cmd.Filter, cmd.Actor, cmd.Reason)
+	if err != nil {
+		return 0, fmt.Errorf("purge_dead_letters: %w", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,93 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/petri-board-arena/internal/application/port"
+	"github.com/petri-board-arena/internal/application/port/repository"
+	"github.com/petri-board-arena/internal/domain/arena"
+)
+
+// maxConflictRetries: novas tentativas quando o Save detecta escrita concorrente
+// (repository.ErrConcurrencyConflict); cada tentativa recarrega a arena.
+const (
+	maxConflictRetries = 3
+	conflictBackoff    = 5 * time.Millisecond
+)
+
+// arenaTx concentra o fluxo comum dos comandos sobre uma arena existente:
+// load -> comportamento de domínio -> save -> publish, tudo na mesma transação.
+// Em conflito de versão a transação inteira é refeita (fn deve ser idempotente).
+type arenaTx struct {
+	uow    port.UnitOfWork
+	repo   repository.ArenaWriteRepository
+	clock  port.Clock
+	events EventPublisher
+}
+
+func (x arenaTx) run(
+	ctx context.Context,
+	op string,
+	id arena.ID,
+	fn func(txCtx context.Context, a *arena.Arena, now time.Time) error,
+) (*arena.Arena, error) {
+	for attempt := 0; ; attempt++ {
+		a, err := x.once(ctx, op, id, fn)
+		if err == nil || !errors.Is(err, repository.ErrConcurrencyConflict) || attempt >= maxConflictRetries {
+			return a, err
+		}
+
+		// jitter evita que os mesmos concorrentes colidam de novo
+		wait := time.Duration(attempt+1)*conflictBackoff + time.Duration(rand.Int64N(int64(conflictBackoff)))
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(wait):
+		}
+	}
+}
+
+func (x arenaTx) once(
+	ctx context.Context,
+	op string,
+	id arena.ID,
+	fn func(txCtx context.Context, a *arena.Arena, now time.Time) error,
+) (*arena.Arena, error) {
+	now := x.clock.Now()
+
+	var out *arena.Arena
+
+	err := x.uow.WithinTransaction(ctx, func(txCtx context.Context) error {
+		a, err := x.repo.GetByID(txCtx, id)
+		if err != nil {
+			return fmt.Errorf("%s: load: %w", op, err)
+		}
+
+		if err := fn(txCtx, a, now); err != nil {
+			return fmt.Errorf("%s: domain reject: %w", op, err)
+		}
+
+		if err := x.repo.Save(txCtx, a); err != nil {
+			return fmt.Errorf("%s: persist: %w", op, err)
+		}
+
+		evs := a.PullEvents()
+		if len(evs) > 0 {
+			if err := x.events.Publish(txCtx, a.Version(), evs...); err != nil {
+				return fmt.Errorf("%s: publish events: %w", op, err)
+			}
+		}
+
+		out = a
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -14,8 +14,22 @@ type IDGenerator interface {
 	NewArenaID(ctx context.Context) (arena.ID, error)
 }
 
+type PlayerIDGenerator interface {
+	NewPlayerID(ctx context.Context) (arena.PlayerID, error)
+}
+
+type ActionIDGenerator interface {
+	NewActionID(ctx context.Context) (arena.ActionID, error)
+}
+
+type SessionTokenIssuer interface {
+	Issue(ctx context.Context, arenaID arena.ID, playerID arena.PlayerID) (string, error)
+}
+
+// EventPublisher recebe os eventos de um Save e a versão do aggregate depois dele:
+// o último evento tem a sequência version e os anteriores seguem em ordem.
 type EventPublisher interface {
-	Publish(ctx context.Context, events ...arena.Event) error
+	Publish(ctx context.Context, version int64, events ...arena.Event) error
 }
 
 type Handler struct {
@@ -45,7 +59,7 @@ func NewHandler(
 func (h *Handler) Handle(ctx context.Context, cmd Command) (Result, error) {
 	name := strings.TrimSpace(cmd.Name)
 	if len(name) < 3 {
-		return Result{}, fmt.Errorf("create_arena: name must have at least 3 chars")
+		return Result{}, fmt.Errorf("create_arena: %w: name must have at least 3 chars", arena.ErrInvalidName)
 	}
 
 	if err := cmd.Config.Validate(); err != nil {
@@ -73,12 +87,12 @@ func (h *Handler) Handle(ctx context.Context, cmd Command) (Result, error) {
 
 		evs := a.PullEvents()
 		if len(evs) > 0 {
-			if err := h.events.Publish(txCtx, evs...); err != nil {
+			if err := h.events.Publish(txCtx, a.Version(), evs...); err != nil {
 				return fmt.Errorf("create_arena: publish events: %w", err)
 			}
 		}
 
-		out = Result{ArenaID: arenaID}
+		out = Result{ArenaID: arenaID, Arena: a}
 		return nil
 	})
 
@@ -0,0 +1,86 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/petri-board-arena/internal/application/port"
+	"github.com/petri-board-arena/internal/application/port/repository"
+	"github.com/petri-board-arena/internal/domain/arena"
+)
+
+// ----- StartArena
+
+type StartArenaHandler struct{ tx arenaTx }
+
+func NewStartArenaHandler(
+	uow port.UnitOfWork,
+	repo repository.ArenaWriteRepository,
+	clock port.Clock,
+	events EventPublisher,
+) *StartArenaHandler {
+	return &StartArenaHandler{tx: arenaTx{uow: uow, repo: repo, clock: clock, events: events}}
+}
+
+func (h *StartArenaHandler) Handle(ctx context.Context, cmd StartArenaCommand) (*arena.Arena, error) {
+	return h.tx.run(ctx, "start_arena", cmd.ArenaID, func(_ context.Context, a *arena.Arena, now time.Time) error {
+		return a.Start(now, cmd.By)
+	})
+}
+
+// ----- PauseArena
+
+type PauseArenaHandler struct{ tx arenaTx }
+
+func NewPauseArenaHandler(
+	uow port.UnitOfWork,
+	repo repository.ArenaWriteRepository,
+	clock port.Clock,
+	events EventPublisher,
+) *PauseArenaHandler {
+	return &PauseArenaHandler{tx: arenaTx{uow: uow, repo: repo, clock: clock, events: events}}
+}
+
+func (h *PauseArenaHandler) Handle(ctx context.Context, cmd PauseArenaCommand) (*arena.Arena, error) {
+	return h.tx.run(ctx, "pause_arena", cmd.ArenaID, func(_ context.Context, a *arena.Arena, now time.Time) error {
+		return a.Pause(now, cmd.By)
+	})
+}
+
+// ----- ResumeArena
+
+type ResumeArenaHandler struct{ tx arenaTx }
+
+func NewResumeArenaHandler(
+	uow port.UnitOfWork,
+	repo repository.ArenaWriteRepository,
+	clock port.Clock,
+	events EventPublisher,
+) *ResumeArenaHandler {
+	return &ResumeArenaHandler{tx: arenaTx{uow: uow, repo: repo, clock: clock, events: events}}
+}
+
+func (h *ResumeArenaHandler) Handle(ctx context.Context, cmd ResumeArenaCommand) (*arena.Arena, error) {
+	return h.tx.run(ctx, "resume_arena", cmd.ArenaID, func(_ context.Context, a *arena.Arena, now time.Time) error {
+		return a.Resume(now, cmd.By)
+	})
+}
+
+// ----- StopArena
+
+type StopArenaHandler struct{ tx arenaTx }
+
+func NewStopArenaHandler(
+	uow port.UnitOfWork,
+	repo repository.ArenaWriteRepository,
+	clock port.Clock,
+	events EventPublisher,
+) *StopArenaHandler {
+	return &StopArenaHandler{tx: arenaTx{uow: uow, repo: repo, clock: clock, events: events}}
+}
+
+func (h *StopArenaHandler) Handle(ctx context.Context, cmd StopArenaCommand) (*arena.Arena, error) {
+	return h.tx.run(ctx, "stop_arena", cmd.ArenaID, func(_ context.Context, a *arena.Arena, now time.Time) error {
+		return a.Stop(now, cmd.By)
+	})
+}
@@ -0,0 +1,84 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/petri-board-arena/internal/application/port"
+	"github.com/petri-board-arena/internal/application/port/repository"
+	"github.com/petri-board-arena/internal/domain/arena"
+)
+
+// ----- JoinArena
+
+type JoinArenaHandler struct {
+	tx     arenaTx
+	ids    PlayerIDGenerator
+	tokens SessionTokenIssuer
+}
+
+func NewJoinArenaHandler(
+	uow port.UnitOfWork,
+	repo repository.ArenaWriteRepository,
+	ids PlayerIDGenerator,
+	tokens SessionTokenIssuer,
+	clock port.Clock,
+	events EventPublisher,
+) *JoinArenaHandler {
+	return &JoinArenaHandler{
+		tx:     arenaTx{uow: uow, repo: repo, clock: clock, events: events},
+		ids:    ids,
+		tokens: tokens,
+	}
+}
+
+func (h *JoinArenaHandler) Handle(ctx context.Context, cmd JoinArenaCommand) (JoinArenaResult, error) {
+	var out JoinArenaResult
+
+	a, err := h.tx.run(ctx, "join_arena", cmd.ArenaID, func(txCtx context.Context, a *arena.Arena, now time.Time) error {
+		pid, err := h.ids.NewPlayerID(txCtx)
+		if err != nil {
+			return fmt.Errorf("generate player id: %w", err)
+		}
+
+		p, err := a.Join(pid, cmd.DisplayName, now)
+		if err != nil {
+			return err
+		}
+
+		token, err := h.tokens.Issue(txCtx, a.ID(), p.ID)
+		if err != nil {
+			return fmt.Errorf("issue session token: %w", err)
+		}
+
+		out.Player = p
+		out.SessionToken = token
+		return nil
+	})
+	if err != nil {
+		return JoinArenaResult{}, err
+	}
+
+	out.Arena = a
+	return out, nil
+}
+
+// ----- LeaveArena
+
+type LeaveArenaHandler struct{ tx arenaTx }
+
+func NewLeaveArenaHandler(
+	uow port.UnitOfWork,
+	repo repository.ArenaWriteRepository,
+	clock port.Clock,
+	events EventPublisher,
+) *LeaveArenaHandler {
+	return &LeaveArenaHandler{tx: arenaTx{uow: uow, repo: repo, clock: clock, events: events}}
+}
+
+func (h *LeaveArenaHandler) Handle(ctx context.Context, cmd LeaveArenaCommand) (*arena.Arena, error) {
+	return h.tx.run(ctx, "leave_arena", cmd.ArenaID, func(_ context.Context, a *arena.Arena, now time.Time) error {
+		return a.Leave(cmd.PlayerID, now)
+	})
+}
@@ -0,0 +1,63 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/petri-board-arena/internal/application/port"
+	"github.com/petri-board-arena/internal/application/port/repository"
+	"github.com/petri-board-arena/internal/domain/arena"
+	"github.com/petri-board-arena/internal/domain/simulation"
+)
+
+// ErrTickAlreadyAdvanced: a arena já passou do tick pedido; nada é salvo.
+var ErrTickAlreadyAdvanced = errors.New("tick already advanced")
+
+// ----- AdvanceTick
+
+// AdvanceTickHandler avança a arena um tick (comando do sistema, sem jogador).
+// As ações vencidas saem do agendamento pelo TickAdvanced e são aplicadas ao mundo
+// (simulation.StepArena), salvo na mesma transação que a arena.
+type AdvanceTickHandler struct {
+	tx     arenaTx
+	worlds repository.ArenaWorldRepository
+}
+
+func NewAdvanceTickHandler(
+	uow port.UnitOfWork,
+	repo repository.ArenaWriteRepository,
+	worlds repository.ArenaWorldRepository,
+	clock port.Clock,
+	events EventPublisher,
+) *AdvanceTickHandler {
+	return &AdvanceTickHandler{
+		tx:     arenaTx{uow: uow, repo: repo, clock: clock, events: events},
+		worlds: worlds,
+	}
+}
+
+func (h *AdvanceTickHandler) Handle(ctx context.Context, cmd AdvanceTickCommand) (*arena.Arena, error) {
+	return h.tx.run(ctx, "advance_tick", cmd.ArenaID, func(txCtx context.Context, a *arena.Arena, now time.Time) error {
+		// o tick esperado torna o comando idempotente entre retries de conflito e drivers concorrentes
+		if a.Tick() >= cmd.Tick {
+			return ErrTickAlreadyAdvanced
+		}
+
+		world, err := h.worlds.GetWorld(txCtx, a.ID())
+		if errors.Is(err, repository.ErrWorldNotFound) {
+			// primeiro tick da arena: o mundo nasce da config e da seed do ID
+			world, err = simulation.NewArenaState(a)
+		}
+		if err != nil {
+			return fmt.Errorf("load world: %w", err)
+		}
+
+		next, _, err := simulation.StepArena(world, a, now)
+		if err != nil {
+			return err
+		}
+		return h.worlds.SaveWorld(txCtx, a.ID(), next)
+	})
+}
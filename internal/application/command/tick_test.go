@@ -0,0 +1,126 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/petri-board-arena/internal/application/port/repository"
+	"github.com/petri-board-arena/internal/domain/arena"
+	"github.com/petri-board-arena/internal/domain/simulation"
+)
+
+// tickRepo guarda a arena como o write model: o Save leva o tick e descarta as ações vencidas.
+type tickRepo struct{ state arena.RehydrateState }
+
+func (r *tickRepo) GetByID(context.Context, arena.ID) (*arena.Arena, error) {
+	return arena.Rehydrate(r.state)
+}
+
+func (r *tickRepo) Save(_ context.Context, a *arena.Arena) error {
+	r.state.Tick = a.Tick()
+	r.state.Status = a.Status()
+	r.state.Version++
+	for tick := range r.state.Scheduled {
+		if tick <= a.Tick() {
+			delete(r.state.Scheduled, tick)
+		}
+	}
+	return nil
+}
+
+type memWorlds struct {
+	worlds map[arena.ID]simulation.State
+	saves  int
+}
+
+func (m *memWorlds) GetWorld(_ context.Context, id arena.ID) (simulation.State, error) {
+	s, ok := m.worlds[id]
+	if !ok {
+		return simulation.State{}, repository.ErrWorldNotFound
+	}
+	return s, nil
+}
+
+func (m *memWorlds) SaveWorld(_ context.Context, id arena.ID, s simulation.State) error {
+	m.worlds[id] = s
+	m.saves++
+	return nil
+}
+
+func runningArena(at time.Time) arena.RehydrateState {
+	return arena.RehydrateState{
+		ID:     arena.ID(uuid.New()),
+		Name:   "petri",
+		Status: arena.StatusRunning,
+		Tick:   4,
+		Config: arena.Config{
+			TickMillis: 100, Width: 10, Height: 10, MaxOrganisms: 10, SnapshotEveryTicks: 10,
+			Temperature: arena.Temperature{Unit: arena.TempC, Value: 20},
+		},
+		Players: []arena.Player{{ID: arena.PlayerID(uuid.New()), DisplayName: "alice", Role: arena.RoleAdmin, JoinedAt: at}},
+		Version: 3,
+	}
+}
+
+func TestAdvanceTickStepsAndSavesTheWorld(t *testing.T) {
+	at := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	repo := &tickRepo{state: runningArena(at)}
+	repo.state.Scheduled = map[int64][]arena.PlayerAction{
+		6: {{
+			ID:          arena.ActionID(uuid.New()),
+			Type:        arena.ActionSetTemperature,
+			PlayerID:    repo.state.Players[0].ID,
+			SubmittedAt: at,
+			ApplyAtTick: 6,
+			Payload:     arena.SetTemperaturePayload{Temperature: arena.Temperature{Unit: arena.TempC, Value: 31}},
+		}},
+	}
+	worlds := &memWorlds{worlds: map[arena.ID]simulation.State{}}
+	h := NewAdvanceTickHandler(fakeUoW{}, repo, worlds, fakeClock{now: at}, nopEvents{})
+	id := repo.state.ID
+
+	// tick 5: sem mundo salvo, nasce um no tick da arena
+	if _, err := h.Handle(context.Background(), AdvanceTickCommand{ArenaID: id, Tick: 5}); err != nil {
+		t.Fatal(err)
+	}
+	if w := worlds.worlds[id]; w.Tick != 5 || w.Ambient != 20 {
+		t.Fatalf("world after tick 5 = tick %d ambient %v, want tick 5 ambient 20", w.Tick, w.Ambient)
+	}
+
+	// tick 6: o mundo salvo é carregado e recebe a ação drenada do agendamento
+	if _, err := h.Handle(context.Background(), AdvanceTickCommand{ArenaID: id, Tick: 6}); err != nil {
+		t.Fatal(err)
+	}
+	if w := worlds.worlds[id]; w.Tick != 6 || w.Ambient != 31 {
+		t.Fatalf("world after tick 6 = tick %d ambient %v, want tick 6 ambient 31", w.Tick, w.Ambient)
+	}
+	if len(repo.state.Scheduled) != 0 {
+		t.Fatalf("scheduled = %+v, want drained", repo.state.Scheduled)
+	}
+}
+
+func TestAdvanceTickRejectsWorldOffTheArenaTick(t *testing.T) {
+	at := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	repo := &tickRepo{state: runningArena(at)}
+	id := repo.state.ID
+
+	stale, err := simulation.NewState(repo.state.Config, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale.Tick = 2
+	worlds := &memWorlds{worlds: map[arena.ID]simulation.State{id: stale}}
+	h := NewAdvanceTickHandler(fakeUoW{}, repo, worlds, fakeClock{now: at}, nopEvents{})
+
+	_, err = h.Handle(context.Background(), AdvanceTickCommand{ArenaID: id, Tick: 5})
+	if !errors.Is(err, simulation.ErrWorldOutOfSync) {
+		t.Fatalf("err = %v, want ErrWorldOutOfSync", err)
+	}
+	if repo.state.Tick != 4 || worlds.saves != 0 {
+		t.Fatalf("arena tick %d, world saves %d: want nothing saved", repo.state.Tick, worlds.saves)
+	}
+}
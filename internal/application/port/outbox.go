@@ -1,7 +1,9 @@
 package port
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -19,6 +21,8 @@ const (
 // OutboxEvent maps to table: outbox_event
 type OutboxEvent struct {
 	ID uuid.UUID `json:"id"`
+	// Seq ordena eventos gravados na mesma transação (created_at empata)
+	Seq int64 `json:"seq"`
 
 	// Identity / routing
 	AggregateType string `json:"aggregateType"`
@@ -42,6 +46,7 @@ type OutboxEvent struct {
 
 	NextAttemptAt time.Time  `json:"nextAttemptAt"`
 	PublishedAt   *time.Time `json:"publishedAt,omitempty"`
+	LastError     *string    `json:"lastError,omitempty"`
 
 	// Locking (multi outbox workers)
 	LockedBy      *string    `json:"lockedBy,omitempty"`
@@ -80,7 +85,9 @@ type OutboxLockParams struct {
 }
 
 type OutboxMarkFailedParams struct {
-	EventID      uuid.UUID
+	EventID uuid.UUID
+	// WorkerID é o dono do lock; vazio não verifica o dono.
+	WorkerID     string
 	BaseBackoff  time.Duration
 	LastErrorMsg string
 }
@@ -128,3 +135,61 @@ type OutboxDeadLetterParams struct {
 	Attempts  int
 	LastError string
 }
+
+// OutboxStore é a persistência do outbox (tabela outbox_event + outbox_dead_letter).
+type OutboxStore interface {
+	// Enqueue grava o evento na transação corrente (ver postgres.TxFrom).
+	Enqueue(ctx context.Context, p OutboxEnqueueParams) error
+	// LockBatch reserva até BatchSize eventos prontos para publicação, incluindo
+	// os PROCESSING cujo lock expirou (o que consome uma tentativa e pode mandá-los
+	// para o dead-letter). Nunca reserva um evento enquanto houver um anterior do
+	// mesmo aggregate pendente de publicação.
+	LockBatch(ctx context.Context, p OutboxLockParams) ([]OutboxEvent, error)
+	// MarkPublished exige que o evento ainda esteja reservado por workerID (vazio não verifica).
+	MarkPublished(ctx context.Context, eventID uuid.UUID, workerID string) error
+	// Release devolve eventos reservados por workerID para PENDING sem contar tentativa.
+	Release(ctx context.Context, workerID string, eventIDs ...uuid.UUID) error
+	// MarkFailed agenda nova tentativa com backoff exponencial; retorna true se o
+	// evento esgotou MaxAttempts e foi movido para o dead-letter.
+	MarkFailed(ctx context.Context, p OutboxMarkFailedParams) (deadLettered bool, err error)
+	MoveToDeadLetter(ctx context.Context, p OutboxDeadLetterParams) error
+}
+
+// ErrEmptyDeadLetterFilter: requeue/purge sem critério afetariam todos os dead letters.
+var ErrEmptyDeadLetterFilter = errors.New("dead letter filter requires at least one criterion")
+
+// OutboxDeadLetterFilter seleciona dead letters; critérios vazios são ignorados.
+type OutboxDeadLetterFilter struct {
+	IDs         []uuid.UUID
+	AggregateID *string
+	EventType   *string
+	// From/To filtram created_at (do dead letter): From inclusivo, To exclusivo.
+	From *time.Time
+	To   *time.Time
+
+	Limit  int
+	Offset int
+}
+
+// Empty indica que nenhum critério foi informado (requeue/purge exigem ao menos um).
+func (f OutboxDeadLetterFilter) Empty() bool {
+	return len(f.IDs) == 0 && f.AggregateID == nil && f.EventType == nil && f.From == nil && f.To == nil
+}
+
+// OutboxDeadLetterAction identifica a operação registrada na auditoria.
+type OutboxDeadLetterAction string
+
+const (
+	DeadLetterRequeue OutboxDeadLetterAction = "REQUEUE"
+	DeadLetterPurge   OutboxDeadLetterAction = "PURGE"
+)
+
+// OutboxDeadLetterAdmin opera outbox_dead_letter. Requeue/Purge gravam uma linha
+// de auditoria por dead letter (outbox_dead_letter_audit) na mesma transação.
+type OutboxDeadLetterAdmin interface {
+	ListDeadLetters(ctx context.Context, f OutboxDeadLetterFilter) ([]OutboxDeadLetter, error)
+	// RequeueDeadLetters devolve os eventos ao outbox_event como PENDING com attempts zerado,
+	// mantendo id e IdempotencyKey originais. Retorna quantos foram reenfileirados.
+	RequeueDeadLetters(ctx context.Context, f OutboxDeadLetterFilter, actor, reason string) (int, error)
+	PurgeDeadLetters(ctx context.Context, f OutboxDeadLetterFilter, actor, reason string) (int, error)
+}
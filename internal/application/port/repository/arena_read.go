@@ -7,6 +7,8 @@ import (
 )
 
 type ArenaReadRepository interface {
+	// GetArena retorna ErrArenaNotFound quando a arena não está no read model.
 	GetArena(ctx context.Context, id string) (*dto.ArenaView, error)
-	ListArenas(ctx context.Context, status *string, limit, offset int) ([]dto.ArenaView, int, error)
+	// ListArenas devolve a página (mais recentes primeiro) e o total que casa com o filtro.
+	ListArenas(ctx context.Context, f dto.ArenaFilter) ([]dto.ArenaView, int, error)
 }
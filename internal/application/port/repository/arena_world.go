@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/petri-board-arena/internal/domain/arena"
+	"github.com/petri-board-arena/internal/domain/simulation"
+)
+
+// ErrWorldNotFound: a arena ainda não tem mundo (nenhum tick foi dado).
+var ErrWorldNotFound = errors.New("arena world not found")
+
+// ArenaWorldRepository guarda o mundo simulado de cada arena. Deve ser usado com o
+// txCtx do UnitOfWork: o mundo e o tick do aggregate avançam na mesma transação.
+type ArenaWorldRepository interface {
+	GetWorld(ctx context.Context, id arena.ID) (simulation.State, error)
+	SaveWorld(ctx context.Context, id arena.ID, s simulation.State) error
+}
@@ -8,5 +8,7 @@ import (
 
 type ArenaWriteRepository interface {
 	GetByID(ctx context.Context, id arena.ID) (*arena.Arena, error)
+	// Save avança a versão do aggregate em uma unidade por evento pendente (pelo menos
+	// uma): o evento i de um Save tem a sequência Version()-len(eventos)+1+i.
 	Save(ctx context.Context, a *arena.Arena) error
 }
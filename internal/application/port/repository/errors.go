@@ -0,0 +1,10 @@
+package repository
+
+import "errors"
+
+// ErrArenaNotFound é retornado pelos repositórios (write e read) quando a arena não existe.
+var ErrArenaNotFound = errors.New("arena not found")
+
+// ErrConcurrencyConflict é retornado pelo Save quando o aggregate mudou desde o load
+// (versão esperada diferente da persistida).
+var ErrConcurrencyConflict = errors.New("concurrency conflict")
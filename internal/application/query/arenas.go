@@ -0,0 +1,63 @@
+package query
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/petri-board-arena/internal/application/port/repository"
+	"github.com/petri-board-arena/internal/application/query/dto"
+)
+
+const (
+	defaultArenaPageLimit = 20
+	maxArenaPageLimit     = 100
+)
+
+type GetArenaHandler struct {
+	repo repository.ArenaReadRepository
+}
+
+func NewGetArenaHandler(repo repository.ArenaReadRepository) *GetArenaHandler {
+	return &GetArenaHandler{repo: repo}
+}
+
+func (h *GetArenaHandler) Handle(ctx context.Context, id string) (*dto.ArenaView, error) {
+	v, err := h.repo.GetArena(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get_arena: %w", err)
+	}
+	return v, nil
+}
+
+type ListArenasHandler struct {
+	repo repository.ArenaReadRepository
+}
+
+func NewListArenasHandler(repo repository.ArenaReadRepository) *ListArenasHandler {
+	return &ListArenasHandler{repo: repo}
+}
+
+type ListArenasResult struct {
+	Items  []dto.ArenaView
+	Total  int
+	Limit  int
+	Offset int
+}
+
+func (h *ListArenasHandler) Handle(ctx context.Context, f dto.ArenaFilter) (ListArenasResult, error) {
+	if f.Limit <= 0 {
+		f.Limit = defaultArenaPageLimit
+	}
+	if f.Limit > maxArenaPageLimit {
+		f.Limit = maxArenaPageLimit
+	}
+	if f.Offset < 0 {
+		f.Offset = 0
+	}
+
+	items, total, err := h.repo.ListArenas(ctx, f)
+	if err != nil {
+		return ListArenasResult{}, fmt.Errorf("list_arenas: %w", err)
+	}
+	return ListArenasResult{Items: items, Total: total, Limit: f.Limit, Offset: f.Offset}, nil
+}
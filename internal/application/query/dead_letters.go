@@ -0,0 +1,24 @@
+package query
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/petri-board-arena/internal/application/port"
+)
+
+type ListDeadLettersHandler struct {
+	store port.OutboxDeadLetterAdmin
+}
+
+func NewListDeadLettersHandler(store port.OutboxDeadLetterAdmin) *ListDeadLettersHandler {
+	return &ListDeadLettersHandler{store: store}
+}
+
+func (h *ListDeadLettersHandler) Handle(ctx context.Context, f port.OutboxDeadLetterFilter) ([]port.OutboxDeadLetter, error) {
+	out, err := h.store.ListDeadLetters(ctx, f)
+	if err != nil {
+		return nil, fmt.Errorf("list_dead_letters: %w", err)
+	}
+	return out, nil
+}
@@ -1,12 +1,28 @@
 package dto
 
-import "time"
+import (
+	"time"
+
+	"github.com/petri-board-arena/internal/domain/arena"
+)
 
 type ArenaView struct {
-	ID        string
-	Name      string
-	Status    string
-	Tick      int64
-	CreatedAt time.Time
-	StartedAt *time.Time
+	ID         string
+	Name       string
+	Status     string
+	Tick       int64
+	CreatedAt  time.Time
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+	Config     arena.Config
+	// Players em ordem de entrada na arena.
+	Players []arena.Player
+}
+
+// ArenaFilter: campos vazios não filtram; NameContains é case-insensitive.
+type ArenaFilter struct {
+	Status       *string
+	NameContains string
+	Limit        int
+	Offset       int
 }
@@ -0,0 +1,144 @@
+package ticker
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/petri-board-arena/internal/application/command"
+	"github.com/petri-board-arena/internal/application/port"
+	"github.com/petri-board-arena/internal/application/port/repository"
+	"github.com/petri-board-arena/internal/application/query/dto"
+	"github.com/petri-board-arena/internal/domain/arena"
+)
+
+// pageSize: arenas RUNNING lidas por página do read model.
+const pageSize = 100
+
+type TickAdvancer interface {
+	Handle(ctx context.Context, cmd command.AdvanceTickCommand) (*arena.Arena, error)
+}
+
+// Driver avança as arenas em andamento a cada config.TickMillis.
+//
+// A lista de arenas RUNNING vem do read model; o AdvanceTickCommand confere status
+// e tick no write model, então atraso da projeção não faz a arena pular ou repetir
+// tick, e várias instâncias do driver podem rodar juntas (a que perder recebe
+// ErrTickAlreadyAdvanced).
+type Driver struct {
+	arenas  repository.ArenaReadRepository
+	advance TickAdvancer
+	clock   port.Clock
+
+	// próximo tick vencido e último tick atingido por este driver, por arena
+	due  map[string]time.Time
+	tick map[string]int64
+}
+
+func NewDriver(arenas repository.ArenaReadRepository, advance TickAdvancer, clock port.Clock) *Driver {
+	return &Driver{
+		arenas:  arenas,
+		advance: advance,
+		clock:   clock,
+		due:     map[string]time.Time{},
+		tick:    map[string]int64{},
+	}
+}
+
+// Run chama RunOnce a cada interval até o ctx ser cancelado.
+func (d *Driver) Run(ctx context.Context, interval time.Duration) {
+	log.Printf("[tick-driver] interval=%s", interval)
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		d.RunOnce(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// RunOnce avança um tick de cada arena RUNNING cujo intervalo venceu.
+// Uma arena recém-vista espera um intervalo inteiro antes do primeiro tick.
+func (d *Driver) RunOnce(ctx context.Context) {
+	views, err := d.running(ctx)
+	if err != nil {
+		log.Printf("[tick-driver] list running arenas: %v", err)
+		return
+	}
+
+	now := d.clock.Now()
+	seen := make(map[string]bool, len(views))
+	for _, v := range views {
+		seen[v.ID] = true
+		interval := time.Duration(v.Config.TickMillis) * time.Millisecond
+
+		due, ok := d.due[v.ID]
+		if !ok {
+			d.due[v.ID] = now.Add(interval)
+			continue
+		}
+		if now.Before(due) {
+			continue
+		}
+		// atrasado mais de um intervalo: não acumula ticks para alcançar
+		if next := due.Add(interval); next.After(now) {
+			d.due[v.ID] = next
+		} else {
+			d.due[v.ID] = now.Add(interval)
+		}
+
+		d.advanceArena(ctx, v)
+	}
+
+	// arenas que saíram de RUNNING (pausa, fim) recomeçam a contagem quando voltarem
+	for id := range d.due {
+		if !seen[id] {
+			delete(d.due, id)
+			delete(d.tick, id)
+		}
+	}
+}
+
+func (d *Driver) advanceArena(ctx context.Context, v dto.ArenaView) {
+	id, err := uuid.Parse(v.ID)
+	if err != nil {
+		log.Printf("[tick-driver] arena %q: invalid id: %v", v.ID, err)
+		return
+	}
+
+	// o read model pode estar atrás do que este driver já gravou
+	current := max(v.Tick, d.tick[v.ID])
+	a, err := d.advance.Handle(ctx, command.AdvanceTickCommand{ArenaID: arena.ID(id), Tick: current + 1})
+	switch {
+	case err == nil:
+		d.tick[v.ID] = a.Tick()
+	case errors.Is(err, command.ErrTickAlreadyAdvanced):
+		// outro driver avançou: volta a confiar no read model
+		delete(d.tick, v.ID)
+	case errors.Is(err, arena.ErrArenaNotRunning), errors.Is(err, arena.ErrArenaFinished):
+		// pausada/parada depois da leitura do read model
+	default:
+		log.Printf("[tick-driver] arena %s: %v", v.ID, err)
+	}
+}
+
+func (d *Driver) running(ctx context.Context) ([]dto.ArenaView, error) {
+	status := string(arena.StatusRunning)
+	var out []dto.ArenaView
+	for offset := 0; ; offset += pageSize {
+		page, total, err := d.arenas.ListArenas(ctx, dto.ArenaFilter{Status: &status, Limit: pageSize, Offset: offset})
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, page...)
+		if len(page) < pageSize || offset+len(page) >= total {
+			return out, nil
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package ticker
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/petri-board-arena/internal/application/command"
+	"github.com/petri-board-arena/internal/application/port/repository"
+	"github.com/petri-board-arena/internal/application/query/dto"
+	"github.com/petri-board-arena/internal/domain/arena"
+)
+
+type fakeClock struct{ now time.Time }
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+// fakeArenas é o read model: devolve views como estão, mesmo atrás do write model.
+type fakeArenas struct{ views []dto.ArenaView }
+
+func (f *fakeArenas) GetArena(context.Context, string) (*dto.ArenaView, error) {
+	return nil, repository.ErrArenaNotFound
+}
+
+func (f *fakeArenas) ListArenas(_ context.Context, flt dto.ArenaFilter) ([]dto.ArenaView, int, error) {
+	var out []dto.ArenaView
+	for _, v := range f.views {
+		if flt.Status == nil || v.Status == *flt.Status {
+			out = append(out, v)
+		}
+	}
+	total := len(out)
+	if flt.Offset >= total {
+		return nil, total, nil
+	}
+	return out[flt.Offset:min(flt.Offset+flt.Limit, total)], total, nil
+}
+
+// fakeAdvancer é o write model: guarda o tick de cada arena e confere o tick esperado.
+type fakeAdvancer struct {
+	ticks map[arena.ID]int64
+	calls []command.AdvanceTickCommand
+}
+
+func (f *fakeAdvancer) Handle(_ context.Context, cmd command.AdvanceTickCommand) (*arena.Arena, error) {
+	f.calls = append(f.calls, cmd)
+	if f.ticks[cmd.ArenaID] >= cmd.Tick {
+		return nil, fmt.Errorf("advance_tick: domain reject: %w", command.ErrTickAlreadyAdvanced)
+	}
+	f.ticks[cmd.ArenaID]++
+	return arena.Rehydrate(arena.RehydrateState{
+		ID:     cmd.ArenaID,
+		Name:   "petri",
+		Status: arena.StatusRunning,
+		Tick:   f.ticks[cmd.ArenaID],
+		Config: testConfig(),
+	})
+}
+
+func testConfig() arena.Config {
+	return arena.Config{
+		TickMillis: 100, Width: 10, Height: 10, MaxOrganisms: 10, SnapshotEveryTicks: 10,
+		Temperature: arena.Temperature{Unit: arena.TempC, Value: 20},
+	}
+}
+
+func TestDriverAdvancesDueArenas(t *testing.T) {
+	id := arena.ID(uuid.New())
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	arenas := &fakeArenas{views: []dto.ArenaView{
+		{ID: id.String(), Status: string(arena.StatusRunning), Tick: 4, Config: testConfig()},
+		{ID: uuid.NewString(), Status: string(arena.StatusPaused), Tick: 9, Config: testConfig()},
+	}}
+	adv := &fakeAdvancer{ticks: map[arena.ID]int64{id: 4}}
+	d := NewDriver(arenas, adv, clock)
+	ctx := context.Background()
+
+	steps := []struct {
+		name      string
+		after     time.Duration
+		wantCalls int
+		wantTick  int64
+	}{
+		{"first sighting waits a full interval", 0, 0, 4},
+		{"not due yet", 50 * time.Millisecond, 0, 4},
+		{"due", 50 * time.Millisecond, 1, 5},
+		// read model ainda em 4: o driver usa o tick que ele mesmo gravou
+		{"due again with a lagging read model", 100 * time.Millisecond, 2, 6},
+		{"late by several intervals advances once", time.Second, 3, 7},
+	}
+	for _, s := range steps {
+		clock.now = clock.now.Add(s.after)
+		d.RunOnce(ctx)
+		if len(adv.calls) != s.wantCalls || adv.ticks[id] != s.wantTick {
+			t.Fatalf("%s: calls=%d tick=%d, want calls=%d tick=%d", s.name, len(adv.calls), adv.ticks[id], s.wantCalls, s.wantTick)
+		}
+	}
+	for _, c := range adv.calls {
+		if c.ArenaID != id {
+			t.Fatalf("advanced arena %s, only %s is RUNNING", c.ArenaID, id)
+		}
+	}
+}
+
+func TestDriverBacksOffWhenAnotherDriverAdvanced(t *testing.T) {
+	id := arena.ID(uuid.New())
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	view := dto.ArenaView{ID: id.String(), Status: string(arena.StatusRunning), Tick: 2, Config: testConfig()}
+	arenas := &fakeArenas{views: []dto.ArenaView{view}}
+	// outro driver já levou a arena ao tick 3
+	adv := &fakeAdvancer{ticks: map[arena.ID]int64{id: 3}}
+	d := NewDriver(arenas, adv, clock)
+	ctx := context.Background()
+
+	d.RunOnce(ctx)
+	clock.now = clock.now.Add(100 * time.Millisecond)
+	d.RunOnce(ctx)
+	if adv.ticks[id] != 3 {
+		t.Fatalf("tick = %d, want 3 (stale command must not advance)", adv.ticks[id])
+	}
+
+	// a projeção alcança o write model: o próximo tick vencido avança
+	arenas.views[0].Tick = 3
+	clock.now = clock.now.Add(100 * time.Millisecond)
+	d.RunOnce(ctx)
+	if adv.ticks[id] != 4 {
+		t.Fatalf("tick = %d, want 4", adv.ticks[id])
+	}
+	if got := adv.calls[len(adv.calls)-1].Tick; got != 4 {
+		t.Fatalf("expected tick in command = %d, want 4", got)
+	}
+}
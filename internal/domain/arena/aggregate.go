@@ -1,9 +1,13 @@
 package arena
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 )
@@ -24,6 +28,9 @@ type Arena struct {
 	players          map[PlayerID]Player
 	scheduledActions map[int64][]PlayerAction
 
+	// version é a versão persistida (0 = nunca salva); o repositório usa para concorrência otimista.
+	version int64
+
 	events []Event
 }
 
@@ -37,6 +44,10 @@ func (a *Arena) Config() Config         { return a.config }
 func (a *Arena) CreatedAt() time.Time   { return a.createdAt }
 func (a *Arena) StartedAt() *time.Time  { return a.startedAt }
 func (a *Arena) FinishedAt() *time.Time { return a.finishedAt }
+func (a *Arena) Version() int64         { return a.version }
+
+// SetVersion is called by the repository after a successful save.
+func (a *Arena) SetVersion(v int64) { a.version = v }
 
 func (a *Arena) Players() []Player {
 	out := make([]Player, 0, len(a.players))
@@ -46,6 +57,20 @@ func (a *Arena) Players() []Player {
 	return out
 }
 
+// ScheduledFor returns a copy of the actions due at the given tick.
+func (a *Arena) ScheduledFor(tick int64) []PlayerAction {
+	return append([]PlayerAction(nil), a.scheduledActions[tick]...)
+}
+
+// Scheduled returns a copy of every pending action keyed by tick, in submission order.
+func (a *Arena) Scheduled() map[int64][]PlayerAction {
+	out := make(map[int64][]PlayerAction, len(a.scheduledActions))
+	for t, actions := range a.scheduledActions {
+		out[t] = append([]PlayerAction(nil), actions...)
+	}
+	return out
+}
+
 func (a *Arena) PullEvents() []Event {
 	ev := a.events
 	a.events = nil
@@ -69,19 +94,9 @@ func NewArena(id ID, name string, cfg Config, now time.Time) (*Arena, error) {
 		return nil, err
 	}
 
-	ar := &Arena{
-		id:               id,
-		name:             name,
-		status:           StatusPending,
-		createdAt:        now.UTC(),
-		tick:             0,
-		config:           cfg,
-		players:          make(map[PlayerID]Player),
-		scheduledActions: make(map[int64][]PlayerAction),
-	}
-
-	ar.record(ArenaCreated{
-		baseEvent: baseEvent{at: now.UTC(), arenaID: id},
+	ar := &Arena{}
+	ar.raise(ArenaCreated{
+		BaseEvent: BaseEvent{at: now.UTC(), arenaID: id},
 		Name:      name,
 		Config:    cfg,
 	})
@@ -101,6 +116,7 @@ type RehydrateState struct {
 	Config     Config
 	Players    []Player
 	Scheduled  map[int64][]PlayerAction
+	Version    int64
 }
 
 func Rehydrate(s RehydrateState) (*Arena, error) {
@@ -125,6 +141,7 @@ func Rehydrate(s RehydrateState) (*Arena, error) {
 		config:           s.Config,
 		players:          make(map[PlayerID]Player),
 		scheduledActions: make(map[int64][]PlayerAction),
+		version:          s.Version,
 	}
 
 	for _, p := range s.Players {
@@ -152,10 +169,7 @@ func (a *Arena) Start(now time.Time, by PlayerID) error {
 		return ErrPermissionDenied
 	}
 
-	n := now.UTC()
-	a.status = StatusRunning
-	a.startedAt = &n
-	a.record(ArenaStarted{baseEvent: baseEvent{at: n, arenaID: a.id}})
+	a.raise(ArenaStarted{BaseEvent: BaseEvent{at: now.UTC(), arenaID: a.id}})
 	return nil
 }
 
@@ -170,9 +184,7 @@ func (a *Arena) Pause(now time.Time, by PlayerID) error {
 		return ErrPermissionDenied
 	}
 
-	n := now.UTC()
-	a.status = StatusPaused
-	a.record(ArenaPaused{baseEvent: baseEvent{at: n, arenaID: a.id}})
+	a.raise(ArenaPaused{BaseEvent: BaseEvent{at: now.UTC(), arenaID: a.id}})
 	return nil
 }
 
@@ -187,9 +199,7 @@ func (a *Arena) Resume(now time.Time, by PlayerID) error {
 		return ErrPermissionDenied
 	}
 
-	n := now.UTC()
-	a.status = StatusRunning
-	a.record(ArenaResumed{baseEvent: baseEvent{at: n, arenaID: a.id}})
+	a.raise(ArenaResumed{BaseEvent: BaseEvent{at: now.UTC(), arenaID: a.id}})
 	return nil
 }
 
@@ -201,17 +211,271 @@ func (a *Arena) Stop(now time.Time, by PlayerID) error {
 		return ErrPermissionDenied
 	}
 
+	a.raise(ArenaStopped{BaseEvent: BaseEvent{at: now.UTC(), arenaID: a.id}})
+	return nil
+}
+
+// UpdateConfig replaces the arena config (admin only). While PENDING any valid
+// config is accepted; once RUNNING/PAUSED only the rates, the temperature and
+// TickMillis may change, since the grid size backs the Area/Point payloads
+// already scheduled.
+func (a *Arena) UpdateConfig(cfg Config, by PlayerID, now time.Time) error {
+	if a.status == StatusFinished {
+		return ErrArenaFinished
+	}
+	if by != PlayerID(uuid.Nil) && !a.isAdmin(by) {
+		return ErrPermissionDenied
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	if a.status != StatusPending {
+		if field, ok := lockedFieldChanged(a.config, cfg); ok {
+			return &FieldError{
+				Field:  field,
+				Reason: fmt.Sprintf("cannot change while arena is %s", a.status),
+				Err:    ErrConfigChangeLocked,
+			}
+		}
+	}
+	if cfg == a.config {
+		return nil
+	}
+
+	a.raise(ArenaConfigUpdated{BaseEvent: BaseEvent{at: now.UTC(), arenaID: a.id}, Config: cfg})
+	return nil
+}
+
+const maxDisplayNameLen = 32
+
+// Join adds a player to the arena. The first player to join (or the first one
+// after every admin left) becomes the arena admin.
+func (a *Arena) Join(playerID PlayerID, displayName string, now time.Time) (Player, error) {
+	if a.status == StatusFinished {
+		return Player{}, ErrArenaFinished
+	}
+	if playerID == PlayerID(uuid.Nil) {
+		return Player{}, errors.New("player id is required")
+	}
+	name := strings.TrimSpace(displayName)
+	if name == "" || utf8.RuneCountInString(name) > maxDisplayNameLen {
+		return Player{}, ErrInvalidDisplayName
+	}
+	if _, ok := a.players[playerID]; ok {
+		return Player{}, ErrPlayerAlreadyJoined
+	}
+	if len(a.players) >= a.config.PlayerCap() {
+		return Player{}, ErrArenaFull
+	}
+
+	role := RolePlayer
+	if !a.hasAdmin() {
+		role = RoleAdmin
+	}
+
 	n := now.UTC()
-	a.status = StatusFinished
-	a.finishedAt = &n
-	a.record(ArenaStopped{baseEvent: baseEvent{at: n, arenaID: a.id}})
+	a.raise(PlayerJoined{
+		BaseEvent:   BaseEvent{at: n, arenaID: a.id},
+		PlayerID:    playerID,
+		DisplayName: name,
+		Role:        role,
+	})
+	return a.players[playerID], nil
+}
+
+// Leave removes a player and discards the actions it still had scheduled.
+// If the admin leaves, the role goes to the oldest remaining player.
+func (a *Arena) Leave(playerID PlayerID, now time.Time) error {
+	p, ok := a.players[playerID]
+	if !ok {
+		return ErrPlayerNotFound
+	}
+
+	var promoted *PlayerID
+	if p.Role == RoleAdmin && !a.hasAdminExcept(playerID) {
+		if next, ok := a.oldestPlayerExcept(playerID); ok {
+			promoted = &next.ID
+		}
+	}
+
+	a.raise(PlayerLeft{
+		BaseEvent:       BaseEvent{at: now.UTC(), arenaID: a.id},
+		PlayerID:        playerID,
+		PromotedAdminID: promoted,
+	})
 	return nil
 }
 
+// SubmitAction schedules a player action. ApplyAtTick defaults to the next
+// tick; the payload is validated against the current config and each player
+// may schedule at most Config.ActionQuota() actions per tick.
+func (a *Arena) SubmitAction(action PlayerAction, now time.Time) (PlayerAction, error) {
+	if a.status == StatusFinished {
+		return PlayerAction{}, ErrArenaFinished
+	}
+	if a.status != StatusRunning && a.status != StatusPaused {
+		return PlayerAction{}, ErrArenaNotRunning
+	}
+	if _, ok := a.players[action.PlayerID]; !ok {
+		return PlayerAction{}, ErrPlayerNotFound
+	}
+	if action.ID == ActionID(uuid.Nil) {
+		return PlayerAction{}, fmt.Errorf("%w: action id is required", ErrInvalidAction)
+	}
+
+	if action.ApplyAtTick == 0 {
+		action.ApplyAtTick = a.tick + 1
+	}
+	if action.ApplyAtTick < a.tick {
+		return PlayerAction{}, ErrApplyAtTickTooOld
+	}
+
+	if action.Payload == nil || PayloadType(action.Payload) != action.Type {
+		return PlayerAction{}, fmt.Errorf("%w: payload does not match action type %q", ErrInvalidAction, action.Type)
+	}
+	if err := action.Payload.Validate(a.config); err != nil {
+		if errors.Is(err, ErrInvalidAction) {
+			return PlayerAction{}, err
+		}
+		return PlayerAction{}, fmt.Errorf("%w: %v", ErrInvalidAction, err)
+	}
+
+	count := 0
+	for _, s := range a.scheduledActions[action.ApplyAtTick] {
+		if s.PlayerID == action.PlayerID {
+			count++
+		}
+	}
+	if count >= a.config.ActionQuota() {
+		return PlayerAction{}, ErrActionQuotaExceeded
+	}
+
+	n := now.UTC()
+	action.SubmittedAt = n
+	a.raise(ActionSubmitted{BaseEvent: BaseEvent{at: n, arenaID: a.id}, Action: action})
+	return action, nil
+}
+
+// AdvanceTick moves time forward by one tick and drains every action scheduled
+// up to the new tick, returning them so the caller can feed the simulation.
+// When Config.MaxTicks is reached the arena finishes on its own.
+func (a *Arena) AdvanceTick(now time.Time) ([]PlayerAction, error) {
+	if a.status == StatusFinished {
+		return nil, ErrArenaFinished
+	}
+	if a.status != StatusRunning {
+		return nil, ErrArenaNotRunning
+	}
+
+	n := now.UTC()
+	next := a.tick + 1
+	due := a.scheduledUpTo(next)
+	a.raise(TickAdvanced{BaseEvent: BaseEvent{at: n, arenaID: a.id}, Tick: next})
+
+	if a.config.MaxTicks > 0 && a.tick >= a.config.MaxTicks {
+		a.raise(ArenaFinished{BaseEvent: BaseEvent{at: n, arenaID: a.id}, Tick: a.tick})
+	}
+
+	return due, nil
+}
+
 // ----------------------------
 // Helpers
 // ----------------------------
 
+// lockedFieldChanged retorna o primeiro campo que não pode mudar com a arena em andamento.
+func lockedFieldChanged(old, cfg Config) (string, bool) {
+	switch {
+	case old.Width != cfg.Width:
+		return "width", true
+	case old.Height != cfg.Height:
+		return "height", true
+	case old.MaxOrganisms != cfg.MaxOrganisms:
+		return "maxOrganisms", true
+	case old.SnapshotEveryTicks != cfg.SnapshotEveryTicks:
+		return "snapshotEveryTicks", true
+	case old.MaxTicks != cfg.MaxTicks:
+		return "maxTicks", true
+	case old.MaxPlayers != cfg.MaxPlayers:
+		return "maxPlayers", true
+	case old.MaxActionsPerTick != cfg.MaxActionsPerTick:
+		return "maxActionsPerTick", true
+	}
+	return "", false
+}
+
+func (a *Arena) hasAdmin() bool {
+	return a.hasAdminExcept(PlayerID(uuid.Nil))
+}
+
+func (a *Arena) hasAdminExcept(skip PlayerID) bool {
+	for _, p := range a.players {
+		if p.ID != skip && p.Role == RoleAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// oldestPlayerExcept desempata JoinedAt pelo ID para ser determinístico.
+func (a *Arena) oldestPlayerExcept(skip PlayerID) (Player, bool) {
+	var (
+		out   Player
+		found bool
+	)
+	for _, p := range a.players {
+		if p.ID == skip {
+			continue
+		}
+		if !found || p.JoinedAt.Before(out.JoinedAt) ||
+			(p.JoinedAt.Equal(out.JoinedAt) && bytes.Compare(p.ID[:], out.ID[:]) < 0) {
+			out, found = p, true
+		}
+	}
+	return out, found
+}
+
+func (a *Arena) dropScheduledOf(pid PlayerID) {
+	for t, actions := range a.scheduledActions {
+		kept := actions[:0]
+		for _, act := range actions {
+			if act.PlayerID != pid {
+				kept = append(kept, act)
+			}
+		}
+		if len(kept) == 0 {
+			delete(a.scheduledActions, t)
+		} else {
+			a.scheduledActions[t] = kept
+		}
+	}
+}
+
+// scheduledUpTo retorna (em ordem de tick) as ações agendadas até o tick informado.
+func (a *Arena) scheduledUpTo(upTo int64) []PlayerAction {
+	ticks := make([]int64, 0, len(a.scheduledActions))
+	for t := range a.scheduledActions {
+		if t <= upTo {
+			ticks = append(ticks, t)
+		}
+	}
+	sort.Slice(ticks, func(i, j int) bool { return ticks[i] < ticks[j] })
+
+	var out []PlayerAction
+	for _, t := range ticks {
+		out = append(out, a.scheduledActions[t]...)
+	}
+	return out
+}
+
+func (a *Arena) dropScheduledUpTo(upTo int64) {
+	for t := range a.scheduledActions {
+		if t <= upTo {
+			delete(a.scheduledActions, t)
+		}
+	}
+}
+
 func (a *Arena) isAdmin(pid PlayerID) bool {
 	p, ok := a.players[pid]
 	return ok && p.Role == RoleAdmin
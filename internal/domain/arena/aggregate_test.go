@@ -0,0 +1,340 @@
+package arena
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var testNow = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func testConfig() Config {
+	return Config{
+		TickMillis: 100, Width: 10, Height: 10, MaxOrganisms: 50, SnapshotEveryTicks: 10,
+		Temperature: Temperature{Unit: TempC, Value: 20},
+	}
+}
+
+func newTestArena(t *testing.T, status Status, cfg Config, players ...Player) *Arena {
+	t.Helper()
+	a, err := Rehydrate(RehydrateState{
+		ID: uuid.New(), Name: "petri", Status: status, CreatedAt: testNow,
+		Tick: 5, Config: cfg, Players: players, Version: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func testPlayer(role PlayerRole, joined time.Duration) Player {
+	return Player{ID: PlayerID(uuid.New()), DisplayName: "p", Role: role, JoinedAt: testNow.Add(joined)}
+}
+
+func nutrients(pid PlayerID, tick int64) PlayerAction {
+	return PlayerAction{
+		ID: ActionID(uuid.New()), Type: ActionAddNutrients, PlayerID: pid, ApplyAtTick: tick,
+		Payload: AddNutrientsPayload{Area: Area{Width: 1, Height: 1}, Amount: 1},
+	}
+}
+
+func TestJoin(t *testing.T) {
+	admin := testPlayer(RoleAdmin, 0)
+	capped := testConfig()
+	capped.MaxPlayers = 1
+
+	tests := []struct {
+		name     string
+		status   Status
+		cfg      Config
+		players  []Player
+		playerID PlayerID
+		display  string
+		wantErr  error
+		wantRole PlayerRole
+	}{
+		{name: "first player becomes admin", status: StatusPending, cfg: testConfig(), display: "alice", wantRole: RoleAdmin},
+		{name: "later player joins as player", status: StatusPending, cfg: testConfig(), players: []Player{admin}, display: "bob", wantRole: RolePlayer},
+		{name: "joins while running", status: StatusRunning, cfg: testConfig(), players: []Player{admin}, display: "bob", wantRole: RolePlayer},
+		{name: "joins while paused", status: StatusPaused, cfg: testConfig(), players: []Player{admin}, display: "bob", wantRole: RolePlayer},
+		{name: "finished arena", status: StatusFinished, cfg: testConfig(), display: "bob", wantErr: ErrArenaFinished},
+		{name: "duplicate join", status: StatusRunning, cfg: testConfig(), players: []Player{admin}, playerID: admin.ID, display: "alice", wantErr: ErrPlayerAlreadyJoined},
+		{name: "arena full", status: StatusRunning, cfg: capped, players: []Player{admin}, display: "bob", wantErr: ErrArenaFull},
+		{name: "blank display name", status: StatusRunning, cfg: testConfig(), display: "   ", wantErr: ErrInvalidDisplayName},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestArena(t, tt.status, tt.cfg, tt.players...)
+			pid := tt.playerID
+			if pid == PlayerID(uuid.Nil) {
+				pid = PlayerID(uuid.New())
+			}
+
+			p, err := a.Join(pid, tt.display, testNow)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				if len(a.PendingEvents()) != 0 || len(a.players) != len(tt.players) {
+					t.Fatal("rejected join changed the arena")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if p.ID != pid || p.Role != tt.wantRole || a.players[pid] != p {
+				t.Fatalf("joined %+v, want id %v role %s", p, pid, tt.wantRole)
+			}
+		})
+	}
+}
+
+func TestLeave(t *testing.T) {
+	admin := testPlayer(RoleAdmin, 0)
+	oldest := testPlayer(RolePlayer, time.Second)
+	newest := testPlayer(RolePlayer, 2*time.Second)
+
+	tests := []struct {
+		name         string
+		players      []Player
+		leaving      PlayerID
+		wantErr      error
+		wantPromoted PlayerID
+	}{
+		{name: "unknown player", players: []Player{admin}, leaving: PlayerID(uuid.New()), wantErr: ErrPlayerNotFound},
+		{name: "player leaves", players: []Player{admin, oldest}, leaving: oldest.ID},
+		{name: "admin hands over to the oldest", players: []Player{admin, newest, oldest}, leaving: admin.ID, wantPromoted: oldest.ID},
+		{name: "last admin leaves alone", players: []Player{admin}, leaving: admin.ID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestArena(t, StatusRunning, testConfig(), tt.players...)
+			err := a.Leave(tt.leaving, testNow)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if _, ok := a.players[tt.leaving]; ok {
+				t.Fatal("player still in the arena")
+			}
+			if tt.wantPromoted != PlayerID(uuid.Nil) && a.players[tt.wantPromoted].Role != RoleAdmin {
+				t.Fatalf("player %v was not promoted to admin", tt.wantPromoted)
+			}
+		})
+	}
+}
+
+func TestLeaveDropsScheduledActions(t *testing.T) {
+	alice := testPlayer(RoleAdmin, 0)
+	bob := testPlayer(RolePlayer, time.Second)
+	a := newTestArena(t, StatusRunning, testConfig(), alice, bob)
+	a.scheduledActions = map[int64][]PlayerAction{
+		6: {nutrients(alice.ID, 6), nutrients(bob.ID, 6)},
+		7: {nutrients(bob.ID, 7)},
+	}
+
+	if err := a.Leave(bob.ID, testNow); err != nil {
+		t.Fatal(err)
+	}
+
+	got := a.Scheduled()
+	if len(got) != 1 || len(got[6]) != 1 || got[6][0].PlayerID != alice.ID {
+		t.Fatalf("scheduled = %+v, want only alice's action at tick 6", got)
+	}
+}
+
+func TestSubmitAction(t *testing.T) {
+	alice := testPlayer(RoleAdmin, 0)
+	bob := testPlayer(RolePlayer, time.Second)
+	single := testConfig()
+	single.MaxActionsPerTick = 1
+	two := testConfig()
+	two.MaxActionsPerTick = 2
+
+	tests := []struct {
+		name      string
+		status    Status
+		cfg       Config
+		scheduled []PlayerAction
+		action    PlayerAction
+		wantErr   error
+		wantTick  int64
+	}{
+		{name: "applyAtTick 0 defaults to the next tick", status: StatusRunning, cfg: testConfig(), action: nutrients(alice.ID, 0), wantTick: 6},
+		{name: "current tick is accepted", status: StatusRunning, cfg: testConfig(), action: nutrients(alice.ID, 5), wantTick: 5},
+		{name: "past tick", status: StatusRunning, cfg: testConfig(), action: nutrients(alice.ID, 4), wantErr: ErrApplyAtTickTooOld},
+		{name: "paused arena accepts", status: StatusPaused, cfg: testConfig(), action: nutrients(alice.ID, 8), wantTick: 8},
+		{name: "pending arena", status: StatusPending, cfg: testConfig(), action: nutrients(alice.ID, 8), wantErr: ErrArenaNotRunning},
+		{name: "finished arena", status: StatusFinished, cfg: testConfig(), action: nutrients(alice.ID, 8), wantErr: ErrArenaFinished},
+		{name: "player not joined", status: StatusRunning, cfg: testConfig(), action: nutrients(PlayerID(uuid.New()), 8), wantErr: ErrPlayerNotFound},
+		{
+			name: "payload outside the grid", status: StatusRunning, cfg: testConfig(), wantErr: ErrInvalidAction,
+			action: PlayerAction{
+				ID: ActionID(uuid.New()), Type: ActionAddNutrients, PlayerID: alice.ID, ApplyAtTick: 8,
+				Payload: AddNutrientsPayload{Area: Area{X: 9, Width: 2, Height: 1}, Amount: 1},
+			},
+		},
+		{
+			name: "second action on the same tick", status: StatusRunning, cfg: single,
+			scheduled: []PlayerAction{nutrients(alice.ID, 8)}, action: nutrients(alice.ID, 8), wantErr: ErrActionQuotaExceeded,
+		},
+		{
+			name: "same player on another tick", status: StatusRunning, cfg: single,
+			scheduled: []PlayerAction{nutrients(alice.ID, 8)}, action: nutrients(alice.ID, 9), wantTick: 9,
+		},
+		{
+			name: "another player on the same tick", status: StatusRunning, cfg: single,
+			scheduled: []PlayerAction{nutrients(alice.ID, 8)}, action: nutrients(bob.ID, 8), wantTick: 8,
+		},
+		{
+			name: "under maxActionsPerTick", status: StatusRunning, cfg: two,
+			scheduled: []PlayerAction{nutrients(alice.ID, 8)}, action: nutrients(alice.ID, 8), wantTick: 8,
+		},
+		{
+			name: "at maxActionsPerTick", status: StatusRunning, cfg: two,
+			scheduled: []PlayerAction{nutrients(alice.ID, 8), nutrients(alice.ID, 8)}, action: nutrients(alice.ID, 8), wantErr: ErrActionQuotaExceeded,
+		},
+		{
+			name: "default quota", status: StatusRunning, cfg: testConfig(),
+			scheduled: []PlayerAction{nutrients(alice.ID, 8), nutrients(alice.ID, 8), nutrients(alice.ID, 8)}, action: nutrients(alice.ID, 8), wantErr: ErrActionQuotaExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestArena(t, tt.status, tt.cfg, alice, bob)
+			for _, s := range tt.scheduled {
+				a.scheduledActions[s.ApplyAtTick] = append(a.scheduledActions[s.ApplyAtTick], s)
+			}
+
+			got, err := a.SubmitAction(tt.action, testNow)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				if len(a.PendingEvents()) != 0 {
+					t.Fatal("rejected action raised events")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.ApplyAtTick != tt.wantTick || !got.SubmittedAt.Equal(testNow) {
+				t.Fatalf("action = tick %d submitted %v, want tick %d submitted %v", got.ApplyAtTick, got.SubmittedAt, tt.wantTick, testNow)
+			}
+			scheduled := a.ScheduledFor(tt.wantTick)
+			if len(scheduled) == 0 || scheduled[len(scheduled)-1].ID != tt.action.ID {
+				t.Fatalf("scheduled at %d = %+v, want the submitted action last", tt.wantTick, scheduled)
+			}
+		})
+	}
+}
+
+func TestUpdateConfig(t *testing.T) {
+	admin := testPlayer(RoleAdmin, 0)
+	player := testPlayer(RolePlayer, time.Second)
+	with := func(fn func(*Config)) Config {
+		c := testConfig()
+		fn(&c)
+		return c
+	}
+
+	tests := []struct {
+		name      string
+		status    Status
+		by        PlayerID
+		cfg       Config
+		wantErr   error
+		wantField string
+		wantEvent bool
+	}{
+		{name: "pending allows the grid", status: StatusPending, by: admin.ID, cfg: with(func(c *Config) { c.Width = 20 }), wantEvent: true},
+		{name: "running allows rates", status: StatusRunning, by: admin.ID, cfg: with(func(c *Config) { c.DiffusionRate, c.MutationRate = 0.5, 0.1 }), wantEvent: true},
+		{name: "running allows temperature", status: StatusRunning, by: admin.ID, cfg: with(func(c *Config) { c.Temperature.Value = 37 }), wantEvent: true},
+		{name: "paused allows tickMillis", status: StatusPaused, by: admin.ID, cfg: with(func(c *Config) { c.TickMillis = 250 }), wantEvent: true},
+		{name: "system update", status: StatusRunning, by: PlayerID(uuid.Nil), cfg: with(func(c *Config) { c.TickMillis = 250 }), wantEvent: true},
+		{name: "unchanged config", status: StatusRunning, by: admin.ID, cfg: testConfig()},
+		{name: "not admin", status: StatusPending, by: player.ID, cfg: with(func(c *Config) { c.Width = 20 }), wantErr: ErrPermissionDenied},
+		{name: "finished arena", status: StatusFinished, by: admin.ID, cfg: testConfig(), wantErr: ErrArenaFinished},
+		{name: "running locks width", status: StatusRunning, by: admin.ID, cfg: with(func(c *Config) { c.Width = 20 }), wantErr: ErrConfigChangeLocked, wantField: "width"},
+		{name: "running locks height", status: StatusRunning, by: admin.ID, cfg: with(func(c *Config) { c.Height = 20 }), wantErr: ErrConfigChangeLocked, wantField: "height"},
+		{name: "paused locks maxOrganisms", status: StatusPaused, by: admin.ID, cfg: with(func(c *Config) { c.MaxOrganisms = 99 }), wantErr: ErrConfigChangeLocked, wantField: "maxOrganisms"},
+		{name: "running locks snapshotEveryTicks", status: StatusRunning, by: admin.ID, cfg: with(func(c *Config) { c.SnapshotEveryTicks = 5 }), wantErr: ErrConfigChangeLocked, wantField: "snapshotEveryTicks"},
+		{name: "running locks maxTicks", status: StatusRunning, by: admin.ID, cfg: with(func(c *Config) { c.MaxTicks = 100 }), wantErr: ErrConfigChangeLocked, wantField: "maxTicks"},
+		{name: "running locks maxPlayers", status: StatusRunning, by: admin.ID, cfg: with(func(c *Config) { c.MaxPlayers = 4 }), wantErr: ErrConfigChangeLocked, wantField: "maxPlayers"},
+		{name: "running locks maxActionsPerTick", status: StatusRunning, by: admin.ID, cfg: with(func(c *Config) { c.MaxActionsPerTick = 1 }), wantErr: ErrConfigChangeLocked, wantField: "maxActionsPerTick"},
+		{name: "invalid before locked", status: StatusRunning, by: admin.ID, cfg: with(func(c *Config) { c.Width = 0 }), wantErr: ErrInvalidConfig, wantField: "width"},
+		{name: "invalid while pending", status: StatusPending, by: admin.ID, cfg: with(func(c *Config) { c.DiffusionRate = 2 }), wantErr: ErrInvalidConfig, wantField: "diffusionRate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestArena(t, tt.status, testConfig(), admin, player)
+
+			err := a.UpdateConfig(tt.cfg, tt.by, testNow)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantField != "" {
+				var fe *FieldError
+				if !errors.As(err, &fe) || fe.Field != tt.wantField {
+					t.Fatalf("err = %v, want FieldError on %q", err, tt.wantField)
+				}
+			}
+			if got := len(a.PendingEvents()) > 0; got != tt.wantEvent {
+				t.Fatalf("raised event = %v, want %v", got, tt.wantEvent)
+			}
+			want := testConfig()
+			if tt.wantEvent {
+				want = tt.cfg
+			}
+			if a.Config() != want {
+				t.Fatalf("config = %+v, want %+v", a.Config(), want)
+			}
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		field string
+		cfg   func(*Config)
+	}{
+		{"tickMillis", func(c *Config) { c.TickMillis = 0 }},
+		{"width", func(c *Config) { c.Width = -1 }},
+		{"height", func(c *Config) { c.Height = 0 }},
+		{"diffusionRate", func(c *Config) { c.DiffusionRate = -0.1 }},
+		{"mutationRate", func(c *Config) { c.MutationRate = 1.5 }},
+		{"maxOrganisms", func(c *Config) { c.MaxOrganisms = 0 }},
+		{"snapshotEveryTicks", func(c *Config) { c.SnapshotEveryTicks = 0 }},
+		{"maxTicks", func(c *Config) { c.MaxTicks = -1 }},
+		{"maxPlayers", func(c *Config) { c.MaxPlayers = -1 }},
+		{"maxActionsPerTick", func(c *Config) { c.MaxActionsPerTick = -1 }},
+		{"temperature.unit", func(c *Config) { c.Temperature.Unit = "F" }},
+		{"temperature.value", func(c *Config) { c.Temperature.Value = 200 }},
+	}
+
+	if err := testConfig().Validate(); err != nil {
+		t.Fatalf("valid config: %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			c := testConfig()
+			tt.cfg(&c)
+
+			err := c.Validate()
+			var fe *FieldError
+			if !errors.As(err, &fe) || fe.Field != tt.field || !errors.Is(err, ErrInvalidConfig) {
+				t.Fatalf("err = %v, want FieldError on %q wrapping ErrInvalidConfig", err, tt.field)
+			}
+		})
+	}
+}
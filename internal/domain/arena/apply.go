@@ -0,0 +1,134 @@
+package arena
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// raise applies the event to the aggregate state and records it as pending.
+// Every state change goes through Apply, so replaying the recorded events
+// rebuilds the same aggregate (event-sourced persistence).
+func (a *Arena) raise(e Event) {
+	a.Apply(e)
+	a.record(e)
+}
+
+// Apply mutates the state for an event that already happened. It does not
+// validate business rules (the behavior methods did that before raising) and
+// ignores unknown events.
+func (a *Arena) Apply(e Event) {
+	switch ev := e.(type) {
+	case ArenaCreated:
+		a.id = ev.ArenaID()
+		a.name = ev.Name
+		a.status = StatusPending
+		a.createdAt = ev.OccurredAt()
+		a.tick = 0
+		a.config = ev.Config
+		a.players = make(map[PlayerID]Player)
+		a.scheduledActions = make(map[int64][]PlayerAction)
+
+	case ArenaStarted:
+		at := ev.OccurredAt()
+		a.status = StatusRunning
+		a.startedAt = &at
+
+	case ArenaPaused:
+		a.status = StatusPaused
+
+	case ArenaResumed:
+		a.status = StatusRunning
+
+	case ArenaStopped:
+		at := ev.OccurredAt()
+		a.status = StatusFinished
+		a.finishedAt = &at
+
+	case ArenaFinished:
+		at := ev.OccurredAt()
+		a.status = StatusFinished
+		a.finishedAt = &at
+
+	case ArenaConfigUpdated:
+		a.config = ev.Config
+
+	case PlayerJoined:
+		a.players[ev.PlayerID] = Player{
+			ID:          ev.PlayerID,
+			DisplayName: ev.DisplayName,
+			Role:        ev.Role,
+			JoinedAt:    ev.OccurredAt(),
+		}
+
+	case PlayerLeft:
+		delete(a.players, ev.PlayerID)
+		a.dropScheduledOf(ev.PlayerID)
+		if ev.PromotedAdminID != nil {
+			if p, ok := a.players[*ev.PromotedAdminID]; ok {
+				p.Role = RoleAdmin
+				a.players[p.ID] = p
+			}
+		}
+
+	case ActionSubmitted:
+		t := ev.Action.ApplyAtTick
+		a.scheduledActions[t] = append(a.scheduledActions[t], ev.Action)
+
+	case TickAdvanced:
+		a.tick = ev.Tick
+		a.dropScheduledUpTo(ev.Tick)
+	}
+}
+
+// PendingEvents returns the events raised since the last PullEvents without
+// clearing them (the event store appends them on Save).
+func (a *Arena) PendingEvents() []Event {
+	return append([]Event(nil), a.events...)
+}
+
+// State exports the current state (snapshots); Rehydrate(a.State()) rebuilds it.
+func (a *Arena) State() RehydrateState {
+	return RehydrateState{
+		ID:         a.id,
+		Name:       a.name,
+		Status:     a.status,
+		CreatedAt:  a.createdAt,
+		StartedAt:  a.startedAt,
+		FinishedAt: a.finishedAt,
+		Tick:       a.tick,
+		Config:     a.config,
+		Players:    a.Players(),
+		Scheduled:  a.Scheduled(),
+		Version:    a.version,
+	}
+}
+
+// Replay rebuilds an arena from an optional snapshot plus the events recorded
+// after it. Without a snapshot the first event must be ArenaCreated.
+func Replay(snapshot *RehydrateState, events []Event) (*Arena, error) {
+	var a *Arena
+	if snapshot != nil {
+		var err error
+		if a, err = Rehydrate(*snapshot); err != nil {
+			return nil, fmt.Errorf("replay: snapshot: %w", err)
+		}
+	} else {
+		if len(events) == 0 {
+			return nil, errors.New("replay: no events")
+		}
+		if _, ok := events[0].(ArenaCreated); !ok {
+			return nil, fmt.Errorf("replay: first event is %s, want ArenaCreated", events[0].EventName())
+		}
+		a = &Arena{}
+	}
+
+	for _, e := range events {
+		if a.id != uuid.Nil && e.ArenaID() != a.id {
+			return nil, fmt.Errorf("replay: event %s belongs to arena %s", e.EventName(), e.ArenaID())
+		}
+		a.Apply(e)
+	}
+	return a, nil
+}
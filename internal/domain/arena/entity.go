@@ -26,6 +26,22 @@ type ActionPayload interface {
 	Validate(cfg Config) error
 }
 
+// PayloadType returns the ActionType a payload belongs to ("" for unknown payloads).
+func PayloadType(p ActionPayload) ActionType {
+	switch p.(type) {
+	case AddNutrientsPayload:
+		return ActionAddNutrients
+	case DropAntibioticPayload:
+		return ActionDropAntibiotic
+	case SetTemperaturePayload:
+		return ActionSetTemperature
+	case SpawnOrganismPayload:
+		return ActionSpawnOrganism
+	default:
+		return ""
+	}
+}
+
 type AddNutrientsPayload struct {
 	Area   Area
 	Amount int
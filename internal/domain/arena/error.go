@@ -1,6 +1,9 @@
 package arena
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // ----------------------------
 // Errors (domain-level)
@@ -14,7 +17,23 @@ var (
 	ErrArenaFinished       = errors.New("arena is finished")
 	ErrPlayerAlreadyJoined = errors.New("player already joined")
 	ErrPlayerNotFound      = errors.New("player not found")
+	ErrInvalidDisplayName  = errors.New("invalid display name")
+	ErrArenaFull           = errors.New("arena is full")
 	ErrPermissionDenied    = errors.New("permission denied")
 	ErrInvalidAction       = errors.New("invalid action payload")
 	ErrApplyAtTickTooOld   = errors.New("applyAtTick must be >= current tick")
+	ErrActionQuotaExceeded = errors.New("action quota exceeded for tick")
+	ErrConfigChangeLocked  = errors.New("config field cannot change after start")
+	ErrInvalidConfig       = errors.New("invalid arena config")
 )
+
+// FieldError aponta o campo da config (caminho no estilo do input GraphQL,
+// ex.: "temperature.value") que causou o erro. Err é o sentinel para errors.Is.
+type FieldError struct {
+	Field  string
+	Reason string
+	Err    error
+}
+
+func (e *FieldError) Error() string { return fmt.Sprintf("%s: %s", e.Field, e.Reason) }
+func (e *FieldError) Unwrap() error { return e.Err }
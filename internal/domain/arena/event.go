@@ -10,40 +10,54 @@ type Event interface {
 	ArenaID() ID
 }
 
-type baseEvent struct {
+// BaseEvent carries the fields every event shares.
+type BaseEvent struct {
 	at      time.Time
 	arenaID ID
 }
 
-func (b baseEvent) OccurredAt() time.Time { return b.at }
-func (b baseEvent) ArenaID() ID           { return b.arenaID }
+// NewBaseEvent is used by stores/decoders that rebuild events from persistence.
+func NewBaseEvent(id ID, at time.Time) BaseEvent {
+	return BaseEvent{at: at.UTC(), arenaID: id}
+}
+
+func (b BaseEvent) OccurredAt() time.Time { return b.at }
+func (b BaseEvent) ArenaID() ID           { return b.arenaID }
 
 type ArenaCreated struct {
-	baseEvent
+	BaseEvent
 	Name   string
 	Config Config
 }
 
 func (e ArenaCreated) EventName() string { return "ArenaCreated" }
 
-type ArenaStarted struct{ baseEvent }
+type ArenaStarted struct{ BaseEvent }
 
 func (e ArenaStarted) EventName() string { return "ArenaStarted" }
 
-type ArenaPaused struct{ baseEvent }
+type ArenaPaused struct{ BaseEvent }
 
 func (e ArenaPaused) EventName() string { return "ArenaPaused" }
 
-type ArenaResumed struct{ baseEvent }
+type ArenaResumed struct{ BaseEvent }
 
 func (e ArenaResumed) EventName() string { return "ArenaResumed" }
 
-type ArenaStopped struct{ baseEvent }
+type ArenaStopped struct{ BaseEvent }
 
 func (e ArenaStopped) EventName() string { return "ArenaStopped" }
 
+// ArenaFinished is recorded when an end condition finishes the arena (as opposed to an explicit Stop).
+type ArenaFinished struct {
+	BaseEvent
+	Tick int64
+}
+
+func (e ArenaFinished) EventName() string { return "ArenaFinished" }
+
 type PlayerJoined struct {
-	baseEvent
+	BaseEvent
 	PlayerID    PlayerID
 	DisplayName string
 	Role        PlayerRole
@@ -52,28 +66,30 @@ type PlayerJoined struct {
 func (e PlayerJoined) EventName() string { return "PlayerJoined" }
 
 type PlayerLeft struct {
-	baseEvent
+	BaseEvent
 	PlayerID PlayerID
+	// PromotedAdminID is set when the leaving admin handed the role to another player.
+	PromotedAdminID *PlayerID
 }
 
 func (e PlayerLeft) EventName() string { return "PlayerLeft" }
 
 type ArenaConfigUpdated struct {
-	baseEvent
+	BaseEvent
 	Config Config
 }
 
 func (e ArenaConfigUpdated) EventName() string { return "ArenaConfigUpdated" }
 
 type ActionSubmitted struct {
-	baseEvent
+	BaseEvent
 	Action PlayerAction
 }
 
 func (e ActionSubmitted) EventName() string { return "ActionSubmitted" }
 
 type TickAdvanced struct {
-	baseEvent
+	BaseEvent
 	Tick int64
 }
 
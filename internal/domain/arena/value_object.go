@@ -114,33 +114,70 @@ type Config struct {
 	MaxOrganisms       int
 	SnapshotEveryTicks int
 	Temperature        Temperature
+
+	// MaxTicks encerra a arena automaticamente ao atingir o tick (0 = sem limite).
+	MaxTicks int64
+	// MaxPlayers limita os jogadores simultâneos (0 = DefaultMaxPlayers).
+	MaxPlayers int
+	// MaxActionsPerTick limita as ações de um jogador num mesmo tick (0 = DefaultMaxActionsPerTick).
+	MaxActionsPerTick int
 }
 
-func (c Config) Validate() error {
-	if c.TickMillis <= 0 {
-		return errors.New("tickMillis must be > 0")
-	}
-	if c.Width <= 0 || c.Height <= 0 {
-		return errors.New("width/height must be > 0")
-	}
-	if c.DiffusionRate < 0 || c.DiffusionRate > 1 {
-		return errors.New("diffusionRate must be in [0,1]")
+const (
+	DefaultMaxPlayers        = 16
+	DefaultMaxActionsPerTick = 3
+)
+
+func (c Config) PlayerCap() int {
+	if c.MaxPlayers == 0 {
+		return DefaultMaxPlayers
 	}
-	if c.MutationRate < 0 || c.MutationRate > 1 {
-		return errors.New("mutationRate must be in [0,1]")
+	return c.MaxPlayers
+}
+
+func (c Config) ActionQuota() int {
+	if c.MaxActionsPerTick == 0 {
+		return DefaultMaxActionsPerTick
 	}
-	if c.MaxOrganisms <= 0 {
-		return errors.New("maxOrganisms must be > 0")
+	return c.MaxActionsPerTick
+}
+
+func (c Config) Validate() error {
+	switch {
+	case c.TickMillis <= 0:
+		return configError("tickMillis", "must be > 0")
+	case c.Width <= 0:
+		return configError("width", "must be > 0")
+	case c.Height <= 0:
+		return configError("height", "must be > 0")
+	case c.DiffusionRate < 0 || c.DiffusionRate > 1:
+		return configError("diffusionRate", "must be in [0,1]")
+	case c.MutationRate < 0 || c.MutationRate > 1:
+		return configError("mutationRate", "must be in [0,1]")
+	case c.MaxOrganisms <= 0:
+		return configError("maxOrganisms", "must be > 0")
+	case c.SnapshotEveryTicks <= 0:
+		return configError("snapshotEveryTicks", "must be > 0")
+	case c.MaxTicks < 0:
+		return configError("maxTicks", "must be >= 0")
+	case c.MaxPlayers < 0:
+		return configError("maxPlayers", "must be >= 0")
+	case c.MaxActionsPerTick < 0:
+		return configError("maxActionsPerTick", "must be >= 0")
 	}
-	if c.SnapshotEveryTicks <= 0 {
-		return errors.New("snapshotEveryTicks must be > 0")
+	if c.Temperature.Unit != TempC {
+		return configError("temperature.unit", fmt.Sprintf("unsupported temperature unit: %s", c.Temperature.Unit))
 	}
 	if err := c.Temperature.Validate(); err != nil {
-		return err
+		return configError("temperature.value", err.Error())
 	}
 	return nil
 }
 
+func configError(field, reason string) error {
+	return &FieldError{Field: field, Reason: reason, Err: ErrInvalidConfig}
+}
+
 func ParseStatus(s string) (Status, error) {
 	switch Status(s) {
 	case StatusPending, StatusRunning, StatusPaused, StatusFinished:
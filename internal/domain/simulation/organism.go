@@ -0,0 +1,88 @@
+package simulation
+
+import (
+	"math"
+
+	"github.com/petri-board-arena/internal/domain/arena"
+)
+
+// traits são os parâmetros fixos de cada espécie; o que evolui fica no Genome.
+type traits struct {
+	initialEnergy float64
+	basalCost     float64
+	divideAt      float64
+	baseGenome    Genome
+}
+
+var kindTraits = map[arena.OrganismKind]traits{
+	arena.KindBacteria: {
+		initialEnergy: 5,
+		basalCost:     0.5,
+		divideAt:      10,
+		baseGenome:    Genome{Uptake: 1.5, ThermalOptimum: 37, ThermalTolerance: 8},
+	},
+	arena.KindFungi: {
+		initialEnergy: 8,
+		basalCost:     0.3,
+		divideAt:      16,
+		baseGenome: Genome{
+			Uptake:           0.8,
+			ThermalOptimum:   28,
+			ThermalTolerance: 10,
+			Resistance:       [len(antibioticKinds)]float64{0.3, 0.3, 0.3},
+		},
+	},
+	// Phage não consome nutrientes: ganha energia infectando bactérias na mesma célula.
+	arena.KindPhage: {
+		initialEnergy: 4,
+		basalCost:     0.8,
+		divideAt:      6,
+		baseGenome:    Genome{ThermalOptimum: 37, ThermalTolerance: 15},
+	},
+}
+
+const (
+	// phageYield é a fração da energia da vítima absorvida pelo phage.
+	phageYield = 0.6
+	// mutationScale é a amplitude relativa de uma mutação pontual.
+	mutationScale = 0.1
+)
+
+// thermalStress multiplica o custo basal conforme o desvio da temperatura ótima.
+func thermalStress(g Genome, temp float64) float64 {
+	if g.ThermalTolerance <= 0 {
+		return 1
+	}
+	d := (temp - g.ThermalOptimum) / g.ThermalTolerance
+	return 1 + d*d
+}
+
+// fitness normaliza a energia pelo limiar de divisão da espécie.
+func fitness(o Organism) float64 {
+	t, ok := kindTraits[o.Kind]
+	if !ok || t.divideAt <= 0 {
+		return 0
+	}
+	return o.Energy / t.divideAt
+}
+
+// mutate perturba cada gene com probabilidade rate; retorna se houve mutação.
+func mutate(g Genome, rate float64, r *rng) (Genome, bool) {
+	mutated := false
+	perturb := func(v, lo, hi float64) float64 {
+		if r.float64() >= rate {
+			return v
+		}
+		mutated = true
+		delta := (r.float64()*2 - 1) * mutationScale * math.Max(math.Abs(v), 1)
+		return math.Min(hi, math.Max(lo, v+delta))
+	}
+
+	g.Uptake = perturb(g.Uptake, 0, 10)
+	g.ThermalOptimum = perturb(g.ThermalOptimum, -50, 150)
+	g.ThermalTolerance = perturb(g.ThermalTolerance, 0.1, 100)
+	for i := range g.Resistance {
+		g.Resistance[i] = perturb(g.Resistance[i], 0, 1)
+	}
+	return g, mutated
+}
@@ -0,0 +1,22 @@
+package simulation
+
+// rng é um splitmix64: determinístico e serializável (o estado vive em State.Seed).
+type rng struct{ state uint64 }
+
+func (r *rng) next() uint64 {
+	r.state += 0x9e3779b97f4a7c15
+	z := r.state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// float64 retorna um valor em [0,1).
+func (r *rng) float64() float64 {
+	return float64(r.next()>>11) / (1 << 53)
+}
+
+// intn retorna um valor em [0,n).
+func (r *rng) intn(n int) int {
+	return int(r.next() % uint64(n))
+}
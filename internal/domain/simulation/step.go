@@ -0,0 +1,291 @@
+package simulation
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/petri-board-arena/internal/domain/arena"
+)
+
+// Stats resume um tick (espelha TickStats do GraphQL).
+type Stats struct {
+	Tick          int64
+	OrganismCount int
+	Births        int
+	Deaths        int
+	Mutations     int
+	AvgFitness    *float64
+}
+
+const (
+	// antibioticDecay é a fração de antibiótico que degrada a cada tick.
+	antibioticDecay = 0.05
+	// temperatureRelax é a fração do desvio em relação ao ambiente corrigida a cada tick.
+	temperatureRelax = 0.1
+)
+
+// Step advances the world by exactly one tick. It is pure: the input state is
+// never mutated and the same (state, actions) always yields the same result.
+//
+// Phases: apply the actions scheduled for the new tick, diffuse the grid
+// layers, then let every organism feed, pay its metabolic cost, suffer
+// antibiotics, die or divide.
+func Step(s State, scheduledActions []arena.PlayerAction) (State, Stats) {
+	next := s.clone()
+	next.Tick++
+
+	r := &rng{state: next.Seed}
+	st := Stats{Tick: next.Tick}
+
+	next.applyActions(sortActions(scheduledActions))
+	next.diffuse()
+	next.live(r, &st)
+
+	next.Seed = r.state
+	st.OrganismCount = len(next.Organisms)
+	if n := len(next.Organisms); n > 0 {
+		var sum float64
+		for _, o := range next.Organisms {
+			sum += fitness(o)
+		}
+		avg := sum / float64(n)
+		st.AvgFitness = &avg
+	}
+	return next, st
+}
+
+// sortActions garante ordem determinística independente da ordem do map de agendamento.
+func sortActions(in []arena.PlayerAction) []arena.PlayerAction {
+	out := append([]arena.PlayerAction(nil), in...)
+	sort.SliceStable(out, func(i, j int) bool {
+		if !out[i].SubmittedAt.Equal(out[j].SubmittedAt) {
+			return out[i].SubmittedAt.Before(out[j].SubmittedAt)
+		}
+		return bytes.Compare(out[i].ID[:], out[j].ID[:]) < 0
+	})
+	return out
+}
+
+// ----------------------------
+// Actions
+// ----------------------------
+
+func (s *State) applyActions(actions []arena.PlayerAction) {
+	for _, a := range actions {
+		// ações inválidas para a config atual são ignoradas (a config pode ter mudado após o agendamento)
+		if a.Payload == nil || a.Payload.Validate(s.Config) != nil {
+			continue
+		}
+
+		switch p := a.Payload.(type) {
+		case arena.AddNutrientsPayload:
+			s.eachCell(p.Area, func(i int) { s.Nutrients[i] += float64(p.Amount) })
+		case arena.DropAntibioticPayload:
+			if k, ok := antibioticIndex(p.Kind); ok {
+				s.eachCell(p.Area, func(i int) { s.Antibiotic[k][i] += p.Concentration })
+			}
+		case arena.SetTemperaturePayload:
+			s.Ambient = p.Temperature.Value
+		case arena.SpawnOrganismPayload:
+			s.spawn(p.Kind, p.Position)
+		}
+	}
+}
+
+func (s *State) eachCell(a arena.Area, fn func(i int)) {
+	for y := a.Y; y < a.Y+a.Height; y++ {
+		for x := a.X; x < a.X+a.Width; x++ {
+			fn(s.index(x, y))
+		}
+	}
+}
+
+func (s *State) spawn(kind arena.OrganismKind, at arena.Point) {
+	t, ok := kindTraits[kind]
+	if !ok || len(s.Organisms) >= s.Config.MaxOrganisms {
+		return
+	}
+	s.Organisms = append(s.Organisms, Organism{
+		ID:     s.NextOrganismID,
+		Kind:   kind,
+		X:      at.X,
+		Y:      at.Y,
+		Energy: t.initialEnergy,
+		Genome: t.baseGenome,
+	})
+	s.NextOrganismID++
+}
+
+// ----------------------------
+// Grid
+// ----------------------------
+
+var neighbours = [4][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}}
+
+func (s *State) diffuse() {
+	rate := s.Config.DiffusionRate
+	s.Nutrients = s.spread(s.Nutrients, rate)
+	for k := range s.Antibiotic {
+		layer := s.spread(s.Antibiotic[k], rate)
+		for i := range layer {
+			layer[i] *= 1 - antibioticDecay
+		}
+		s.Antibiotic[k] = layer
+	}
+
+	temp := s.spread(s.Temperature, rate)
+	for i := range temp {
+		temp[i] += (s.Ambient - temp[i]) * temperatureRelax
+	}
+	s.Temperature = temp
+}
+
+// spread distribui uma fração rate de cada célula igualmente entre os vizinhos
+// (4-vizinhança). A massa é conservada: a parte destinada a fora do grid fica na célula.
+func (s *State) spread(layer []float64, rate float64) []float64 {
+	out := append([]float64(nil), layer...)
+	if rate <= 0 {
+		return out
+	}
+	w, h := s.Config.Width, s.Config.Height
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			v := layer[s.index(x, y)]
+			if v == 0 {
+				continue
+			}
+			share := v * rate / float64(len(neighbours))
+			for _, d := range neighbours {
+				nx, ny := x+d[0], y+d[1]
+				if !s.inBounds(nx, ny) {
+					continue
+				}
+				out[s.index(nx, ny)] += share
+				out[s.index(x, y)] -= share
+			}
+		}
+	}
+	return out
+}
+
+// ----------------------------
+// Organisms
+// ----------------------------
+
+func (s *State) live(r *rng, st *Stats) {
+	// bactérias por célula, para a predação dos phages
+	hosts := make(map[int][]int)
+	for i, o := range s.Organisms {
+		if o.Kind == arena.KindBacteria {
+			c := s.index(o.X, o.Y)
+			hosts[c] = append(hosts[c], i)
+		}
+	}
+
+	dead := make([]bool, len(s.Organisms))
+	population := len(s.Organisms)
+	var born []Organism
+
+	for i := range s.Organisms {
+		if dead[i] {
+			continue
+		}
+		o := &s.Organisms[i]
+		t := kindTraits[o.Kind]
+		c := s.index(o.X, o.Y)
+		o.Age++
+
+		// alimentação
+		if o.Kind == arena.KindPhage {
+			for _, h := range hosts[c] {
+				if !dead[h] {
+					o.Energy += s.Organisms[h].Energy * phageYield
+					dead[h] = true
+					population--
+					break
+				}
+			}
+		} else {
+			eaten := min(o.Genome.Uptake, s.Nutrients[c])
+			s.Nutrients[c] -= eaten
+			o.Energy += eaten
+		}
+
+		// metabolismo
+		o.Energy -= t.basalCost * thermalStress(o.Genome, s.Temperature[c])
+
+		// antibióticos (phages são imunes)
+		if o.Kind != arena.KindPhage {
+			for k := range s.Antibiotic {
+				dose := s.Antibiotic[k][c] * (1 - o.Genome.Resistance[k])
+				if dose > 0 && r.float64() < dose/(1+dose) {
+					o.Energy = 0
+					break
+				}
+			}
+		}
+
+		if o.Energy <= 0 {
+			dead[i] = true
+			population--
+			continue
+		}
+
+		// divisão
+		if o.Energy >= t.divideAt && population < s.Config.MaxOrganisms {
+			d := neighbours[r.intn(len(neighbours))]
+			nx, ny := o.X+d[0], o.Y+d[1]
+			if !s.inBounds(nx, ny) {
+				nx, ny = o.X, o.Y
+			}
+
+			g, mutated := mutate(o.Genome, s.Config.MutationRate, r)
+			if mutated {
+				st.Mutations++
+			}
+
+			o.Energy /= 2
+			born = append(born, Organism{
+				ID:         s.NextOrganismID,
+				ParentID:   o.ID,
+				Kind:       o.Kind,
+				X:          nx,
+				Y:          ny,
+				Energy:     o.Energy,
+				Generation: o.Generation + 1,
+				Genome:     g,
+			})
+			s.NextOrganismID++
+			population++
+			st.Births++
+		}
+	}
+
+	alive := s.Organisms[:0]
+	for i, o := range s.Organisms {
+		if dead[i] {
+			st.Deaths++
+			continue
+		}
+		alive = append(alive, o)
+	}
+	s.Organisms = append(alive, born...)
+}
+
+// StepArena advances the arena aggregate and the world together: the actions
+// drained by AdvanceTick are exactly the ones Step applies, so both end up on
+// the same tick. The world must start on the arena tick (ErrWorldOutOfSync).
+func StepArena(s State, a *arena.Arena, now time.Time) (State, Stats, error) {
+	if s.Tick != a.Tick() {
+		return s, Stats{}, fmt.Errorf("%w: world %d, arena %d", ErrWorldOutOfSync, s.Tick, a.Tick())
+	}
+	due, err := a.AdvanceTick(now)
+	if err != nil {
+		return s, Stats{}, err
+	}
+	s.Config = a.Config()
+	next, st := Step(s, due)
+	return next, st, nil
+}
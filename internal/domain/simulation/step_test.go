@@ -0,0 +1,175 @@
+package simulation
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/petri-board-arena/internal/domain/arena"
+)
+
+func testConfig() arena.Config {
+	return arena.Config{
+		TickMillis: 100, Width: 8, Height: 8, MaxOrganisms: 50, SnapshotEveryTicks: 10,
+		Temperature: arena.Temperature{Unit: arena.TempC, Value: 30},
+	}
+}
+
+func testActions(at time.Time) []arena.PlayerAction {
+	return []arena.PlayerAction{
+		{
+			ID: arena.ActionID(uuid.New()), Type: arena.ActionSpawnOrganism, SubmittedAt: at, ApplyAtTick: 1,
+			Payload: arena.SpawnOrganismPayload{Kind: arena.KindBacteria, Position: arena.Point{X: 2, Y: 2}},
+		},
+		{
+			ID: arena.ActionID(uuid.New()), Type: arena.ActionAddNutrients, SubmittedAt: at, ApplyAtTick: 1,
+			Payload: arena.AddNutrientsPayload{Area: arena.Area{X: 0, Y: 0, Width: 4, Height: 4}, Amount: 5},
+		},
+		{
+			ID: arena.ActionID(uuid.New()), Type: arena.ActionSpawnOrganism, SubmittedAt: at.Add(time.Millisecond), ApplyAtTick: 1,
+			Payload: arena.SpawnOrganismPayload{Kind: arena.KindFungi, Position: arena.Point{X: 5, Y: 5}},
+		},
+	}
+}
+
+func TestStepIsDeterministic(t *testing.T) {
+	s0, err := NewState(testConfig(), 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actions := testActions(time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC))
+	before := s0.clone()
+
+	a, aStats := Step(s0, actions)
+	// a ordem do agendamento (map) não muda o resultado
+	reversed := []arena.PlayerAction{actions[2], actions[1], actions[0]}
+	b, bStats := Step(s0, reversed)
+
+	if !reflect.DeepEqual(a, b) || !reflect.DeepEqual(aStats, bStats) {
+		t.Fatal("same state and actions produced different results")
+	}
+	if !reflect.DeepEqual(s0, before) {
+		t.Fatal("Step mutated its input state")
+	}
+	if a.Tick != 1 || aStats.Tick != 1 {
+		t.Fatalf("tick = %d (stats %d), want 1", a.Tick, aStats.Tick)
+	}
+	if a.NextOrganismID < 3 {
+		t.Fatalf("NextOrganismID = %d, want both spawns applied", a.NextOrganismID)
+	}
+
+	// vários ticks seguidos continuam reproduzíveis
+	for i := 0; i < 20; i++ {
+		a, aStats = Step(a, nil)
+		b, bStats = Step(b, nil)
+	}
+	if !reflect.DeepEqual(a, b) || !reflect.DeepEqual(aStats, bStats) {
+		t.Fatal("runs diverged after 20 ticks")
+	}
+}
+
+func TestStepIgnoresActionsInvalidForTheConfig(t *testing.T) {
+	s0, err := NewState(testConfig(), 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	outside := arena.PlayerAction{
+		ID: arena.ActionID(uuid.New()), Type: arena.ActionSpawnOrganism, ApplyAtTick: 1,
+		Payload: arena.SpawnOrganismPayload{Kind: arena.KindBacteria, Position: arena.Point{X: 20, Y: 20}},
+	}
+	got, st := Step(s0, []arena.PlayerAction{outside})
+	want, wantStats := Step(s0, nil)
+	if !reflect.DeepEqual(got, want) || !reflect.DeepEqual(st, wantStats) {
+		t.Fatal("an action outside the grid changed the world")
+	}
+}
+
+func TestStepArenaAppliesTheDrainedActions(t *testing.T) {
+	at := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	a, err := arena.NewArena(arena.ID(uuid.New()), "petri", testConfig(), at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	player, err := a.Join(arena.PlayerID(uuid.New()), "alice", at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Start(at, player.ID); err != nil {
+		t.Fatal(err)
+	}
+	var submitted []arena.PlayerAction
+	for _, act := range testActions(at)[:2] {
+		act.PlayerID = player.ID
+		got, err := a.SubmitAction(act, at)
+		if err != nil {
+			t.Fatal(err)
+		}
+		submitted = append(submitted, got)
+	}
+
+	s0, err := NewState(testConfig(), 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, st, err := StepArena(s0, a, at.Add(100*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Tick != a.Tick() {
+		t.Fatalf("world tick %d, arena tick %d", got.Tick, a.Tick())
+	}
+	want, wantStats := Step(s0, submitted)
+	if !reflect.DeepEqual(got, want) || !reflect.DeepEqual(st, wantStats) {
+		t.Fatal("StepArena did not apply exactly the actions drained by AdvanceTick")
+	}
+	if got.NextOrganismID != 2 {
+		t.Fatalf("NextOrganismID = %d, want the scheduled spawn applied", got.NextOrganismID)
+	}
+
+	// arena pausada: nem a arena nem o mundo avançam
+	if err := a.Pause(at, player.ID); err != nil {
+		t.Fatal(err)
+	}
+	same, _, err := StepArena(got, a, at.Add(200*time.Millisecond))
+	if err == nil || same.Tick != got.Tick {
+		t.Fatalf("StepArena on a paused arena: tick %d err %v, want tick %d and an error", same.Tick, err, got.Tick)
+	}
+}
+
+func TestNewArenaState(t *testing.T) {
+	at := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	id := arena.ID(uuid.New())
+	a, err := arena.Rehydrate(arena.RehydrateState{ID: id, Name: "petri", Status: arena.StatusRunning, Tick: 12, Config: testConfig(), CreatedAt: at})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := arena.Rehydrate(arena.RehydrateState{ID: arena.ID(uuid.New()), Name: "petri", Status: arena.StatusRunning, Tick: 12, Config: testConfig(), CreatedAt: at})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s1, err := NewArenaState(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s2, _ := NewArenaState(a)
+	other, _ := NewArenaState(b)
+	if s1.Tick != 12 {
+		t.Fatalf("tick = %d, want the arena tick 12", s1.Tick)
+	}
+	if !reflect.DeepEqual(s1, s2) {
+		t.Fatal("same arena produced different worlds")
+	}
+	if s1.Seed == other.Seed {
+		t.Fatal("different arenas share the seed")
+	}
+
+	// mundo fora do tick da arena: nem a arena nem o mundo avançam
+	s1.Tick = 11
+	if _, _, err := StepArena(s1, a, at); !errors.Is(err, ErrWorldOutOfSync) || a.Tick() != 12 {
+		t.Fatalf("StepArena out of sync: err %v arena tick %d, want ErrWorldOutOfSync and tick 12", err, a.Tick())
+	}
+}
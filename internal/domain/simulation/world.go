@@ -0,0 +1,162 @@
+package simulation
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/petri-board-arena/internal/domain/arena"
+)
+
+// ----------------------------
+// Layers (espelha o enum WorldLayer do GraphQL)
+// ----------------------------
+
+type Layer string
+
+const (
+	LayerOrganisms   Layer = "ORGANISMS"
+	LayerNutrients   Layer = "NUTRIENTS"
+	LayerAntibiotic  Layer = "ANTIBIOTIC"
+	LayerTemperature Layer = "TEMPERATURE"
+)
+
+var Layers = []Layer{LayerOrganisms, LayerNutrients, LayerAntibiotic, LayerTemperature}
+
+// antibioticKinds fixa a ordem das camadas de antibiótico e dos genes de resistência.
+var antibioticKinds = [...]arena.AntibioticKind{arena.AntibioticA, arena.AntibioticB, arena.AntibioticC}
+
+func antibioticIndex(k arena.AntibioticKind) (int, bool) {
+	for i, kk := range antibioticKinds {
+		if kk == k {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// ----------------------------
+// Organisms
+// ----------------------------
+
+type Genome struct {
+	Uptake           float64
+	ThermalOptimum   float64
+	ThermalTolerance float64
+	Resistance       [len(antibioticKinds)]float64
+}
+
+type Organism struct {
+	ID         int64
+	ParentID   int64
+	Kind       arena.OrganismKind
+	X          int
+	Y          int
+	Energy     float64
+	Age        int64
+	Generation int
+	Genome     Genome
+}
+
+// ----------------------------
+// State
+// ----------------------------
+
+// State is the whole Petri dish at a given tick. Grid layers are row-major
+// slices of Width*Height cells.
+type State struct {
+	Tick   int64
+	Config arena.Config
+
+	// Ambient is the temperature the TEMPERATURE layer relaxes towards.
+	Ambient float64
+
+	Organisms   []Organism
+	Nutrients   []float64
+	Antibiotic  [len(antibioticKinds)][]float64
+	Temperature []float64
+
+	NextOrganismID int64
+	Seed           uint64
+}
+
+func NewState(cfg arena.Config, seed uint64) (State, error) {
+	if err := cfg.Validate(); err != nil {
+		return State{}, err
+	}
+
+	cells := cfg.Width * cfg.Height
+	s := State{
+		Config:         cfg,
+		Ambient:        cfg.Temperature.Value,
+		Nutrients:      make([]float64, cells),
+		Temperature:    make([]float64, cells),
+		NextOrganismID: 1,
+		Seed:           seed,
+	}
+	for i := range s.Antibiotic {
+		s.Antibiotic[i] = make([]float64, cells)
+	}
+	for i := range s.Temperature {
+		s.Temperature[i] = cfg.Temperature.Value
+	}
+	return s, nil
+}
+
+// ErrWorldOutOfSync: o mundo não está no tick da arena (StepArena).
+var ErrWorldOutOfSync = errors.New("world is not at the arena tick")
+
+// NewArenaState cria o mundo de uma arena no tick em que ela está. A seed vem do ID:
+// a mesma arena sempre começa no mesmo mundo.
+func NewArenaState(a *arena.Arena) (State, error) {
+	id := a.ID()
+	s, err := NewState(a.Config(), binary.BigEndian.Uint64(id[:8]))
+	if err != nil {
+		return State{}, err
+	}
+	s.Tick = a.Tick()
+	return s, nil
+}
+
+func (s State) Width() int  { return s.Config.Width }
+func (s State) Height() int { return s.Config.Height }
+
+func (s State) index(x, y int) int { return y*s.Config.Width + x }
+
+func (s State) inBounds(x, y int) bool {
+	return x >= 0 && y >= 0 && x < s.Config.Width && y < s.Config.Height
+}
+
+// Grid returns a copy of the requested layer. ORGANISMS counts living
+// organisms per cell and ANTIBIOTIC sums the concentration of every kind.
+func (s State) Grid(l Layer) []float64 {
+	out := make([]float64, s.Config.Width*s.Config.Height)
+	switch l {
+	case LayerOrganisms:
+		for _, o := range s.Organisms {
+			out[s.index(o.X, o.Y)]++
+		}
+	case LayerNutrients:
+		copy(out, s.Nutrients)
+	case LayerAntibiotic:
+		for _, layer := range s.Antibiotic {
+			for i, v := range layer {
+				out[i] += v
+			}
+		}
+	case LayerTemperature:
+		copy(out, s.Temperature)
+	}
+	return out
+}
+
+// clone faz deep copy para manter Step puro.
+func (s State) clone() State {
+	c := s
+	c.Organisms = append([]Organism(nil), s.Organisms...)
+	c.Nutrients = append([]float64(nil), s.Nutrients...)
+	c.Temperature = append([]float64(nil), s.Temperature...)
+	for i := range s.Antibiotic {
+		c.Antibiotic[i] = append([]float64(nil), s.Antibiotic[i]...)
+	}
+	return c
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"log"
 	"net/http"
@@ -15,27 +16,27 @@ import (
 	"github.com/petri-board-arena/graph"
 
 	createarena "github.com/petri-board-arena/internal/application/command"
-	"github.com/petri-board-arena/internal/application/port/repository"
+	"github.com/petri-board-arena/internal/application/query"
+	"github.com/petri-board-arena/internal/application/ticker"
 
 	"github.com/petri-board-arena/internal/infrastructure/adapter"
+	"github.com/petri-board-arena/internal/infrastructure/config"
 	pg "github.com/petri-board-arena/internal/infrastructure/persistence/postgres"
+	pgoutbox "github.com/petri-board-arena/internal/infrastructure/persistence/postgres/outbox"
 	pgwrite "github.com/petri-board-arena/internal/infrastructure/persistence/postgres/write"
+	infraredis "github.com/petri-board-arena/internal/infrastructure/persistence/redis"
+	"github.com/petri-board-arena/internal/runtime/requestctx"
 )
 
-const defaultPort = "8080"
-
 func main() {
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = defaultPort
-	}
-
-	dsn := os.Getenv("DATABASE_URL")
-	if dsn == "" {
-		log.Fatal("DATABASE_URL not set")
+	cfg, err := config.Load(config.ServiceAPI, os.Args[1:])
+	if err != nil {
+		log.Fatal(err)
 	}
+	cfg.Report(log.Writer())
+	port := cfg.App.Port
 
-	db, err := sql.Open("postgres", dsn)
+	db, err := sql.Open("postgres", cfg.Postgres.URL)
 	if err != nil {
 		log.Fatalf("open db: %v", err)
 	}
@@ -45,17 +46,51 @@ func main() {
 		log.Fatalf("ping db: %v", err)
 	}
 
+	rdb, err := infraredis.NewRedisClient(cfg.Redis.URL)
+	if err != nil {
+		log.Fatalf("redis: %v", err)
+	}
+	defer rdb.Close()
+	readRepo := infraredis.NewArenaReadRepo(rdb)
+
 	uow := pg.NewUnitOfWork(db)
-	var writeRepo repository.ArenaWriteRepository = pgwrite.NewArenaRepo(db)
+	// postgres.arenaStore: row (tabelas) ou event (arena_event + snapshots)
+	writeRepo := pgwrite.NewArenaRepository(db, cfg.Postgres)
 
 	clock := adapter.RealClock{}
 	ids := adapter.UUIDGen{}
-	pub := adapter.NopArenaPublisher{}
+	// tokens de sessão assinados: identificam o jogador nas mutations (Authorization: Bearer)
+	tokens := adapter.NewSignedSessionTokens(cfg.Session.Secret, cfg.Session.TTL)
+	// eventos de domínio vão para o outbox na mesma tx do aggregate; o relay publica no Kafka
+	outboxStore := pgoutbox.NewStore(db)
+	pub := adapter.NewOutboxArenaPublisher(outboxStore, cfg.Kafka.Topic, 0)
 
 	createArenaHandler := createarena.NewHandler(uow, writeRepo, ids, clock, pub)
 
+	// ticks: arenas RUNNING avançam a cada tickMillis (ticker.interval 0 desabilita)
+	if cfg.Ticker.Interval > 0 {
+		advance := createarena.NewAdvanceTickHandler(uow, writeRepo, pgwrite.NewArenaWorldRepo(db), clock, pub)
+		go ticker.NewDriver(readRepo, advance, clock).Run(context.Background(), cfg.Ticker.Interval)
+	}
+
 	resolvers := graph.NewResolver(graph.ResolverDeps{
-		CreateArenaHandler: createArenaHandler,
+		CreateArenaHandler:    createArenaHandler,
+		StartArenaHandler:     createarena.NewStartArenaHandler(uow, writeRepo, clock, pub),
+		PauseArenaHandler:     createarena.NewPauseArenaHandler(uow, writeRepo, clock, pub),
+		ResumeArenaHandler:    createarena.NewResumeArenaHandler(uow, writeRepo, clock, pub),
+		StopArenaHandler:      createarena.NewStopArenaHandler(uow, writeRepo, clock, pub),
+		JoinArenaHandler:      createarena.NewJoinArenaHandler(uow, writeRepo, ids, tokens, clock, pub),
+		LeaveArenaHandler:     createarena.NewLeaveArenaHandler(uow, writeRepo, clock, pub),
+		SubmitActionHandler:   createarena.NewSubmitActionHandler(uow, writeRepo, ids, clock, pub),
+		SetArenaConfigHandler: createarena.NewSetArenaConfigHandler(uow, writeRepo, clock, pub),
+
+		GetArenaHandler:   query.NewGetArenaHandler(readRepo),
+		ListArenasHandler: query.NewListArenasHandler(readRepo),
+
+		ListDeadLettersHandler:    query.NewListDeadLettersHandler(outboxStore),
+		RequeueDeadLettersHandler: createarena.NewRequeueDeadLettersHandler(outboxStore),
+		PurgeDeadLettersHandler:   createarena.NewPurgeDeadLettersHandler(outboxStore),
+		AdminToken:                cfg.Admin.APIToken,
 	})
 
 	schema := graph.NewExecutableSchema(graph.Config{Resolvers: resolvers})
@@ -66,9 +101,10 @@ func main() {
 	srv.AddTransport(transport.POST{})
 
 	srv.Use(extension.Introspection{})
+	srv.SetErrorPresenter(graph.ErrorPresenter)
 
 	http.Handle("/", playground.Handler("GraphQL playground", "/query"))
-	http.Handle("/query", srv)
+	http.Handle("/query", requestctx.Middleware(tokens, srv))
 
 	log.Printf("connect to http://localhost:%s/ for GraphQL playground", port)
 	log.Fatal(http.ListenAndServe(":"+port, nil))
@@ -0,0 +1,96 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/petri-board-arena/internal/application/port"
+	"github.com/petri-board-arena/internal/domain/arena"
+	"github.com/petri-board-arena/internal/runtime/requestctx"
+)
+
+type recordingOutbox struct {
+	port.OutboxStore
+	keys map[string]bool
+}
+
+func (r *recordingOutbox) Enqueue(_ context.Context, p port.OutboxEnqueueParams) error {
+	if p.IdempotencyKey == nil {
+		return nil
+	}
+	if r.keys[*p.IdempotencyKey] {
+		// o mesmo que ux_outbox_event_idempotency_key
+		return errDuplicateKey
+	}
+	r.keys[*p.IdempotencyKey] = true
+	return nil
+}
+
+var errDuplicateKey = errors.New("duplicate idempotency key")
+
+// Mesmo aggregate e mesma sequência (evento gravado de novo) colidem; eventos de
+// comandos diferentes da mesma requisição (ex.: mutations com alias) não.
+func TestOutboxArenaPublisherIdempotencyKey(t *testing.T) {
+	ctx := requestctx.WithCausationID(context.Background(), "req-1")
+	id := arena.ID(uuid.New())
+	at := time.Now()
+	started := arena.ArenaStarted{BaseEvent: arena.NewBaseEvent(id, at)}
+	paused := arena.ArenaPaused{BaseEvent: arena.NewBaseEvent(id, at)}
+
+	tests := []struct {
+		name     string
+		publish  func(pub *OutboxArenaPublisher) error
+		wantErr  bool
+		wantKeys []string
+	}{
+		{
+			name: "same aggregate and sequence",
+			publish: func(pub *OutboxArenaPublisher) error {
+				if err := pub.Publish(ctx, 2, started); err != nil {
+					return err
+				}
+				return pub.Publish(ctx, 2, started)
+			},
+			wantErr:  true,
+			wantKeys: []string{"arena:" + id.String() + ":2"},
+		},
+		{
+			name: "same request, next sequence",
+			publish: func(pub *OutboxArenaPublisher) error {
+				if err := pub.Publish(ctx, 2, started); err != nil {
+					return err
+				}
+				return pub.Publish(ctx, 3, paused)
+			},
+			wantKeys: []string{"arena:" + id.String() + ":2", "arena:" + id.String() + ":3"},
+		},
+		{
+			name: "several events in one save",
+			publish: func(pub *OutboxArenaPublisher) error {
+				return pub.Publish(ctx, 5, started, paused)
+			},
+			wantKeys: []string{"arena:" + id.String() + ":4", "arena:" + id.String() + ":5"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &recordingOutbox{keys: map[string]bool{}}
+			err := tt.publish(NewOutboxArenaPublisher(store, "arena.events", 0))
+			if tt.wantErr != errors.Is(err, errDuplicateKey) {
+				t.Fatalf("err = %v, want duplicate %v", err, tt.wantErr)
+			}
+			if len(store.keys) != len(tt.wantKeys) {
+				t.Fatalf("keys = %v, want %v", store.keys, tt.wantKeys)
+			}
+			for _, k := range tt.wantKeys {
+				if !store.keys[k] {
+					t.Fatalf("keys = %v, want %v", store.keys, tt.wantKeys)
+				}
+			}
+		})
+	}
+}
@@ -9,4 +9,4 @@ import (
 // substituir por Outbox/EventBus.
 type NopArenaPublisher struct{}
 
-func (NopArenaPublisher) Publish(_ context.Context, _ ...arena.Event) error { return nil }
+func (NopArenaPublisher) Publish(_ context.Context, _ int64, _ ...arena.Event) error { return nil }
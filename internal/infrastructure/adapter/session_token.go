@@ -0,0 +1,77 @@
+package adapter
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/petri-board-arena/internal/domain/arena"
+	"github.com/petri-board-arena/internal/runtime/requestctx"
+)
+
+// ErrInvalidSessionToken: token malformado, com assinatura inválida ou expirado.
+var ErrInvalidSessionToken = errors.New("invalid session token")
+
+// sessionClaimsLen: arenaId (16) + playerId (16) + expiração unix (8).
+const sessionClaimsLen = 16 + 16 + 8
+
+// SignedSessionTokens emite e valida tokens "<claims>.<hmac>" (base64url) que ligam
+// o jogador à arena em que entrou. Não há estado no servidor: a saída da arena é
+// barrada pelo domínio (jogador que saiu não é mais membro).
+type SignedSessionTokens struct {
+	secret []byte
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+func NewSignedSessionTokens(secret string, ttl time.Duration) *SignedSessionTokens {
+	return &SignedSessionTokens{secret: []byte(secret), ttl: ttl, now: time.Now}
+}
+
+func (t *SignedSessionTokens) Issue(_ context.Context, arenaID arena.ID, playerID arena.PlayerID) (string, error) {
+	claims := make([]byte, sessionClaimsLen)
+	copy(claims[0:16], arenaID[:])
+	copy(claims[16:32], playerID[:])
+	binary.BigEndian.PutUint64(claims[32:], uint64(t.now().Add(t.ttl).Unix()))
+
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString(claims) + "." + enc.EncodeToString(t.sign(claims)), nil
+}
+
+// Verify implementa requestctx.SessionVerifier.
+func (t *SignedSessionTokens) Verify(token string) (requestctx.Session, error) {
+	enc := base64.RawURLEncoding
+	c, s, ok := strings.Cut(token, ".")
+	if !ok {
+		return requestctx.Session{}, ErrInvalidSessionToken
+	}
+	claims, err := enc.DecodeString(c)
+	if err != nil || len(claims) != sessionClaimsLen {
+		return requestctx.Session{}, ErrInvalidSessionToken
+	}
+	sig, err := enc.DecodeString(s)
+	if err != nil || !hmac.Equal(sig, t.sign(claims)) {
+		return requestctx.Session{}, ErrInvalidSessionToken
+	}
+	if exp := int64(binary.BigEndian.Uint64(claims[32:])); t.now().Unix() >= exp {
+		return requestctx.Session{}, ErrInvalidSessionToken
+	}
+
+	return requestctx.Session{
+		ArenaID:  arena.ID(uuid.UUID(claims[0:16])),
+		PlayerID: arena.PlayerID(uuid.UUID(claims[16:32])),
+	}, nil
+}
+
+func (t *SignedSessionTokens) sign(claims []byte) []byte {
+	m := hmac.New(sha256.New, t.secret)
+	m.Write(claims)
+	return m.Sum(nil)
+}
@@ -0,0 +1,61 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/petri-board-arena/internal/domain/arena"
+)
+
+func TestSignedSessionTokens(t *testing.T) {
+	arenaID := arena.ID(uuid.New())
+	playerID := arena.PlayerID(uuid.New())
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tokens := NewSignedSessionTokens("0123456789abcdef0123456789abcdef", time.Hour)
+	tokens.now = func() time.Time { return now }
+
+	token, err := tokens.Issue(context.Background(), arenaID, playerID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := tokens.Verify(token)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if s.ArenaID != arenaID || s.PlayerID != playerID {
+		t.Fatalf("Verify = %+v, want arena %s player %s", s, arenaID, uuid.UUID(playerID))
+	}
+
+	other := NewSignedSessionTokens("another-secret-another-secret-xx", time.Hour)
+	tampered := []byte(token)
+	tampered[3] ^= 1
+
+	cases := map[string]struct {
+		tokens *SignedSessionTokens
+		token  string
+		at     time.Time
+	}{
+		"empty":        {tokens, "", now},
+		"no signature": {tokens, token[:len(token)/2], now},
+		"tampered":     {tokens, string(tampered), now},
+		"other secret": {other, token, now},
+		"expired":      {tokens, token, now.Add(time.Hour)},
+		"garbage":      {tokens, "a.b", now},
+		"short claims": {tokens, "AAAA.AAAA", now},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			at := tc.at
+			tc.tokens.now = func() time.Time { return at }
+			if _, err := tc.tokens.Verify(tc.token); !errors.Is(err, ErrInvalidSessionToken) {
+				t.Fatalf("Verify = %v, want ErrInvalidSessionToken", err)
+			}
+		})
+	}
+}
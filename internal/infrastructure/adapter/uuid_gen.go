@@ -12,3 +12,11 @@ type UUIDGen struct{}
 func (UUIDGen) NewArenaID(_ context.Context) (arena.ID, error) {
 	return uuid.New(), nil
 }
+
+func (UUIDGen) NewPlayerID(_ context.Context) (arena.PlayerID, error) {
+	return arena.PlayerID(uuid.New()), nil
+}
+
+func (UUIDGen) NewActionID(_ context.Context) (arena.ActionID, error) {
+	return arena.ActionID(uuid.New()), nil
+}
@@ -0,0 +1,361 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/google/uuid"
+)
+
+// Service indica qual binário está carregando a config (muda o que é obrigatório).
+type Service string
+
+const (
+	ServiceAPI    Service = "api"
+	ServiceWorker Service = "worker"
+	ServiceRelay  Service = "relay"
+)
+
+// Config é a configuração tipada de todos os binários (api, worker, outbox-relay).
+//
+// Precedência (menor → maior): defaults, arquivo YAML (-config ou CONFIG_FILE),
+// variáveis de ambiente, flags (-<chave>=valor, ex.: -kafka.maxRetries=3).
+type Config struct {
+	App      App
+	Postgres Postgres
+	Redis    Redis
+	Kafka    Kafka
+	Worker   Worker
+	Relay    Relay
+	Janitor  Janitor
+	Ticker   Ticker
+	Metrics  Metrics
+	Admin    Admin
+	Session  Session
+
+	// origem de cada chave (default, file, env:NOME, flag) e avisos (ex.: env legado)
+	sources map[string]string
+	notes   []string
+}
+
+type App struct {
+	Env  string
+	Port string
+}
+
+type Postgres struct {
+	URL string
+	// ArenaStore escolhe a persistência do aggregate arena: ArenaStoreRow ou ArenaStoreEvent.
+	ArenaStore string
+	// SnapshotEvery: eventos entre snapshots quando ArenaStore = ArenaStoreEvent.
+	SnapshotEvery int
+}
+
+const (
+	ArenaStoreRow   = "row"
+	ArenaStoreEvent = "event"
+)
+
+type Redis struct {
+	URL string
+}
+
+type Kafka struct {
+	Brokers  []string
+	Topic    string
+	GroupID  string
+	DLQTopic string
+
+	PollMinBytes   int
+	PollMaxBytes   int
+	ReadTimeout    time.Duration
+	CommitInterval time.Duration
+
+	// MaxRetries: tentativas inline quando não há RetryTiers
+	MaxRetries      int
+	RetryBackoff    time.Duration
+	RetryMaxBackoff time.Duration
+	RetryTiers      []time.Duration
+
+	Workers         int
+	WorkerQueueSize int
+
+	// producer (outbox relay)
+	BatchTimeout time.Duration
+	WriteTimeout time.Duration
+	// Encoding do valor publicado: json ou protobuf (consumidores leem os dois pelo header contentType)
+	Encoding string
+}
+
+type Worker struct {
+	// IdempotencyTTL: validade da marca processed:event:<id> (padrão 7 dias; 0 = não expira)
+	IdempotencyTTL time.Duration
+	// ActionLogMaxLen: tamanho aproximado do stream arena:<id>:actions (0 = sem limite)
+	ActionLogMaxLen int
+}
+
+type Relay struct {
+	// WorkerID identifica a instância (outbox_event.locked_by); padrão único por processo.
+	WorkerID     string
+	BatchSize    int
+	LockTTL      time.Duration
+	PollInterval time.Duration
+	BaseBackoff  time.Duration
+
+	// Listen habilita LISTEN/NOTIFY para acordar o relay logo após o commit.
+	Listen             bool
+	ListenMinReconnect time.Duration
+	ListenMaxReconnect time.Duration
+}
+
+// Janitor remove/arquiva eventos publicados; Interval 0 desabilita.
+type Janitor struct {
+	Interval         time.Duration
+	Mode             string
+	Retention        time.Duration
+	BatchSize        int
+	MaxBatches       int
+	ArchiveRetention time.Duration
+}
+
+// Ticker.Interval: de quanto em quanto a api procura arenas com tick vencido; 0 desabilita.
+type Ticker struct {
+	Interval time.Duration
+}
+
+// Metrics.Addr expõe /debug/vars (expvar); vazio desabilita.
+type Metrics struct {
+	Addr string
+}
+
+// Admin.APIToken habilita as operações de ops no GraphQL (header X-Admin-Token).
+type Admin struct {
+	APIToken string
+}
+
+// Session assina os tokens de sessão emitidos no joinArena (HMAC-SHA256).
+type Session struct {
+	Secret string
+	TTL    time.Duration
+}
+
+// Load monta a config do service a partir de defaults, YAML, env e args (flags).
+// Todos os problemas (parse e validação) voltam juntos em um único erro.
+func Load(service Service, args []string) (Config, error) {
+	var cfg Config
+	cfg.sources = map[string]string{}
+	fields := cfg.fields()
+
+	var errs []error
+	for _, f := range fields {
+		if err := f.value.Set(f.def); err != nil {
+			errs = append(errs, fmt.Errorf("%s: invalid default %q: %w", f.key, f.def, err))
+		}
+		cfg.sources[f.key] = "default"
+	}
+
+	fs := flag.NewFlagSet(string(service), flag.ContinueOnError)
+	configFile := fs.String("config", os.Getenv("CONFIG_FILE"), "arquivo YAML de configuração")
+	flagged := make(map[string]*flagValue, len(fields))
+	for _, f := range fields {
+		fv := &flagValue{}
+		flagged[f.key] = fv
+		fs.Var(fv, f.key, "env "+f.env)
+	}
+	if err := fs.Parse(args); err != nil {
+		return Config{}, fmt.Errorf("config(%s): %w", service, err)
+	}
+
+	// arquivo
+	if *configFile != "" {
+		values, err := readYAML(*configFile)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("config file %s: %w", *configFile, err))
+		}
+		known := make(map[string]bool, len(fields))
+		for _, f := range fields {
+			known[f.key] = true
+			v, ok := values[f.key]
+			if !ok {
+				continue
+			}
+			if err := f.value.Set(v); err != nil {
+				errs = append(errs, fmt.Errorf("%s (file): %w", f.key, err))
+			}
+			cfg.sources[f.key] = "file"
+		}
+		for k := range values {
+			if !known[k] {
+				errs = append(errs, fmt.Errorf("%s (file): unknown key", k))
+			}
+		}
+	}
+
+	// env: o nome canônico vence os aliases legados
+	for _, f := range fields {
+		name, v, ok := lookupEnv(f.env, f.aliases)
+		if !ok {
+			continue
+		}
+		if name != f.env {
+			cfg.notes = append(cfg.notes, fmt.Sprintf("%s is deprecated, use %s", name, f.env))
+		}
+		if err := f.value.Set(v); err != nil {
+			errs = append(errs, fmt.Errorf("%s (env %s): %w", f.key, name, err))
+		}
+		cfg.sources[f.key] = "env:" + name
+	}
+
+	// flags
+	for _, f := range fields {
+		fv := flagged[f.key]
+		if !fv.set {
+			continue
+		}
+		if err := f.value.Set(fv.raw); err != nil {
+			errs = append(errs, fmt.Errorf("%s (flag): %w", f.key, err))
+		}
+		cfg.sources[f.key] = "flag"
+	}
+
+	if cfg.Relay.WorkerID == "" {
+		cfg.Relay.WorkerID = defaultWorkerID()
+	}
+
+	errs = append(errs, cfg.validate(service)...)
+	if err := errors.Join(errs...); err != nil {
+		return Config{}, fmt.Errorf("config(%s):\n%w", service, err)
+	}
+	return cfg, nil
+}
+
+func (c *Config) validate(service Service) []error {
+	var errs []error
+	require := func(ok bool, msg string) {
+		if !ok {
+			errs = append(errs, errors.New(msg))
+		}
+	}
+	positive := func(key string, v int64) {
+		require(v > 0, key+": must be > 0")
+	}
+
+	switch service {
+	case ServiceAPI:
+		require(c.Postgres.URL != "", "postgres.url: WRITE_DATABASE_URL not set")
+		require(c.Redis.URL != "", "redis.url: REDIS_URL not set")
+		require(c.Kafka.Topic != "", "kafka.topic: KAFKA_TOPIC not set")
+		require(c.Postgres.ArenaStore == ArenaStoreRow || c.Postgres.ArenaStore == ArenaStoreEvent, "postgres.arenaStore: must be row or event")
+		positive("postgres.snapshotEvery", int64(c.Postgres.SnapshotEvery))
+		require(len(c.Session.Secret) >= 32, "session.secret: SESSION_SECRET must have at least 32 bytes")
+		positive("session.ttl", int64(c.Session.TTL))
+		require(c.Ticker.Interval >= 0, "ticker.interval: must be >= 0")
+
+	case ServiceWorker:
+		require(c.Redis.URL != "", "redis.url: REDIS_URL not set")
+		require(len(c.Kafka.Brokers) > 0, "kafka.brokers: KAFKA_BROKERS not set")
+		require(c.Kafka.Topic != "", "kafka.topic: KAFKA_TOPIC not set")
+		require(c.Kafka.GroupID != "", "kafka.groupId: KAFKA_GROUP_ID not set")
+		require(c.Kafka.DLQTopic != "", "kafka.dlqTopic: KAFKA_DLQ_TOPIC not set")
+		require(c.Kafka.DLQTopic != c.Kafka.Topic, "kafka.dlqTopic: must differ from kafka.topic")
+		positive("kafka.maxRetries", int64(c.Kafka.MaxRetries))
+		positive("kafka.pollMinBytes", int64(c.Kafka.PollMinBytes))
+		require(c.Kafka.PollMaxBytes >= c.Kafka.PollMinBytes, "kafka.pollMaxBytes: must be >= kafka.pollMinBytes")
+		positive("kafka.readTimeout", int64(c.Kafka.ReadTimeout))
+		positive("kafka.workers", int64(c.Kafka.Workers))
+		positive("kafka.workerQueueSize", int64(c.Kafka.WorkerQueueSize))
+		require(c.Worker.IdempotencyTTL >= 0, "worker.idempotencyTtl: must be >= 0")
+		require(c.Worker.ActionLogMaxLen >= 0, "worker.actionLogMaxLen: must be >= 0")
+
+	case ServiceRelay:
+		require(c.Postgres.URL != "", "postgres.url: WRITE_DATABASE_URL not set")
+		require(len(c.Kafka.Brokers) > 0, "kafka.brokers: KAFKA_BROKERS not set")
+		require(c.Kafka.Encoding == "json" || c.Kafka.Encoding == "protobuf", "kafka.encoding: must be json or protobuf")
+		positive("relay.batchSize", int64(c.Relay.BatchSize))
+		positive("relay.lockTtl", int64(c.Relay.LockTTL))
+		positive("relay.pollInterval", int64(c.Relay.PollInterval))
+		positive("relay.baseBackoff", int64(c.Relay.BaseBackoff))
+		if c.Janitor.Interval > 0 {
+			require(c.Janitor.Mode == "delete" || c.Janitor.Mode == "archive", "janitor.mode: must be delete or archive")
+			positive("janitor.retention", int64(c.Janitor.Retention))
+			positive("janitor.batchSize", int64(c.Janitor.BatchSize))
+			positive("janitor.maxBatches", int64(c.Janitor.MaxBatches))
+		}
+
+	default:
+		errs = append(errs, fmt.Errorf("unknown service %q", service))
+	}
+
+	for _, u := range []struct{ key, v string }{{"postgres.url", c.Postgres.URL}, {"redis.url", c.Redis.URL}} {
+		if u.v == "" {
+			continue
+		}
+		if _, err := url.Parse(u.v); err != nil {
+			errs = append(errs, fmt.Errorf("%s: invalid url", u.key))
+		}
+	}
+	return errs
+}
+
+func lookupEnv(name string, aliases []string) (string, string, bool) {
+	for _, n := range append([]string{name}, aliases...) {
+		if v := strings.TrimSpace(os.Getenv(n)); v != "" {
+			return n, v, true
+		}
+	}
+	return "", "", false
+}
+
+// readYAML achata o documento em chaves pontilhadas (kafka.maxRetries) com
+// valores em texto; listas viram CSV.
+func readYAML(path string) (map[string]string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]any
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+	out := map[string]string{}
+	flatten("", doc, out)
+	return out, nil
+}
+
+func flatten(prefix string, v any, out map[string]string) {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, child := range t {
+			key := k
+			if prefix != "" {
+				key = prefix + "." + k
+			}
+			flatten(key, child, out)
+		}
+	case []any:
+		parts := make([]string, len(t))
+		for i, item := range t {
+			parts[i] = fmt.Sprint(item)
+		}
+		out[prefix] = strings.Join(parts, ",")
+	case nil:
+		out[prefix] = ""
+	default:
+		out[prefix] = fmt.Sprint(t)
+	}
+}
+
+// defaultWorkerID é único por processo: hostname sozinho colide entre réplicas do mesmo pod/host.
+func defaultWorkerID() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "relay"
+	}
+	return fmt.Sprintf("%s-%d-%s", host, os.Getpid(), uuid.NewString()[:8])
+}
@@ -0,0 +1,165 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// clearEnv zera as envs da tabela (e o CONFIG_FILE) para o teste não herdar o ambiente.
+func clearEnv(t *testing.T) {
+	t.Helper()
+	t.Setenv("CONFIG_FILE", "")
+	var c Config
+	for _, f := range c.fields() {
+		t.Setenv(f.env, "")
+		for _, a := range f.aliases {
+			t.Setenv(a, "")
+		}
+	}
+}
+
+func writeYAML(t *testing.T, doc string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(doc), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("REDIS_URL", "redis://localhost:6379/0")
+	t.Setenv("KAFKA_WORKERS", "4")
+	t.Setenv("KAFKA_WORKER_QUEUE_SIZE", "11")
+	path := writeYAML(t, `
+kafka:
+  maxRetries: 7
+  workers: 3
+  workerQueueSize: 10
+`)
+
+	cfg, err := Load(ServiceWorker, []string{"-config", path, "-kafka.workerQueueSize=12"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		key    string
+		got    int
+		want   int
+		source string
+	}{
+		{"kafka.pollMaxBytes", cfg.Kafka.PollMaxBytes, 10000000, "default"},
+		{"kafka.maxRetries", cfg.Kafka.MaxRetries, 7, "file"},
+		{"kafka.workers", cfg.Kafka.Workers, 4, "env:KAFKA_WORKERS"},
+		{"kafka.workerQueueSize", cfg.Kafka.WorkerQueueSize, 12, "flag"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want || cfg.sources[tt.key] != tt.source {
+			t.Errorf("%s = %d (%s), want %d (%s)", tt.key, tt.got, cfg.sources[tt.key], tt.want, tt.source)
+		}
+	}
+	if cfg.Worker.IdempotencyTTL != 7*24*time.Hour {
+		t.Errorf("worker.idempotencyTtl = %v, want the 7 day default", cfg.Worker.IdempotencyTTL)
+	}
+}
+
+func TestLoadLegacyEnvAliases(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("READ_DATABASE_URL", "redis://localhost:6379/0")
+	t.Setenv("WORKER_MAX_RETRIES", "9")
+	// o nome canônico vence o alias e não gera aviso
+	t.Setenv("KAFKA_MAX_BYTES", "500")
+	t.Setenv("KAFKA_POLL_MAX_BYTES", "600")
+
+	cfg, err := Load(ServiceWorker, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Redis.URL != "redis://localhost:6379/0" || cfg.Kafka.MaxRetries != 9 || cfg.Kafka.PollMaxBytes != 600 {
+		t.Fatalf("redis.url %q, kafka.maxRetries %d, kafka.pollMaxBytes %d", cfg.Redis.URL, cfg.Kafka.MaxRetries, cfg.Kafka.PollMaxBytes)
+	}
+
+	var report strings.Builder
+	cfg.Report(&report)
+	for _, want := range []string{
+		"warning: READ_DATABASE_URL is deprecated, use REDIS_URL",
+		"warning: WORKER_MAX_RETRIES is deprecated, use KAFKA_MAX_RETRIES",
+		"(env:WORKER_MAX_RETRIES)",
+	} {
+		if !strings.Contains(report.String(), want) {
+			t.Errorf("report missing %q:\n%s", want, report.String())
+		}
+	}
+	if strings.Contains(report.String(), "KAFKA_MAX_BYTES is deprecated") {
+		t.Errorf("report warns about an alias shadowed by the canonical env:\n%s", report.String())
+	}
+}
+
+func TestLoadJoinsEveryError(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("KAFKA_WORKERS", "many")
+	path := writeYAML(t, `
+kafka:
+  unknownKey: 1
+`)
+
+	_, err := Load(ServiceWorker, []string{"-config", path, "-kafka.readTimeout=soon"})
+	if err == nil {
+		t.Fatal("want error")
+	}
+	for _, want := range []string{
+		"config(worker)",
+		`kafka.workers (env KAFKA_WORKERS): invalid integer "many"`,
+		`kafka.readTimeout (flag): invalid duration "soon"`,
+		"kafka.unknownKey (file): unknown key",
+		"redis.url: REDIS_URL not set",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error missing %q:\n%v", want, err)
+		}
+	}
+}
+
+func TestReportRedactsSecrets(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("WRITE_DATABASE_URL", "postgres://arena:pg-pass@db:5432/arena?sslmode=disable&sslpassword=ssl-pass")
+	t.Setenv("REDIS_URL", "redis://:redis-pass@cache:6379/0")
+	t.Setenv("SESSION_SECRET", strings.Repeat("s", 32))
+	t.Setenv("ADMIN_API_TOKEN", "admin-token")
+
+	cfg, err := Load(ServiceAPI, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report strings.Builder
+	cfg.Report(&report)
+	out := report.String()
+
+	for _, secret := range []string{"pg-pass", "ssl-pass", "redis-pass", strings.Repeat("s", 32), "admin-token"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("report leaks %q:\n%s", secret, out)
+		}
+	}
+	values := map[string]string{}
+	for _, line := range strings.Split(out, "\n") {
+		if cols := strings.Fields(line); len(cols) == 3 {
+			values[cols[0]] = cols[1]
+		}
+	}
+	// host e banco continuam visíveis para diagnóstico
+	for key, want := range map[string]string{
+		"postgres.url":   "postgres://arena:xxxxx@db:5432/arena?sslmode=disable&sslpassword=xxxxx",
+		"redis.url":      "redis://:xxxxx@cache:6379/0",
+		"session.secret": redacted,
+		"admin.apiToken": redacted,
+	} {
+		if values[key] != want {
+			t.Errorf("%s = %q, want %q", key, values[key], want)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// field liga uma chave (YAML/flag) à env canônica, aliases legados, default e destino.
+type field struct {
+	key     string
+	env     string
+	aliases []string
+	def     string
+	secret  bool
+	value   value
+}
+
+type value interface {
+	Set(string) error
+	String() string
+}
+
+// fields é a tabela única de configuração: toda chave nova entra aqui.
+func (c *Config) fields() []field {
+	return []field{
+		{key: "app.env", env: "APP_ENV", def: "dev", value: (*stringValue)(&c.App.Env)},
+		{key: "app.port", env: "PORT", def: "8080", value: (*stringValue)(&c.App.Port)},
+
+		{key: "postgres.url", env: "WRITE_DATABASE_URL", aliases: []string{"DATABASE_URL"}, secret: true, value: (*stringValue)(&c.Postgres.URL)},
+		{key: "postgres.arenaStore", env: "ARENA_STORE", def: ArenaStoreRow, value: (*stringValue)(&c.Postgres.ArenaStore)},
+		{key: "postgres.snapshotEvery", env: "ARENA_SNAPSHOT_EVERY", def: "100", value: (*intValue)(&c.Postgres.SnapshotEvery)},
+		{key: "redis.url", env: "REDIS_URL", aliases: []string{"READ_DATABASE_URL"}, secret: true, value: (*stringValue)(&c.Redis.URL)},
+
+		{key: "kafka.brokers", env: "KAFKA_BROKERS", def: "localhost:9092", value: (*csvValue)(&c.Kafka.Brokers)},
+		{key: "kafka.topic", env: "KAFKA_TOPIC", def: "petri.arena.events.v1", value: (*stringValue)(&c.Kafka.Topic)},
+		{key: "kafka.groupId", env: "KAFKA_GROUP_ID", def: "petri-arena-projector", value: (*stringValue)(&c.Kafka.GroupID)},
+		{key: "kafka.dlqTopic", env: "KAFKA_DLQ_TOPIC", def: "petri.arena.events.v1.dlq", value: (*stringValue)(&c.Kafka.DLQTopic)},
+		{key: "kafka.pollMinBytes", env: "KAFKA_POLL_MIN_BYTES", aliases: []string{"KAFKA_MIN_BYTES"}, def: "1", value: (*intValue)(&c.Kafka.PollMinBytes)},
+		{key: "kafka.pollMaxBytes", env: "KAFKA_POLL_MAX_BYTES", aliases: []string{"KAFKA_MAX_BYTES"}, def: "10000000", value: (*intValue)(&c.Kafka.PollMaxBytes)},
+		{key: "kafka.readTimeout", env: "KAFKA_READ_TIMEOUT", def: "2s", value: (*durationValue)(&c.Kafka.ReadTimeout)},
+		{key: "kafka.commitInterval", env: "KAFKA_COMMIT_INTERVAL", def: "1s", value: (*durationValue)(&c.Kafka.CommitInterval)},
+		{key: "kafka.maxRetries", env: "KAFKA_MAX_RETRIES", aliases: []string{"WORKER_MAX_RETRIES"}, def: "5", value: (*intValue)(&c.Kafka.MaxRetries)},
+		{key: "kafka.retryBackoff", env: "KAFKA_RETRY_BACKOFF", aliases: []string{"WORKER_RETRY_BACKOFF"}, def: "200ms", value: (*durationValue)(&c.Kafka.RetryBackoff)},
+		{key: "kafka.retryMaxBackoff", env: "KAFKA_RETRY_MAX_BACKOFF", def: "10s", value: (*durationValue)(&c.Kafka.RetryMaxBackoff)},
+		{key: "kafka.retryTiers", env: "KAFKA_RETRY_TIERS", def: "1s,30s,5m", value: (*durationsValue)(&c.Kafka.RetryTiers)},
+		{key: "kafka.workers", env: "KAFKA_WORKERS", def: "8", value: (*intValue)(&c.Kafka.Workers)},
+		{key: "kafka.workerQueueSize", env: "KAFKA_WORKER_QUEUE_SIZE", def: "64", value: (*intValue)(&c.Kafka.WorkerQueueSize)},
+		{key: "kafka.batchTimeout", env: "KAFKA_BATCH_TIMEOUT", def: "10ms", value: (*durationValue)(&c.Kafka.BatchTimeout)},
+		{key: "kafka.writeTimeout", env: "KAFKA_WRITE_TIMEOUT", def: "10s", value: (*durationValue)(&c.Kafka.WriteTimeout)},
+		{key: "kafka.encoding", env: "KAFKA_ENCODING", def: "json", value: (*stringValue)(&c.Kafka.Encoding)},
+
+		{key: "worker.idempotencyTtl", env: "WORKER_IDEMPOTENCY_TTL", def: "168h", value: (*durationValue)(&c.Worker.IdempotencyTTL)},
+		{key: "worker.actionLogMaxLen", env: "WORKER_ACTION_LOG_MAX_LEN", def: "10000", value: (*intValue)(&c.Worker.ActionLogMaxLen)},
+
+		{key: "relay.workerId", env: "RELAY_WORKER_ID", value: (*stringValue)(&c.Relay.WorkerID)},
+		{key: "relay.batchSize", env: "RELAY_BATCH_SIZE", def: "100", value: (*intValue)(&c.Relay.BatchSize)},
+		{key: "relay.lockTtl", env: "RELAY_LOCK_TTL", def: "30s", value: (*durationValue)(&c.Relay.LockTTL)},
+		{key: "relay.pollInterval", env: "RELAY_POLL_INTERVAL", def: "500ms", value: (*durationValue)(&c.Relay.PollInterval)},
+		{key: "relay.baseBackoff", env: "RELAY_BASE_BACKOFF", def: "1s", value: (*durationValue)(&c.Relay.BaseBackoff)},
+		{key: "relay.listen", env: "RELAY_LISTEN", def: "true", value: (*boolValue)(&c.Relay.Listen)},
+		{key: "relay.listenMinReconnect", env: "RELAY_LISTEN_MIN_RECONNECT", def: "1s", value: (*durationValue)(&c.Relay.ListenMinReconnect)},
+		{key: "relay.listenMaxReconnect", env: "RELAY_LISTEN_MAX_RECONNECT", def: "30s", value: (*durationValue)(&c.Relay.ListenMaxReconnect)},
+
+		{key: "janitor.interval", env: "JANITOR_INTERVAL", def: "0s", value: (*durationValue)(&c.Janitor.Interval)},
+		{key: "janitor.mode", env: "JANITOR_MODE", def: "delete", value: (*stringValue)(&c.Janitor.Mode)},
+		{key: "janitor.retention", env: "JANITOR_RETENTION", def: "168h", value: (*durationValue)(&c.Janitor.Retention)},
+		{key: "janitor.batchSize", env: "JANITOR_BATCH_SIZE", def: "1000", value: (*intValue)(&c.Janitor.BatchSize)},
+		{key: "janitor.maxBatches", env: "JANITOR_MAX_BATCHES", def: "100", value: (*intValue)(&c.Janitor.MaxBatches)},
+		{key: "janitor.archiveRetention", env: "JANITOR_ARCHIVE_RETENTION", def: "0s", value: (*durationValue)(&c.Janitor.ArchiveRetention)},
+
+		{key: "ticker.interval", env: "TICKER_INTERVAL", def: "50ms", value: (*durationValue)(&c.Ticker.Interval)},
+
+		{key: "metrics.addr", env: "METRICS_ADDR", value: (*stringValue)(&c.Metrics.Addr)},
+		{key: "admin.apiToken", env: "ADMIN_API_TOKEN", secret: true, value: (*stringValue)(&c.Admin.APIToken)},
+		{key: "session.secret", env: "SESSION_SECRET", secret: true, value: (*stringValue)(&c.Session.Secret)},
+		{key: "session.ttl", env: "SESSION_TTL", def: "24h", value: (*durationValue)(&c.Session.TTL)},
+	}
+}
+
+// ----------------------------
+// values
+// ----------------------------
+
+type stringValue string
+
+func (v *stringValue) Set(s string) error { *v = stringValue(strings.TrimSpace(s)); return nil }
+func (v *stringValue) String() string     { return string(*v) }
+
+type intValue int
+
+func (v *intValue) Set(s string) error {
+	n, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil {
+		return fmt.Errorf("invalid integer %q", s)
+	}
+	*v = intValue(n)
+	return nil
+}
+func (v *intValue) String() string { return strconv.Itoa(int(*v)) }
+
+type boolValue bool
+
+func (v *boolValue) Set(s string) error {
+	b, err := strconv.ParseBool(strings.TrimSpace(s))
+	if err != nil {
+		return fmt.Errorf("invalid boolean %q", s)
+	}
+	*v = boolValue(b)
+	return nil
+}
+func (v *boolValue) String() string { return strconv.FormatBool(bool(*v)) }
+
+type durationValue time.Duration
+
+func (v *durationValue) Set(s string) error {
+	d, err := time.ParseDuration(strings.TrimSpace(s))
+	if err != nil {
+		return fmt.Errorf("invalid duration %q", s)
+	}
+	*v = durationValue(d)
+	return nil
+}
+func (v *durationValue) String() string { return time.Duration(*v).String() }
+
+type csvValue []string
+
+func (v *csvValue) Set(s string) error {
+	var out []string
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	*v = out
+	return nil
+}
+func (v *csvValue) String() string { return strings.Join(*v, ",") }
+
+// durationsValue: CSV de durations; "off" desabilita (lista vazia).
+type durationsValue []time.Duration
+
+func (v *durationsValue) Set(s string) error {
+	s = strings.TrimSpace(s)
+	if s == "" || s == "off" {
+		*v = nil
+		return nil
+	}
+	var out []time.Duration
+	for _, p := range strings.Split(s, ",") {
+		d, err := time.ParseDuration(strings.TrimSpace(p))
+		if err != nil || d <= 0 {
+			return fmt.Errorf("invalid duration %q", p)
+		}
+		out = append(out, d)
+	}
+	*v = out
+	return nil
+}
+
+func (v *durationsValue) String() string {
+	if len(*v) == 0 {
+		return "off"
+	}
+	parts := make([]string, len(*v))
+	for i, d := range *v {
+		parts[i] = d.String()
+	}
+	return strings.Join(parts, ",")
+}
+
+// flagValue guarda o texto da flag; é aplicado por último (maior precedência).
+type flagValue struct {
+	raw string
+	set bool
+}
+
+func (f *flagValue) Set(s string) error { f.raw, f.set = s, true; return nil }
+func (f *flagValue) String() string     { return f.raw }
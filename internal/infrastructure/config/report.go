@@ -0,0 +1,49 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"text/tabwriter"
+)
+
+const redacted = "xxxxx"
+
+// Report escreve a config efetiva (chave, valor, origem) com segredos mascarados,
+// seguida dos avisos de env legado.
+func (c Config) Report(w io.Writer) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, f := range c.fields() {
+		v := f.value.String()
+		if f.secret {
+			v = redact(v)
+		}
+		if v == "" {
+			v = "-"
+		}
+		fmt.Fprintf(tw, "  %s\t%s\t(%s)\n", f.key, v, c.sources[f.key])
+	}
+	_ = tw.Flush()
+
+	for _, n := range c.notes {
+		fmt.Fprintf(w, "  warning: %s\n", n)
+	}
+}
+
+// redact mantém host/db de URLs (útil para diagnóstico) e esconde credenciais.
+func redact(v string) string {
+	if v == "" {
+		return ""
+	}
+	if u, err := url.Parse(v); err == nil && u.Scheme != "" && u.Host != "" {
+		q := u.Query()
+		for _, k := range []string{"password", "sslpassword"} {
+			if q.Has(k) {
+				q.Set(k, redacted)
+			}
+		}
+		u.RawQuery = q.Encode()
+		return u.Redacted()
+	}
+	return redacted
+}
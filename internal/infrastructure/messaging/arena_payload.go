@@ -0,0 +1,333 @@
+package messaging
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/petri-board-arena/internal/domain/arena"
+)
+
+// ----------------------------
+// Wire payloads (EventEnvelope.Payload) dos eventos de arena
+//
+// A tag proto é o número do campo na codificação protobuf (ProtobufEnvelopeCodec):
+// nunca renumere nem reaproveite números; campos novos pegam o próximo livre.
+// ----------------------------
+
+type TemperaturePayload struct {
+	Value float64 `json:"value" proto:"1"`
+	Unit  string  `json:"unit" proto:"2"`
+}
+
+type ConfigPayload struct {
+	TickMillis         int                `json:"tickMillis" proto:"1"`
+	Width              int                `json:"width" proto:"2"`
+	Height             int                `json:"height" proto:"3"`
+	DiffusionRate      float64            `json:"diffusionRate" proto:"4"`
+	MutationRate       float64            `json:"mutationRate" proto:"5"`
+	MaxOrganisms       int                `json:"maxOrganisms" proto:"6"`
+	SnapshotEveryTicks int                `json:"snapshotEveryTicks" proto:"7"`
+	Temperature        TemperaturePayload `json:"temperature" proto:"8"`
+	MaxTicks           int64              `json:"maxTicks" proto:"9"`
+	MaxPlayers         int                `json:"maxPlayers" proto:"10"`
+	MaxActionsPerTick  int                `json:"maxActionsPerTick" proto:"11"`
+}
+
+type AreaPayload struct {
+	X      int `json:"x" proto:"1"`
+	Y      int `json:"y" proto:"2"`
+	Width  int `json:"width" proto:"3"`
+	Height int `json:"height" proto:"4"`
+}
+
+type PointPayload struct {
+	X int `json:"x" proto:"1"`
+	Y int `json:"y" proto:"2"`
+}
+
+// ActionPayloadData achata a union de payloads; o type da ação diz quais campos valem.
+type ActionPayloadData struct {
+	Area             *AreaPayload        `json:"area,omitempty" proto:"1"`
+	Amount           int                 `json:"amount,omitempty" proto:"2"`
+	Kind             string              `json:"kind,omitempty" proto:"3"`
+	Concentration    float64             `json:"concentration,omitempty" proto:"4"`
+	Temperature      *TemperaturePayload `json:"temperature,omitempty" proto:"5"`
+	Position         *PointPayload       `json:"position,omitempty" proto:"6"`
+	GenomeTemplateID *string             `json:"genomeTemplateId,omitempty" proto:"7"`
+}
+
+type ActionPayload struct {
+	ID          string            `json:"id" proto:"1"`
+	Type        string            `json:"type" proto:"2"`
+	PlayerID    string            `json:"playerId" proto:"3"`
+	SubmittedAt time.Time         `json:"submittedAt" proto:"4"`
+	ApplyAtTick int64             `json:"applyAtTick" proto:"5"`
+	Payload     ActionPayloadData `json:"payload" proto:"6"`
+}
+
+type ArenaCreatedPayload struct {
+	Name   string        `json:"name" proto:"1"`
+	Config ConfigPayload `json:"config" proto:"2"`
+}
+
+type ArenaFinishedPayload struct {
+	Tick int64 `json:"tick" proto:"1"`
+}
+
+type PlayerJoinedPayload struct {
+	PlayerID    string `json:"playerId" proto:"1"`
+	DisplayName string `json:"displayName" proto:"2"`
+	Role        string `json:"role" proto:"3"`
+}
+
+type PlayerLeftPayload struct {
+	PlayerID        string  `json:"playerId" proto:"1"`
+	PromotedAdminID *string `json:"promotedAdminId,omitempty" proto:"2"`
+}
+
+type ArenaConfigUpdatedPayload struct {
+	Config ConfigPayload `json:"config" proto:"1"`
+}
+
+type ActionSubmittedPayload struct {
+	Action ActionPayload `json:"action" proto:"1"`
+}
+
+type TickAdvancedPayload struct {
+	Tick int64 `json:"tick" proto:"1"`
+}
+
+// EncodeArenaEvent serializa o evento de domínio no payload do envelope, sempre na
+// versão atual do evento em ArenaCodecs (ArenaPayloadVersion).
+func EncodeArenaEvent(ev arena.Event) (json.RawMessage, error) {
+	var v any
+	switch e := ev.(type) {
+	case arena.ArenaCreated:
+		v = ArenaCreatedPayload{Name: e.Name, Config: ConfigToPayload(e.Config)}
+	case arena.ArenaStarted, arena.ArenaPaused, arena.ArenaResumed, arena.ArenaStopped:
+		v = EmptyPayload{}
+	case arena.ArenaFinished:
+		v = ArenaFinishedPayload{Tick: e.Tick}
+	case arena.PlayerJoined:
+		v = PlayerJoinedPayload{
+			PlayerID:    uuid.UUID(e.PlayerID).String(),
+			DisplayName: e.DisplayName,
+			Role:        string(e.Role),
+		}
+	case arena.PlayerLeft:
+		pl := PlayerLeftPayload{PlayerID: uuid.UUID(e.PlayerID).String()}
+		if e.PromotedAdminID != nil {
+			s := uuid.UUID(*e.PromotedAdminID).String()
+			pl.PromotedAdminID = &s
+		}
+		v = pl
+	case arena.ArenaConfigUpdated:
+		v = ArenaConfigUpdatedPayload{Config: ConfigToPayload(e.Config)}
+	case arena.ActionSubmitted:
+		v = ActionSubmittedPayload{Action: ActionToPayload(e.Action)}
+	case arena.TickAdvanced:
+		v = TickAdvancedPayload{Tick: e.Tick}
+	default:
+		return nil, fmt.Errorf("encode arena event: unsupported event %s", ev.EventName())
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("encode arena event %s: %w", ev.EventName(), err)
+	}
+	return b, nil
+}
+
+func ConfigToPayload(c arena.Config) ConfigPayload {
+	return ConfigPayload{
+		TickMillis:         c.TickMillis,
+		Width:              c.Width,
+		Height:             c.Height,
+		DiffusionRate:      c.DiffusionRate,
+		MutationRate:       c.MutationRate,
+		MaxOrganisms:       c.MaxOrganisms,
+		SnapshotEveryTicks: c.SnapshotEveryTicks,
+		Temperature:        TemperaturePayload{Value: c.Temperature.Value, Unit: string(c.Temperature.Unit)},
+		MaxTicks:           c.MaxTicks,
+		MaxPlayers:         c.MaxPlayers,
+		MaxActionsPerTick:  c.MaxActionsPerTick,
+	}
+}
+
+// PayloadToConfig é o inverso de ConfigToPayload (usado pelo read model).
+func PayloadToConfig(p ConfigPayload) arena.Config {
+	return arena.Config{
+		TickMillis:         p.TickMillis,
+		Width:              p.Width,
+		Height:             p.Height,
+		DiffusionRate:      p.DiffusionRate,
+		MutationRate:       p.MutationRate,
+		MaxOrganisms:       p.MaxOrganisms,
+		SnapshotEveryTicks: p.SnapshotEveryTicks,
+		Temperature:        arena.Temperature{Value: p.Temperature.Value, Unit: arena.TemperatureUnit(p.Temperature.Unit)},
+		MaxTicks:           p.MaxTicks,
+		MaxPlayers:         p.MaxPlayers,
+		MaxActionsPerTick:  p.MaxActionsPerTick,
+	}
+}
+
+func ActionToPayload(a arena.PlayerAction) ActionPayload {
+	out := ActionPayload{
+		ID:          uuid.UUID(a.ID).String(),
+		Type:        string(a.Type),
+		PlayerID:    uuid.UUID(a.PlayerID).String(),
+		SubmittedAt: a.SubmittedAt.UTC(),
+		ApplyAtTick: a.ApplyAtTick,
+	}
+
+	switch p := a.Payload.(type) {
+	case arena.AddNutrientsPayload:
+		out.Payload.Area = areaToPayload(p.Area)
+		out.Payload.Amount = p.Amount
+	case arena.DropAntibioticPayload:
+		out.Payload.Area = areaToPayload(p.Area)
+		out.Payload.Kind = string(p.Kind)
+		out.Payload.Concentration = p.Concentration
+	case arena.SetTemperaturePayload:
+		out.Payload.Temperature = &TemperaturePayload{Value: p.Temperature.Value, Unit: string(p.Temperature.Unit)}
+	case arena.SpawnOrganismPayload:
+		out.Payload.Kind = string(p.Kind)
+		out.Payload.Position = &PointPayload{X: p.Position.X, Y: p.Position.Y}
+		out.Payload.GenomeTemplateID = p.GenomeTemplateID
+	}
+	return out
+}
+
+func areaToPayload(a arena.Area) *AreaPayload {
+	return &AreaPayload{X: a.X, Y: a.Y, Width: a.Width, Height: a.Height}
+}
+
+// DecodeArenaEvent é o inverso de EncodeArenaEvent: passa o payload pelos upcasters
+// de ArenaCodecs (version é a versão gravada com ele) e reconstrói o evento de domínio.
+func DecodeArenaEvent(name string, version int, arenaID arena.ID, at time.Time, payload []byte) (arena.Event, error) {
+	v, err := ArenaCodecs().Decode(name, version, payload)
+	if err != nil {
+		return nil, fmt.Errorf("decode arena event: %w", err)
+	}
+	base := arena.NewBaseEvent(arenaID, at)
+
+	switch pl := v.(type) {
+	case *ArenaCreatedPayload:
+		return arena.ArenaCreated{BaseEvent: base, Name: pl.Name, Config: PayloadToConfig(pl.Config)}, nil
+	case *ArenaFinishedPayload:
+		return arena.ArenaFinished{BaseEvent: base, Tick: pl.Tick}, nil
+	case *PlayerJoinedPayload:
+		pid, err := uuid.Parse(pl.PlayerID)
+		if err != nil {
+			return nil, fmt.Errorf("decode arena event %s: playerId: %w", name, err)
+		}
+		return arena.PlayerJoined{
+			BaseEvent:   base,
+			PlayerID:    arena.PlayerID(pid),
+			DisplayName: pl.DisplayName,
+			Role:        arena.PlayerRole(pl.Role),
+		}, nil
+	case *PlayerLeftPayload:
+		pid, err := uuid.Parse(pl.PlayerID)
+		if err != nil {
+			return nil, fmt.Errorf("decode arena event %s: playerId: %w", name, err)
+		}
+		ev := arena.PlayerLeft{BaseEvent: base, PlayerID: arena.PlayerID(pid)}
+		if pl.PromotedAdminID != nil {
+			promoted, err := uuid.Parse(*pl.PromotedAdminID)
+			if err != nil {
+				return nil, fmt.Errorf("decode arena event %s: promotedAdminId: %w", name, err)
+			}
+			id := arena.PlayerID(promoted)
+			ev.PromotedAdminID = &id
+		}
+		return ev, nil
+	case *ArenaConfigUpdatedPayload:
+		return arena.ArenaConfigUpdated{BaseEvent: base, Config: PayloadToConfig(pl.Config)}, nil
+	case *ActionSubmittedPayload:
+		action, err := PayloadToAction(pl.Action)
+		if err != nil {
+			return nil, fmt.Errorf("decode arena event %s: %w", name, err)
+		}
+		return arena.ActionSubmitted{BaseEvent: base, Action: action}, nil
+	case *TickAdvancedPayload:
+		return arena.TickAdvanced{BaseEvent: base, Tick: pl.Tick}, nil
+	case *EmptyPayload:
+		switch name {
+		case "ArenaStarted":
+			return arena.ArenaStarted{BaseEvent: base}, nil
+		case "ArenaPaused":
+			return arena.ArenaPaused{BaseEvent: base}, nil
+		case "ArenaResumed":
+			return arena.ArenaResumed{BaseEvent: base}, nil
+		case "ArenaStopped":
+			return arena.ArenaStopped{BaseEvent: base}, nil
+		}
+	}
+	return nil, fmt.Errorf("decode arena event: unsupported event %s", name)
+}
+
+// PayloadToAction é o inverso de ActionToPayload.
+func PayloadToAction(p ActionPayload) (arena.PlayerAction, error) {
+	id, err := uuid.Parse(p.ID)
+	if err != nil {
+		return arena.PlayerAction{}, fmt.Errorf("action id: %w", err)
+	}
+	pid, err := uuid.Parse(p.PlayerID)
+	if err != nil {
+		return arena.PlayerAction{}, fmt.Errorf("action playerId: %w", err)
+	}
+
+	out := arena.PlayerAction{
+		ID:          arena.ActionID(id),
+		Type:        arena.ActionType(p.Type),
+		PlayerID:    arena.PlayerID(pid),
+		SubmittedAt: p.SubmittedAt.UTC(),
+		ApplyAtTick: p.ApplyAtTick,
+	}
+
+	d := p.Payload
+	switch out.Type {
+	case arena.ActionAddNutrients:
+		out.Payload = arena.AddNutrientsPayload{Area: payloadToArea(d.Area), Amount: d.Amount}
+	case arena.ActionDropAntibiotic:
+		out.Payload = arena.DropAntibioticPayload{
+			Area:          payloadToArea(d.Area),
+			Kind:          arena.AntibioticKind(d.Kind),
+			Concentration: d.Concentration,
+		}
+	case arena.ActionSetTemperature:
+		if d.Temperature == nil {
+			return arena.PlayerAction{}, fmt.Errorf("action %s: missing temperature", out.Type)
+		}
+		out.Payload = arena.SetTemperaturePayload{Temperature: arena.Temperature{
+			Value: d.Temperature.Value,
+			Unit:  arena.TemperatureUnit(d.Temperature.Unit),
+		}}
+	case arena.ActionSpawnOrganism:
+		sp := arena.SpawnOrganismPayload{Kind: arena.OrganismKind(d.Kind), GenomeTemplateID: d.GenomeTemplateID}
+		if d.Position != nil {
+			sp.Position = arena.Point{X: d.Position.X, Y: d.Position.Y}
+		}
+		out.Payload = sp
+	default:
+		return arena.PlayerAction{}, fmt.Errorf("unknown action type %q", p.Type)
+	}
+	return out, nil
+}
+
+func payloadToArea(a *AreaPayload) arena.Area {
+	if a == nil {
+		return arena.Area{}
+	}
+	return arena.Area{X: a.X, Y: a.Y, Width: a.Width, Height: a.Height}
+}
+
+// ArenaPayloadVersion é a versão que EncodeArenaEvent produz para o evento.
+func ArenaPayloadVersion(name string) int {
+	v, _ := ArenaCodecs().CurrentVersion(name)
+	return normalizeVersion(v)
+}
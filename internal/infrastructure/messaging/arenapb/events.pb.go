@@ -0,0 +1,1257 @@
+// Eventos de arena no Kafka com kafka.encoding=protobuf (ProtobufEnvelopeCodec).
+//
+// Os números de campo são o contrato do fio: nunca renumere nem reaproveite um número;
+// campo removido vira `reserved`. Cada mensagem de payload corresponde a um
+// (evento, versão) de messaging.ArenaCodecs e os nomes JSON dos campos são os do
+// payload JSON (a correspondência é conferida no registro do codec). Uma versão nova
+// de um evento ganha uma mensagem nova (ex.: ArenaCreatedV2).
+//
+// Gerado com `make proto` (protoc-gen-go) em internal/infrastructure/messaging/arenapb.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: arena/v1/events.proto
+
+package arenapb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// EventEnvelope é o valor da mensagem Kafka. payload é a mensagem do (event_type,
+// version); eventos que o produtor não conhece seguem como JSON em json_payload.
+type EventEnvelope struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	EventType     string                 `protobuf:"bytes,2,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	AggregateId   string                 `protobuf:"bytes,3,opt,name=aggregate_id,json=aggregateId,proto3" json:"aggregate_id,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	Version       int64                  `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	Payload       []byte                 `protobuf:"bytes,6,opt,name=payload,proto3" json:"payload,omitempty"`
+	JsonPayload   []byte                 `protobuf:"bytes,7,opt,name=json_payload,json=jsonPayload,proto3" json:"json_payload,omitempty"`
+	Sequence      int64                  `protobuf:"varint,8,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventEnvelope) Reset() {
+	*x = EventEnvelope{}
+	mi := &file_arena_v1_events_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventEnvelope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventEnvelope) ProtoMessage() {}
+
+func (x *EventEnvelope) ProtoReflect() protoreflect.Message {
+	mi := &file_arena_v1_events_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventEnvelope.ProtoReflect.Descriptor instead.
+func (*EventEnvelope) Descriptor() ([]byte, []int) {
+	return file_arena_v1_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EventEnvelope) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *EventEnvelope) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *EventEnvelope) GetAggregateId() string {
+	if x != nil {
+		return x.AggregateId
+	}
+	return ""
+}
+
+func (x *EventEnvelope) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *EventEnvelope) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *EventEnvelope) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *EventEnvelope) GetJsonPayload() []byte {
+	if x != nil {
+		return x.JsonPayload
+	}
+	return nil
+}
+
+func (x *EventEnvelope) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+type Temperature struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         float64                `protobuf:"fixed64,1,opt,name=value,proto3" json:"value,omitempty"`
+	Unit          string                 `protobuf:"bytes,2,opt,name=unit,proto3" json:"unit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Temperature) Reset() {
+	*x = Temperature{}
+	mi := &file_arena_v1_events_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Temperature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Temperature) ProtoMessage() {}
+
+func (x *Temperature) ProtoReflect() protoreflect.Message {
+	mi := &file_arena_v1_events_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Temperature.ProtoReflect.Descriptor instead.
+func (*Temperature) Descriptor() ([]byte, []int) {
+	return file_arena_v1_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Temperature) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Temperature) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+type Config struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	TickMillis         int64                  `protobuf:"varint,1,opt,name=tick_millis,json=tickMillis,proto3" json:"tick_millis,omitempty"`
+	Width              int64                  `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`
+	Height             int64                  `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	DiffusionRate      float64                `protobuf:"fixed64,4,opt,name=diffusion_rate,json=diffusionRate,proto3" json:"diffusion_rate,omitempty"`
+	MutationRate       float64                `protobuf:"fixed64,5,opt,name=mutation_rate,json=mutationRate,proto3" json:"mutation_rate,omitempty"`
+	MaxOrganisms       int64                  `protobuf:"varint,6,opt,name=max_organisms,json=maxOrganisms,proto3" json:"max_organisms,omitempty"`
+	SnapshotEveryTicks int64                  `protobuf:"varint,7,opt,name=snapshot_every_ticks,json=snapshotEveryTicks,proto3" json:"snapshot_every_ticks,omitempty"`
+	Temperature        *Temperature           `protobuf:"bytes,8,opt,name=temperature,proto3" json:"temperature,omitempty"`
+	MaxTicks           int64                  `protobuf:"varint,9,opt,name=max_ticks,json=maxTicks,proto3" json:"max_ticks,omitempty"`
+	MaxPlayers         int64                  `protobuf:"varint,10,opt,name=max_players,json=maxPlayers,proto3" json:"max_players,omitempty"`
+	MaxActionsPerTick  int64                  `protobuf:"varint,11,opt,name=max_actions_per_tick,json=maxActionsPerTick,proto3" json:"max_actions_per_tick,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Config) Reset() {
+	*x = Config{}
+	mi := &file_arena_v1_events_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Config) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Config) ProtoMessage() {}
+
+func (x *Config) ProtoReflect() protoreflect.Message {
+	mi := &file_arena_v1_events_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Config.ProtoReflect.Descriptor instead.
+func (*Config) Descriptor() ([]byte, []int) {
+	return file_arena_v1_events_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Config) GetTickMillis() int64 {
+	if x != nil {
+		return x.TickMillis
+	}
+	return 0
+}
+
+func (x *Config) GetWidth() int64 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Config) GetHeight() int64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Config) GetDiffusionRate() float64 {
+	if x != nil {
+		return x.DiffusionRate
+	}
+	return 0
+}
+
+func (x *Config) GetMutationRate() float64 {
+	if x != nil {
+		return x.MutationRate
+	}
+	return 0
+}
+
+func (x *Config) GetMaxOrganisms() int64 {
+	if x != nil {
+		return x.MaxOrganisms
+	}
+	return 0
+}
+
+func (x *Config) GetSnapshotEveryTicks() int64 {
+	if x != nil {
+		return x.SnapshotEveryTicks
+	}
+	return 0
+}
+
+func (x *Config) GetTemperature() *Temperature {
+	if x != nil {
+		return x.Temperature
+	}
+	return nil
+}
+
+func (x *Config) GetMaxTicks() int64 {
+	if x != nil {
+		return x.MaxTicks
+	}
+	return 0
+}
+
+func (x *Config) GetMaxPlayers() int64 {
+	if x != nil {
+		return x.MaxPlayers
+	}
+	return 0
+}
+
+func (x *Config) GetMaxActionsPerTick() int64 {
+	if x != nil {
+		return x.MaxActionsPerTick
+	}
+	return 0
+}
+
+type Area struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	X             int64                  `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y             int64                  `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
+	Width         int64                  `protobuf:"varint,3,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int64                  `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Area) Reset() {
+	*x = Area{}
+	mi := &file_arena_v1_events_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Area) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Area) ProtoMessage() {}
+
+func (x *Area) ProtoReflect() protoreflect.Message {
+	mi := &file_arena_v1_events_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Area.ProtoReflect.Descriptor instead.
+func (*Area) Descriptor() ([]byte, []int) {
+	return file_arena_v1_events_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Area) GetX() int64 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Area) GetY() int64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *Area) GetWidth() int64 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Area) GetHeight() int64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+type Point struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	X             int64                  `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y             int64                  `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Point) Reset() {
+	*x = Point{}
+	mi := &file_arena_v1_events_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Point) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Point) ProtoMessage() {}
+
+func (x *Point) ProtoReflect() protoreflect.Message {
+	mi := &file_arena_v1_events_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Point.ProtoReflect.Descriptor instead.
+func (*Point) Descriptor() ([]byte, []int) {
+	return file_arena_v1_events_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Point) GetX() int64 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Point) GetY() int64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+// ActionData achata a union de payloads; o type da ação diz quais campos valem.
+type ActionData struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Area             *Area                  `protobuf:"bytes,1,opt,name=area,proto3,oneof" json:"area,omitempty"`
+	Amount           int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Kind             string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	Concentration    float64                `protobuf:"fixed64,4,opt,name=concentration,proto3" json:"concentration,omitempty"`
+	Temperature      *Temperature           `protobuf:"bytes,5,opt,name=temperature,proto3,oneof" json:"temperature,omitempty"`
+	Position         *Point                 `protobuf:"bytes,6,opt,name=position,proto3,oneof" json:"position,omitempty"`
+	GenomeTemplateId *string                `protobuf:"bytes,7,opt,name=genome_template_id,json=genomeTemplateId,proto3,oneof" json:"genome_template_id,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ActionData) Reset() {
+	*x = ActionData{}
+	mi := &file_arena_v1_events_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActionData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionData) ProtoMessage() {}
+
+func (x *ActionData) ProtoReflect() protoreflect.Message {
+	mi := &file_arena_v1_events_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionData.ProtoReflect.Descriptor instead.
+func (*ActionData) Descriptor() ([]byte, []int) {
+	return file_arena_v1_events_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ActionData) GetArea() *Area {
+	if x != nil {
+		return x.Area
+	}
+	return nil
+}
+
+func (x *ActionData) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *ActionData) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ActionData) GetConcentration() float64 {
+	if x != nil {
+		return x.Concentration
+	}
+	return 0
+}
+
+func (x *ActionData) GetTemperature() *Temperature {
+	if x != nil {
+		return x.Temperature
+	}
+	return nil
+}
+
+func (x *ActionData) GetPosition() *Point {
+	if x != nil {
+		return x.Position
+	}
+	return nil
+}
+
+func (x *ActionData) GetGenomeTemplateId() string {
+	if x != nil && x.GenomeTemplateId != nil {
+		return *x.GenomeTemplateId
+	}
+	return ""
+}
+
+type Action struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	PlayerId      string                 `protobuf:"bytes,3,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	SubmittedAt   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=submitted_at,json=submittedAt,proto3" json:"submitted_at,omitempty"`
+	ApplyAtTick   int64                  `protobuf:"varint,5,opt,name=apply_at_tick,json=applyAtTick,proto3" json:"apply_at_tick,omitempty"`
+	Payload       *ActionData            `protobuf:"bytes,6,opt,name=payload,proto3" json:"payload,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Action) Reset() {
+	*x = Action{}
+	mi := &file_arena_v1_events_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Action) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Action) ProtoMessage() {}
+
+func (x *Action) ProtoReflect() protoreflect.Message {
+	mi := &file_arena_v1_events_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Action.ProtoReflect.Descriptor instead.
+func (*Action) Descriptor() ([]byte, []int) {
+	return file_arena_v1_events_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Action) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Action) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Action) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *Action) GetSubmittedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SubmittedAt
+	}
+	return nil
+}
+
+func (x *Action) GetApplyAtTick() int64 {
+	if x != nil {
+		return x.ApplyAtTick
+	}
+	return 0
+}
+
+func (x *Action) GetPayload() *ActionData {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+type ArenaCreated struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Config        *Config                `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArenaCreated) Reset() {
+	*x = ArenaCreated{}
+	mi := &file_arena_v1_events_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArenaCreated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArenaCreated) ProtoMessage() {}
+
+func (x *ArenaCreated) ProtoReflect() protoreflect.Message {
+	mi := &file_arena_v1_events_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArenaCreated.ProtoReflect.Descriptor instead.
+func (*ArenaCreated) Descriptor() ([]byte, []int) {
+	return file_arena_v1_events_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ArenaCreated) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ArenaCreated) GetConfig() *Config {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+type ArenaStarted struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArenaStarted) Reset() {
+	*x = ArenaStarted{}
+	mi := &file_arena_v1_events_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArenaStarted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArenaStarted) ProtoMessage() {}
+
+func (x *ArenaStarted) ProtoReflect() protoreflect.Message {
+	mi := &file_arena_v1_events_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArenaStarted.ProtoReflect.Descriptor instead.
+func (*ArenaStarted) Descriptor() ([]byte, []int) {
+	return file_arena_v1_events_proto_rawDescGZIP(), []int{8}
+}
+
+type ArenaPaused struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArenaPaused) Reset() {
+	*x = ArenaPaused{}
+	mi := &file_arena_v1_events_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArenaPaused) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArenaPaused) ProtoMessage() {}
+
+func (x *ArenaPaused) ProtoReflect() protoreflect.Message {
+	mi := &file_arena_v1_events_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArenaPaused.ProtoReflect.Descriptor instead.
+func (*ArenaPaused) Descriptor() ([]byte, []int) {
+	return file_arena_v1_events_proto_rawDescGZIP(), []int{9}
+}
+
+type ArenaResumed struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArenaResumed) Reset() {
+	*x = ArenaResumed{}
+	mi := &file_arena_v1_events_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArenaResumed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArenaResumed) ProtoMessage() {}
+
+func (x *ArenaResumed) ProtoReflect() protoreflect.Message {
+	mi := &file_arena_v1_events_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArenaResumed.ProtoReflect.Descriptor instead.
+func (*ArenaResumed) Descriptor() ([]byte, []int) {
+	return file_arena_v1_events_proto_rawDescGZIP(), []int{10}
+}
+
+type ArenaStopped struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArenaStopped) Reset() {
+	*x = ArenaStopped{}
+	mi := &file_arena_v1_events_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArenaStopped) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArenaStopped) ProtoMessage() {}
+
+func (x *ArenaStopped) ProtoReflect() protoreflect.Message {
+	mi := &file_arena_v1_events_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArenaStopped.ProtoReflect.Descriptor instead.
+func (*ArenaStopped) Descriptor() ([]byte, []int) {
+	return file_arena_v1_events_proto_rawDescGZIP(), []int{11}
+}
+
+type ArenaFinished struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tick          int64                  `protobuf:"varint,1,opt,name=tick,proto3" json:"tick,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArenaFinished) Reset() {
+	*x = ArenaFinished{}
+	mi := &file_arena_v1_events_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArenaFinished) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArenaFinished) ProtoMessage() {}
+
+func (x *ArenaFinished) ProtoReflect() protoreflect.Message {
+	mi := &file_arena_v1_events_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArenaFinished.ProtoReflect.Descriptor instead.
+func (*ArenaFinished) Descriptor() ([]byte, []int) {
+	return file_arena_v1_events_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ArenaFinished) GetTick() int64 {
+	if x != nil {
+		return x.Tick
+	}
+	return 0
+}
+
+type PlayerJoined struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	DisplayName   string                 `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayerJoined) Reset() {
+	*x = PlayerJoined{}
+	mi := &file_arena_v1_events_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerJoined) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerJoined) ProtoMessage() {}
+
+func (x *PlayerJoined) ProtoReflect() protoreflect.Message {
+	mi := &file_arena_v1_events_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerJoined.ProtoReflect.Descriptor instead.
+func (*PlayerJoined) Descriptor() ([]byte, []int) {
+	return file_arena_v1_events_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PlayerJoined) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *PlayerJoined) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *PlayerJoined) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type PlayerLeft struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId        string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	PromotedAdminId *string                `protobuf:"bytes,2,opt,name=promoted_admin_id,json=promotedAdminId,proto3,oneof" json:"promoted_admin_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PlayerLeft) Reset() {
+	*x = PlayerLeft{}
+	mi := &file_arena_v1_events_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerLeft) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerLeft) ProtoMessage() {}
+
+func (x *PlayerLeft) ProtoReflect() protoreflect.Message {
+	mi := &file_arena_v1_events_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerLeft.ProtoReflect.Descriptor instead.
+func (*PlayerLeft) Descriptor() ([]byte, []int) {
+	return file_arena_v1_events_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PlayerLeft) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *PlayerLeft) GetPromotedAdminId() string {
+	if x != nil && x.PromotedAdminId != nil {
+		return *x.PromotedAdminId
+	}
+	return ""
+}
+
+type ArenaConfigUpdated struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Config        *Config                `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArenaConfigUpdated) Reset() {
+	*x = ArenaConfigUpdated{}
+	mi := &file_arena_v1_events_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArenaConfigUpdated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArenaConfigUpdated) ProtoMessage() {}
+
+func (x *ArenaConfigUpdated) ProtoReflect() protoreflect.Message {
+	mi := &file_arena_v1_events_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArenaConfigUpdated.ProtoReflect.Descriptor instead.
+func (*ArenaConfigUpdated) Descriptor() ([]byte, []int) {
+	return file_arena_v1_events_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ArenaConfigUpdated) GetConfig() *Config {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+type ActionSubmitted struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Action        *Action                `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActionSubmitted) Reset() {
+	*x = ActionSubmitted{}
+	mi := &file_arena_v1_events_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActionSubmitted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionSubmitted) ProtoMessage() {}
+
+func (x *ActionSubmitted) ProtoReflect() protoreflect.Message {
+	mi := &file_arena_v1_events_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionSubmitted.ProtoReflect.Descriptor instead.
+func (*ActionSubmitted) Descriptor() ([]byte, []int) {
+	return file_arena_v1_events_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ActionSubmitted) GetAction() *Action {
+	if x != nil {
+		return x.Action
+	}
+	return nil
+}
+
+type TickAdvanced struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tick          int64                  `protobuf:"varint,1,opt,name=tick,proto3" json:"tick,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TickAdvanced) Reset() {
+	*x = TickAdvanced{}
+	mi := &file_arena_v1_events_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TickAdvanced) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TickAdvanced) ProtoMessage() {}
+
+func (x *TickAdvanced) ProtoReflect() protoreflect.Message {
+	mi := &file_arena_v1_events_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TickAdvanced.ProtoReflect.Descriptor instead.
+func (*TickAdvanced) Descriptor() ([]byte, []int) {
+	return file_arena_v1_events_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *TickAdvanced) GetTick() int64 {
+	if x != nil {
+		return x.Tick
+	}
+	return 0
+}
+
+var File_arena_v1_events_proto protoreflect.FileDescriptor
+
+const file_arena_v1_events_proto_rawDesc = "" +
+	"\n" +
+	"\x15arena/v1/events.proto\x12\x0epetri.arena.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x9c\x02\n" +
+	"\rEventEnvelope\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x02 \x01(\tR\teventType\x12!\n" +
+	"\faggregate_id\x18\x03 \x01(\tR\vaggregateId\x12;\n" +
+	"\voccurred_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\x03R\aversion\x12\x18\n" +
+	"\apayload\x18\x06 \x01(\fR\apayload\x12!\n" +
+	"\fjson_payload\x18\a \x01(\fR\vjsonPayload\x12\x1a\n" +
+	"\bsequence\x18\b \x01(\x03R\bsequence\"7\n" +
+	"\vTemperature\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\x01R\x05value\x12\x12\n" +
+	"\x04unit\x18\x02 \x01(\tR\x04unit\"\xa8\x03\n" +
+	"\x06Config\x12\x1f\n" +
+	"\vtick_millis\x18\x01 \x01(\x03R\n" +
+	"tickMillis\x12\x14\n" +
+	"\x05width\x18\x02 \x01(\x03R\x05width\x12\x16\n" +
+	"\x06height\x18\x03 \x01(\x03R\x06height\x12%\n" +
+	"\x0ediffusion_rate\x18\x04 \x01(\x01R\rdiffusionRate\x12#\n" +
+	"\rmutation_rate\x18\x05 \x01(\x01R\fmutationRate\x12#\n" +
+	"\rmax_organisms\x18\x06 \x01(\x03R\fmaxOrganisms\x120\n" +
+	"\x14snapshot_every_ticks\x18\a \x01(\x03R\x12snapshotEveryTicks\x12=\n" +
+	"\vtemperature\x18\b \x01(\v2\x1b.petri.arena.v1.TemperatureR\vtemperature\x12\x1b\n" +
+	"\tmax_ticks\x18\t \x01(\x03R\bmaxTicks\x12\x1f\n" +
+	"\vmax_players\x18\n" +
+	" \x01(\x03R\n" +
+	"maxPlayers\x12/\n" +
+	"\x14max_actions_per_tick\x18\v \x01(\x03R\x11maxActionsPerTick\"P\n" +
+	"\x04Area\x12\f\n" +
+	"\x01x\x18\x01 \x01(\x03R\x01x\x12\f\n" +
+	"\x01y\x18\x02 \x01(\x03R\x01y\x12\x14\n" +
+	"\x05width\x18\x03 \x01(\x03R\x05width\x12\x16\n" +
+	"\x06height\x18\x04 \x01(\x03R\x06height\"#\n" +
+	"\x05Point\x12\f\n" +
+	"\x01x\x18\x01 \x01(\x03R\x01x\x12\f\n" +
+	"\x01y\x18\x02 \x01(\x03R\x01y\"\xf9\x02\n" +
+	"\n" +
+	"ActionData\x12-\n" +
+	"\x04area\x18\x01 \x01(\v2\x14.petri.arena.v1.AreaH\x00R\x04area\x88\x01\x01\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12$\n" +
+	"\rconcentration\x18\x04 \x01(\x01R\rconcentration\x12B\n" +
+	"\vtemperature\x18\x05 \x01(\v2\x1b.petri.arena.v1.TemperatureH\x01R\vtemperature\x88\x01\x01\x126\n" +
+	"\bposition\x18\x06 \x01(\v2\x15.petri.arena.v1.PointH\x02R\bposition\x88\x01\x01\x121\n" +
+	"\x12genome_template_id\x18\a \x01(\tH\x03R\x10genomeTemplateId\x88\x01\x01B\a\n" +
+	"\x05_areaB\x0e\n" +
+	"\f_temperatureB\v\n" +
+	"\t_positionB\x15\n" +
+	"\x13_genome_template_id\"\xe2\x01\n" +
+	"\x06Action\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x1b\n" +
+	"\tplayer_id\x18\x03 \x01(\tR\bplayerId\x12=\n" +
+	"\fsubmitted_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vsubmittedAt\x12\"\n" +
+	"\rapply_at_tick\x18\x05 \x01(\x03R\vapplyAtTick\x124\n" +
+	"\apayload\x18\x06 \x01(\v2\x1a.petri.arena.v1.ActionDataR\apayload\"R\n" +
+	"\fArenaCreated\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12.\n" +
+	"\x06config\x18\x02 \x01(\v2\x16.petri.arena.v1.ConfigR\x06config\"\x0e\n" +
+	"\fArenaStarted\"\r\n" +
+	"\vArenaPaused\"\x0e\n" +
+	"\fArenaResumed\"\x0e\n" +
+	"\fArenaStopped\"#\n" +
+	"\rArenaFinished\x12\x12\n" +
+	"\x04tick\x18\x01 \x01(\x03R\x04tick\"b\n" +
+	"\fPlayerJoined\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12!\n" +
+	"\fdisplay_name\x18\x02 \x01(\tR\vdisplayName\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\"p\n" +
+	"\n" +
+	"PlayerLeft\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12/\n" +
+	"\x11promoted_admin_id\x18\x02 \x01(\tH\x00R\x0fpromotedAdminId\x88\x01\x01B\x14\n" +
+	"\x12_promoted_admin_id\"D\n" +
+	"\x12ArenaConfigUpdated\x12.\n" +
+	"\x06config\x18\x01 \x01(\v2\x16.petri.arena.v1.ConfigR\x06config\"A\n" +
+	"\x0fActionSubmitted\x12.\n" +
+	"\x06action\x18\x01 \x01(\v2\x16.petri.arena.v1.ActionR\x06action\"\"\n" +
+	"\fTickAdvanced\x12\x12\n" +
+	"\x04tick\x18\x01 \x01(\x03R\x04tickBHZFgithub.com/petri-board-arena/internal/infrastructure/messaging/arenapbb\x06proto3"
+
+var (
+	file_arena_v1_events_proto_rawDescOnce sync.Once
+	file_arena_v1_events_proto_rawDescData []byte
+)
+
+func file_arena_v1_events_proto_rawDescGZIP() []byte {
+	file_arena_v1_events_proto_rawDescOnce.Do(func() {
+		file_arena_v1_events_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_arena_v1_events_proto_rawDesc), len(file_arena_v1_events_proto_rawDesc)))
+	})
+	return file_arena_v1_events_proto_rawDescData
+}
+
+var file_arena_v1_events_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_arena_v1_events_proto_goTypes = []any{
+	(*EventEnvelope)(nil),         // 0: petri.arena.v1.EventEnvelope
+	(*Temperature)(nil),           // 1: petri.arena.v1.Temperature
+	(*Config)(nil),                // 2: petri.arena.v1.Config
+	(*Area)(nil),                  // 3: petri.arena.v1.Area
+	(*Point)(nil),                 // 4: petri.arena.v1.Point
+	(*ActionData)(nil),            // 5: petri.arena.v1.ActionData
+	(*Action)(nil),                // 6: petri.arena.v1.Action
+	(*ArenaCreated)(nil),          // 7: petri.arena.v1.ArenaCreated
+	(*ArenaStarted)(nil),          // 8: petri.arena.v1.ArenaStarted
+	(*ArenaPaused)(nil),           // 9: petri.arena.v1.ArenaPaused
+	(*ArenaResumed)(nil),          // 10: petri.arena.v1.ArenaResumed
+	(*ArenaStopped)(nil),          // 11: petri.arena.v1.ArenaStopped
+	(*ArenaFinished)(nil),         // 12: petri.arena.v1.ArenaFinished
+	(*PlayerJoined)(nil),          // 13: petri.arena.v1.PlayerJoined
+	(*PlayerLeft)(nil),            // 14: petri.arena.v1.PlayerLeft
+	(*ArenaConfigUpdated)(nil),    // 15: petri.arena.v1.ArenaConfigUpdated
+	(*ActionSubmitted)(nil),       // 16: petri.arena.v1.ActionSubmitted
+	(*TickAdvanced)(nil),          // 17: petri.arena.v1.TickAdvanced
+	(*timestamppb.Timestamp)(nil), // 18: google.protobuf.Timestamp
+}
+var file_arena_v1_events_proto_depIdxs = []int32{
+	18, // 0: petri.arena.v1.EventEnvelope.occurred_at:type_name -> google.protobuf.Timestamp
+	1,  // 1: petri.arena.v1.Config.temperature:type_name -> petri.arena.v1.Temperature
+	3,  // 2: petri.arena.v1.ActionData.area:type_name -> petri.arena.v1.Area
+	1,  // 3: petri.arena.v1.ActionData.temperature:type_name -> petri.arena.v1.Temperature
+	4,  // 4: petri.arena.v1.ActionData.position:type_name -> petri.arena.v1.Point
+	18, // 5: petri.arena.v1.Action.submitted_at:type_name -> google.protobuf.Timestamp
+	5,  // 6: petri.arena.v1.Action.payload:type_name -> petri.arena.v1.ActionData
+	2,  // 7: petri.arena.v1.ArenaCreated.config:type_name -> petri.arena.v1.Config
+	2,  // 8: petri.arena.v1.ArenaConfigUpdated.config:type_name -> petri.arena.v1.Config
+	6,  // 9: petri.arena.v1.ActionSubmitted.action:type_name -> petri.arena.v1.Action
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_arena_v1_events_proto_init() }
+func file_arena_v1_events_proto_init() {
+	if File_arena_v1_events_proto != nil {
+		return
+	}
+	file_arena_v1_events_proto_msgTypes[5].OneofWrappers = []any{}
+	file_arena_v1_events_proto_msgTypes[14].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_arena_v1_events_proto_rawDesc), len(file_arena_v1_events_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   18,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_arena_v1_events_proto_goTypes,
+		DependencyIndexes: file_arena_v1_events_proto_depIdxs,
+		MessageInfos:      file_arena_v1_events_proto_msgTypes,
+	}.Build()
+	File_arena_v1_events_proto = out.File
+	file_arena_v1_events_proto_goTypes = nil
+	file_arena_v1_events_proto_depIdxs = nil
+}
@@ -0,0 +1,270 @@
+package messaging
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/petri-board-arena/internal/infrastructure/messaging/arenapb"
+)
+
+var (
+	// ErrUnknownEvent: nenhum codec registrado para o EventType.
+	ErrUnknownEvent = errors.New("unknown event type")
+	// ErrUnsupportedVersion: versão mais nova do que a conhecida (produtor à frente do
+	// consumidor) ou sem upcaster até a versão atual.
+	ErrUnsupportedVersion = errors.New("unsupported event version")
+	// ErrInvalidPayload: o payload não bate com o schema/tipo da versão.
+	ErrInvalidPayload = errors.New("invalid event payload")
+)
+
+// Upcaster converte o payload da versão N para a N+1 (JSON → JSON).
+type Upcaster func(payload json.RawMessage) (json.RawMessage, error)
+
+// EventCodec descreve uma versão do payload de um evento.
+type EventCodec struct {
+	Name    string
+	Version int
+	Schema  *JSONSchema
+	typ     reflect.Type
+	proto   protoreflect.MessageType
+}
+
+// New devolve um ponteiro para um valor zerado do tipo Go da versão.
+func (c *EventCodec) New() any { return reflect.New(c.typ).Interface() }
+
+// ProtoMessage é o nome da mensagem de proto/arena/v1 do payload (ex.: petri.arena.v1.ArenaCreated).
+func (c *EventCodec) ProtoMessage() protoreflect.FullName { return c.proto.Descriptor().FullName() }
+
+// ProtoDescriptor descreve a mensagem protobuf do payload (ver ProtobufEnvelopeCodec).
+func (c *EventCodec) ProtoDescriptor() []ProtoField { return ProtoDescriptorOf(c.proto.Descriptor()) }
+
+type codecKey struct {
+	name    string
+	version int
+}
+
+// CodecRegistry mapeia (EventName, versão) para schema + tipo Go e guarda os upcasters
+// entre versões. O tipo Go da versão mais alta é o que o código usa; payloads antigos
+// passam pelos upcasters (N→N+1→...→atual) antes de chegar ao consumidor.
+//
+// Para evoluir um evento: registre a nova versão com o tipo novo, um upcaster da
+// versão anterior para ela, e só então passe a produzir a versão nova.
+type CodecRegistry struct {
+	mu        sync.RWMutex
+	codecs    map[codecKey]*EventCodec
+	current   map[string]int
+	upcasters map[codecKey]Upcaster // chave = versão de origem
+}
+
+func NewCodecRegistry() *CodecRegistry {
+	return &CodecRegistry{
+		codecs:    make(map[codecKey]*EventCodec),
+		current:   make(map[string]int),
+		upcasters: make(map[codecKey]Upcaster),
+	}
+}
+
+// Register associa (name, version) ao tipo do payload (um valor ou ponteiro de
+// exemplo) e à mensagem protobuf gerada dele; os dois precisam corresponder campo a campo.
+func (r *CodecRegistry) Register(name string, version int, payload any, msg proto.Message) error {
+	if name == "" || version < 1 || payload == nil || msg == nil {
+		return fmt.Errorf("register codec %q v%d: name, version >= 1, payload type and proto message are required", name, version)
+	}
+	t := reflect.TypeOf(payload)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	mt := msg.ProtoReflect().Type()
+	if err := checkProtoMapping(t, mt.Descriptor()); err != nil {
+		return fmt.Errorf("register codec %s v%d: %w", name, version, err)
+	}
+
+	schema := schemaOf(t)
+	schema.Schema = jsonSchemaDraft
+	schema.ID = fmt.Sprintf("urn:petri-board-arena:event:%s:v%d", name, version)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	k := codecKey{name, version}
+	if _, ok := r.codecs[k]; ok {
+		return fmt.Errorf("register codec %s v%d: already registered", name, version)
+	}
+	r.codecs[k] = &EventCodec{Name: name, Version: version, Schema: schema, typ: t, proto: mt}
+	if version > r.current[name] {
+		r.current[name] = version
+	}
+	return nil
+}
+
+// RegisterUpcaster registra a conversão from → from+1 de name.
+func (r *CodecRegistry) RegisterUpcaster(name string, from int, fn Upcaster) error {
+	if fn == nil || from < 1 {
+		return fmt.Errorf("register upcaster %s v%d: invalid upcaster", name, from)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	k := codecKey{name, from}
+	if _, ok := r.upcasters[k]; ok {
+		return fmt.Errorf("register upcaster %s v%d: already registered", name, from)
+	}
+	r.upcasters[k] = fn
+	return nil
+}
+
+// Check confere que toda versão antiga tem um caminho de upcast até a atual.
+func (r *CodecRegistry) Check() error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var errs []error
+	for name, cur := range r.current {
+		for v := 1; v < cur; v++ {
+			if _, ok := r.upcasters[codecKey{name, v}]; !ok {
+				errs = append(errs, fmt.Errorf("%s: missing upcaster v%d -> v%d", name, v, v+1))
+			}
+		}
+		if _, ok := r.codecs[codecKey{name, cur}]; !ok {
+			errs = append(errs, fmt.Errorf("%s: missing codec for current version v%d", name, cur))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// CurrentVersion é a versão que os produtores devem gravar no envelope.
+func (r *CodecRegistry) CurrentVersion(name string) (int, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	v, ok := r.current[name]
+	return v, ok
+}
+
+// Codec devolve o codec de (name, version), se registrado.
+func (r *CodecRegistry) Codec(name string, version int) (*EventCodec, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	c, ok := r.codecs[codecKey{name, normalizeVersion(version)}]
+	return c, ok
+}
+
+// Events lista os EventNames registrados, em ordem.
+func (r *CodecRegistry) Events() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]string, 0, len(r.current))
+	for name := range r.current {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Codecs lista todos os codecs (todas as versões), por nome e versão.
+func (r *CodecRegistry) Codecs() []*EventCodec {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]*EventCodec, 0, len(r.codecs))
+	for _, c := range r.codecs {
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Name != out[j].Name {
+			return out[i].Name < out[j].Name
+		}
+		return out[i].Version < out[j].Version
+	})
+	return out
+}
+
+// Upcast leva o payload de version até a versão atual de name.
+func (r *CodecRegistry) Upcast(name string, version int, payload json.RawMessage) (json.RawMessage, int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	cur, ok := r.current[name]
+	if !ok {
+		return nil, 0, fmt.Errorf("%w: %s", ErrUnknownEvent, name)
+	}
+	v := normalizeVersion(version)
+	if v > cur {
+		return nil, v, fmt.Errorf("%w: %s v%d (latest known v%d)", ErrUnsupportedVersion, name, v, cur)
+	}
+	for ; v < cur; v++ {
+		up, ok := r.upcasters[codecKey{name, v}]
+		if !ok {
+			return nil, v, fmt.Errorf("%w: %s has no upcaster from v%d", ErrUnsupportedVersion, name, v)
+		}
+		out, err := up(payload)
+		if err != nil {
+			return nil, v, fmt.Errorf("%w: upcast %s v%d -> v%d: %v", ErrInvalidPayload, name, v, v+1, err)
+		}
+		payload = out
+	}
+	return payload, cur, nil
+}
+
+// Decode faz o upcast até a versão atual, valida contra o schema dela e devolve um
+// ponteiro para o tipo Go registrado (ex.: *ArenaCreatedPayload).
+func (r *CodecRegistry) Decode(name string, version int, payload json.RawMessage) (any, error) {
+	payload, cur, err := r.Upcast(name, version, payload)
+	if err != nil {
+		return nil, err
+	}
+	c, ok := r.Codec(name, cur)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s v%d has no codec", ErrUnsupportedVersion, name, cur)
+	}
+	if len(payload) == 0 {
+		payload = json.RawMessage("{}")
+	}
+	if err := c.Schema.Validate(payload); err != nil {
+		return nil, fmt.Errorf("%w: %s v%d: %v", ErrInvalidPayload, name, cur, err)
+	}
+	v := c.New()
+	if err := json.Unmarshal(payload, v); err != nil {
+		return nil, fmt.Errorf("%w: %s v%d: %v", ErrInvalidPayload, name, cur, err)
+	}
+	return v, nil
+}
+
+// normalizeVersion: envelopes gravados antes do versionamento chegam sem version (0) e são v1.
+func normalizeVersion(v int) int {
+	if v < 1 {
+		return 1
+	}
+	return v
+}
+
+// EmptyPayload é o payload dos eventos sem dados além do envelope (ArenaStarted etc.).
+type EmptyPayload struct{}
+
+// ArenaCodecs é o registro dos eventos de arena, compartilhado por produtores
+// (outbox, event store) e consumidores (projector).
+var ArenaCodecs = sync.OnceValue(func() *CodecRegistry {
+	r := NewCodecRegistry()
+	must := func(err error) {
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	must(r.Register("ArenaCreated", 1, ArenaCreatedPayload{}, &arenapb.ArenaCreated{}))
+	must(r.Register("ArenaStarted", 1, EmptyPayload{}, &arenapb.ArenaStarted{}))
+	must(r.Register("ArenaPaused", 1, EmptyPayload{}, &arenapb.ArenaPaused{}))
+	must(r.Register("ArenaResumed", 1, EmptyPayload{}, &arenapb.ArenaResumed{}))
+	must(r.Register("ArenaStopped", 1, EmptyPayload{}, &arenapb.ArenaStopped{}))
+	must(r.Register("ArenaFinished", 1, ArenaFinishedPayload{}, &arenapb.ArenaFinished{}))
+	must(r.Register("PlayerJoined", 1, PlayerJoinedPayload{}, &arenapb.PlayerJoined{}))
+	must(r.Register("PlayerLeft", 1, PlayerLeftPayload{}, &arenapb.PlayerLeft{}))
+	must(r.Register("ArenaConfigUpdated", 1, ArenaConfigUpdatedPayload{}, &arenapb.ArenaConfigUpdated{}))
+	must(r.Register("ActionSubmitted", 1, ActionSubmittedPayload{}, &arenapb.ActionSubmitted{}))
+	must(r.Register("TickAdvanced", 1, TickAdvancedPayload{}, &arenapb.TickAdvanced{}))
+
+	must(r.Check())
+	return r
+})
@@ -0,0 +1,178 @@
+package messaging
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/petri-board-arena/internal/infrastructure/messaging/arenapb"
+)
+
+// HeaderContentType é o header Kafka (e do outbox) com o formato do valor da mensagem.
+// Mensagens sem o header são JSON (produzidas antes do codec plugável).
+const HeaderContentType = "contentType"
+
+const (
+	ContentTypeJSON     = "application/json"
+	ContentTypeProtobuf = "application/x-protobuf"
+)
+
+// Formatos aceitos em kafka.encoding.
+const (
+	EncodingJSON     = "json"
+	EncodingProtobuf = "protobuf"
+)
+
+var ErrUnsupportedContentType = errors.New("unsupported content type")
+
+// EnvelopeCodec serializa o EventEnvelope no valor da mensagem Kafka.
+type EnvelopeCodec interface {
+	ContentType() string
+	Encode(EventEnvelope) ([]byte, error)
+	Decode([]byte) (EventEnvelope, error)
+}
+
+// NewEnvelopeCodec devolve o codec de um formato de kafka.encoding.
+func NewEnvelopeCodec(encoding string) (EnvelopeCodec, error) {
+	switch encoding {
+	case "", EncodingJSON:
+		return JSONEnvelopeCodec{}, nil
+	case EncodingProtobuf:
+		return NewProtobufEnvelopeCodec(ArenaCodecs()), nil
+	}
+	return nil, fmt.Errorf("envelope codec: unknown encoding %q (%s|%s)", encoding, EncodingJSON, EncodingProtobuf)
+}
+
+// EnvelopeCodecFor escolhe o codec pelo header de content type.
+func EnvelopeCodecFor(contentType string) (EnvelopeCodec, error) {
+	mt, _, _ := strings.Cut(contentType, ";")
+	switch strings.TrimSpace(mt) {
+	case "", ContentTypeJSON:
+		return JSONEnvelopeCodec{}, nil
+	case ContentTypeProtobuf:
+		return NewProtobufEnvelopeCodec(ArenaCodecs()), nil
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnsupportedContentType, contentType)
+}
+
+// DecodeEnvelope decodifica o valor de uma mensagem conforme o content type dela.
+func DecodeEnvelope(contentType string, value []byte) (EventEnvelope, error) {
+	c, err := EnvelopeCodecFor(contentType)
+	if err != nil {
+		return EventEnvelope{}, err
+	}
+	return c.Decode(value)
+}
+
+// ----------------------------
+// JSON
+// ----------------------------
+
+type JSONEnvelopeCodec struct{}
+
+func (JSONEnvelopeCodec) ContentType() string { return ContentTypeJSON }
+
+func (JSONEnvelopeCodec) Encode(ev EventEnvelope) ([]byte, error) {
+	return json.Marshal(ev)
+}
+
+func (JSONEnvelopeCodec) Decode(b []byte) (EventEnvelope, error) {
+	var ev EventEnvelope
+	if err := json.Unmarshal(b, &ev); err != nil {
+		return EventEnvelope{}, err
+	}
+	return ev, nil
+}
+
+// ----------------------------
+// Protobuf
+// ----------------------------
+
+// ProtobufEnvelopeCodec usa as mensagens de proto/arena/v1 (arenapb): o envelope é
+// arenapb.EventEnvelope e o payload vai codificado com a mensagem de (eventType,
+// version) do registry; eventos que o registry não conhece seguem como JSON em json_payload.
+type ProtobufEnvelopeCodec struct {
+	codecs *CodecRegistry
+}
+
+func NewProtobufEnvelopeCodec(codecs *CodecRegistry) *ProtobufEnvelopeCodec {
+	return &ProtobufEnvelopeCodec{codecs: codecs}
+}
+
+func (c *ProtobufEnvelopeCodec) ContentType() string { return ContentTypeProtobuf }
+
+func (c *ProtobufEnvelopeCodec) Encode(ev EventEnvelope) ([]byte, error) {
+	pe := &arenapb.EventEnvelope{
+		EventId:     ev.EventID,
+		EventType:   ev.EventType,
+		AggregateId: ev.AggregateID,
+		Version:     int64(ev.Version),
+		Sequence:    ev.Sequence,
+	}
+	if !ev.OccurredAt.IsZero() {
+		pe.OccurredAt = timestamppb.New(ev.OccurredAt)
+	}
+
+	codec, ok := c.codecs.Codec(ev.EventType, ev.Version)
+	if !ok {
+		pe.JsonPayload = ev.Payload
+	} else {
+		v := codec.New()
+		if len(ev.Payload) > 0 {
+			if err := json.Unmarshal(ev.Payload, v); err != nil {
+				return nil, fmt.Errorf("protobuf envelope %s v%d: %w: %v", ev.EventType, ev.Version, ErrInvalidPayload, err)
+			}
+		}
+		b, err := marshalPayload(v, codec.proto)
+		if err != nil {
+			return nil, fmt.Errorf("protobuf envelope %s v%d: %w", ev.EventType, ev.Version, err)
+		}
+		pe.Payload = b
+	}
+
+	return proto.Marshal(pe)
+}
+
+// Decode devolve o envelope com o payload em JSON, na versão em que foi produzido:
+// o upcast continua com o consumidor (CodecRegistry.Decode).
+func (c *ProtobufEnvelopeCodec) Decode(b []byte) (EventEnvelope, error) {
+	var pe arenapb.EventEnvelope
+	if err := proto.Unmarshal(b, &pe); err != nil {
+		return EventEnvelope{}, fmt.Errorf("protobuf envelope: %w", err)
+	}
+
+	ev := EventEnvelope{
+		EventID:     pe.EventId,
+		EventType:   pe.EventType,
+		AggregateID: pe.AggregateId,
+		Version:     int(pe.Version),
+		Sequence:    pe.Sequence,
+		Payload:     json.RawMessage(pe.JsonPayload),
+	}
+	if pe.OccurredAt != nil {
+		ev.OccurredAt = pe.OccurredAt.AsTime().UTC()
+	}
+	if len(pe.JsonPayload) > 0 {
+		return ev, nil
+	}
+
+	codec, ok := c.codecs.Codec(pe.EventType, int(pe.Version))
+	if !ok {
+		// sem o tipo não dá para ler os bytes: o produtor está à frente deste consumidor
+		return EventEnvelope{}, fmt.Errorf("protobuf envelope: %w: %s v%d", ErrUnsupportedVersion, pe.EventType, pe.Version)
+	}
+	v := codec.New()
+	if err := unmarshalPayload(pe.Payload, codec.proto, v); err != nil {
+		return EventEnvelope{}, fmt.Errorf("protobuf envelope %s v%d: %w: %v", pe.EventType, pe.Version, ErrInvalidPayload, err)
+	}
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return EventEnvelope{}, fmt.Errorf("protobuf envelope %s v%d: %w", pe.EventType, pe.Version, err)
+	}
+	ev.Payload = payload
+	return ev, nil
+}
@@ -0,0 +1,287 @@
+package messaging
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/petri-board-arena/internal/domain/arena"
+	"github.com/petri-board-arena/internal/infrastructure/messaging/arenapb"
+)
+
+// sampleArenaEvents tem ao menos um evento de cada tipo de ArenaCodecs, com os campos
+// opcionais preenchidos e vazios.
+func sampleArenaEvents() []arena.Event {
+	id := arena.ID(uuid.New())
+	base := arena.NewBaseEvent(id, time.Date(2026, 3, 4, 5, 6, 7, 890, time.UTC))
+	player := arena.PlayerID(uuid.New())
+	promoted := arena.PlayerID(uuid.New())
+	genome := "genome-1"
+	cfg := arena.Config{
+		TickMillis: 250, Width: 64, Height: 32, DiffusionRate: 0.25, MutationRate: 0.01,
+		MaxOrganisms: 500, SnapshotEveryTicks: 10,
+		Temperature: arena.Temperature{Value: 37.5, Unit: arena.TempC},
+		MaxTicks:    1000, MaxPlayers: 4, MaxActionsPerTick: 2,
+	}
+	action := func(typ arena.ActionType, p arena.ActionPayload) arena.Event {
+		return arena.ActionSubmitted{BaseEvent: base, Action: arena.PlayerAction{
+			ID:          arena.ActionID(uuid.New()),
+			Type:        typ,
+			PlayerID:    player,
+			SubmittedAt: time.Date(2026, 3, 4, 5, 6, 8, 123456789, time.UTC),
+			ApplyAtTick: 12,
+			Payload:     p,
+		}}
+	}
+	area := arena.Area{X: 1, Y: 2, Width: 3, Height: 4}
+
+	return []arena.Event{
+		arena.ArenaCreated{BaseEvent: base, Name: "petri", Config: cfg},
+		arena.ArenaStarted{BaseEvent: base},
+		arena.ArenaPaused{BaseEvent: base},
+		arena.ArenaResumed{BaseEvent: base},
+		arena.ArenaStopped{BaseEvent: base},
+		arena.ArenaFinished{BaseEvent: base, Tick: 1000},
+		arena.PlayerJoined{BaseEvent: base, PlayerID: player, DisplayName: "ana", Role: arena.RoleAdmin},
+		arena.PlayerLeft{BaseEvent: base, PlayerID: player},
+		arena.PlayerLeft{BaseEvent: base, PlayerID: player, PromotedAdminID: &promoted},
+		arena.ArenaConfigUpdated{BaseEvent: base, Config: cfg},
+		action(arena.ActionAddNutrients, arena.AddNutrientsPayload{Area: area, Amount: 5}),
+		action(arena.ActionDropAntibiotic, arena.DropAntibioticPayload{Area: area, Kind: arena.AntibioticB, Concentration: 0.5}),
+		action(arena.ActionSetTemperature, arena.SetTemperaturePayload{Temperature: arena.Temperature{Value: 0, Unit: arena.TempC}}),
+		action(arena.ActionSpawnOrganism, arena.SpawnOrganismPayload{Kind: arena.KindFungi, Position: arena.Point{X: 0, Y: 7}}),
+		action(arena.ActionSpawnOrganism, arena.SpawnOrganismPayload{Kind: arena.KindPhage, Position: arena.Point{X: 3, Y: 0}, GenomeTemplateID: &genome}),
+		arena.TickAdvanced{BaseEvent: base, Tick: 0},
+		arena.TickAdvanced{BaseEvent: base, Tick: 42},
+	}
+}
+
+func TestArenaEventsRoundTrip(t *testing.T) {
+	events := sampleArenaEvents()
+
+	covered := map[string]bool{}
+	for _, ev := range events {
+		covered[ev.EventName()] = true
+	}
+	for _, name := range ArenaCodecs().Events() {
+		if !covered[name] {
+			t.Errorf("no sample for registered event %s", name)
+		}
+	}
+
+	codecs := []EnvelopeCodec{JSONEnvelopeCodec{}, NewProtobufEnvelopeCodec(ArenaCodecs())}
+	for _, ev := range events {
+		payload, err := EncodeArenaEvent(ev)
+		if err != nil {
+			t.Fatalf("encode %s: %v", ev.EventName(), err)
+		}
+		env := EventEnvelope{
+			EventID:     uuid.NewString(),
+			EventType:   ev.EventName(),
+			AggregateID: ev.ArenaID().String(),
+			OccurredAt:  ev.OccurredAt(),
+			Version:     ArenaPayloadVersion(ev.EventName()),
+			Sequence:    7,
+			Payload:     payload,
+		}
+
+		for _, c := range codecs {
+			b, err := c.Encode(env)
+			if err != nil {
+				t.Fatalf("%s %s: encode: %v", c.ContentType(), ev.EventName(), err)
+			}
+			got, err := DecodeEnvelope(c.ContentType(), b)
+			if err != nil {
+				t.Fatalf("%s %s: decode: %v", c.ContentType(), ev.EventName(), err)
+			}
+			if got.EventID != env.EventID || got.EventType != env.EventType || got.AggregateID != env.AggregateID ||
+				!got.OccurredAt.Equal(env.OccurredAt) || got.Version != env.Version || got.Sequence != env.Sequence {
+				t.Fatalf("%s %s: envelope = %+v, want %+v", c.ContentType(), ev.EventName(), got, env)
+			}
+
+			back, err := DecodeArenaEvent(got.EventType, got.Version, ev.ArenaID(), got.OccurredAt, got.Payload)
+			if err != nil {
+				t.Fatalf("%s %s: decode event: %v", c.ContentType(), ev.EventName(), err)
+			}
+			if !reflect.DeepEqual(back, ev) {
+				t.Fatalf("%s %s: round trip\n got %+v\nwant %+v", c.ContentType(), ev.EventName(), back, ev)
+			}
+		}
+	}
+}
+
+// Os bytes do payload são os da mensagem gerada (arenapb), nos dois sentidos.
+func TestProtobufPayloadIsGeneratedMessage(t *testing.T) {
+	codec, _ := ArenaCodecs().Codec("PlayerLeft", 1)
+	if codec.ProtoMessage() != "petri.arena.v1.PlayerLeft" {
+		t.Fatalf("PlayerLeft v1 message = %s", codec.ProtoMessage())
+	}
+
+	promoted := "p2"
+	b, err := marshalPayload(&PlayerLeftPayload{PlayerID: "p1", PromotedAdminID: &promoted}, codec.proto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var pb arenapb.PlayerLeft
+	if err := proto.Unmarshal(b, &pb); err != nil {
+		t.Fatal(err)
+	}
+	if pb.GetPlayerId() != "p1" || pb.PromotedAdminId == nil || pb.GetPromotedAdminId() != "p2" {
+		t.Fatalf("arenapb.PlayerLeft = %v", &pb)
+	}
+
+	// optional preserva presença mesmo com valor zero
+	empty := ""
+	b, err = proto.Marshal(&arenapb.PlayerLeft{PlayerId: "p1", PromotedAdminId: &empty})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got PlayerLeftPayload
+	if err := unmarshalPayload(b, codec.proto, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.PlayerID != "p1" || got.PromotedAdminID == nil || *got.PromotedAdminID != "" {
+		t.Fatalf("payload = %+v, want playerId p1 and an empty promotedAdminId", got)
+	}
+}
+
+func TestRegisterRejectsProtoMismatch(t *testing.T) {
+	type wrongName struct {
+		Ticks int64 `json:"ticks" proto:"1"`
+	}
+	type wrongType struct {
+		Tick string `json:"tick" proto:"1"`
+	}
+	type missingField struct{}
+
+	cases := map[string]any{
+		"json name":     wrongName{},
+		"type":          wrongType{},
+		"missing field": missingField{},
+	}
+	for name, payload := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := NewCodecRegistry().Register("TickAdvanced", 1, payload, &arenapb.TickAdvanced{})
+			if err == nil {
+				t.Fatal("Register accepted a payload that does not match the proto message")
+			}
+		})
+	}
+}
+
+type playerV1 struct {
+	PlayerID        string  `json:"playerId" proto:"1"`
+	PromotedAdminID *string `json:"promotedAdminId,omitempty" proto:"2"`
+}
+
+// v2 = PlayerJoinedPayload: ganhou displayName e role.
+func testUpcastRegistry(t *testing.T) *CodecRegistry {
+	t.Helper()
+	r := NewCodecRegistry()
+	if err := r.Register("Player", 1, playerV1{}, &arenapb.PlayerLeft{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Register("Player", 2, PlayerJoinedPayload{}, &arenapb.PlayerJoined{}); err != nil {
+		t.Fatal(err)
+	}
+	err := r.RegisterUpcaster("Player", 1, func(payload json.RawMessage) (json.RawMessage, error) {
+		var v1 playerV1
+		if err := json.Unmarshal(payload, &v1); err != nil {
+			return nil, err
+		}
+		return json.Marshal(PlayerJoinedPayload{PlayerID: v1.PlayerID, DisplayName: "anonymous", Role: "PLAYER"})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Check(); err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestCodecRegistryUpcast(t *testing.T) {
+	r := testUpcastRegistry(t)
+	want := &PlayerJoinedPayload{PlayerID: "p1", DisplayName: "anonymous", Role: "PLAYER"}
+
+	cases := []struct {
+		name    string
+		version int
+		payload string
+		want    *PlayerJoinedPayload
+		err     error
+	}{
+		{name: "v1 is upcast", version: 1, payload: `{"playerId":"p1"}`, want: want},
+		{name: "missing version is v1", version: 0, payload: `{"playerId":"p1"}`, want: want},
+		{name: "current version", version: 2, payload: `{"playerId":"p1","displayName":"ana","role":"ADMIN"}`,
+			want: &PlayerJoinedPayload{PlayerID: "p1", DisplayName: "ana", Role: "ADMIN"}},
+		{name: "newer than known", version: 3, payload: `{}`, err: ErrUnsupportedVersion},
+		{name: "outside the schema", version: 2, payload: `{"playerId":"p1"}`, err: ErrInvalidPayload},
+		{name: "upcaster fails", version: 1, payload: `[]`, err: ErrInvalidPayload},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := r.Decode("Player", tc.version, json.RawMessage(tc.payload))
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("Decode err = %v, want %v", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("Decode = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+
+	if _, err := r.Decode("Unknown", 1, json.RawMessage(`{}`)); !errors.Is(err, ErrUnknownEvent) {
+		t.Fatalf("Decode(Unknown) err = %v, want ErrUnknownEvent", err)
+	}
+}
+
+// Um v1 que chega em protobuf segue na versão em que foi produzido e só é convertido
+// pelo consumidor (Decode).
+func TestProtobufEnvelopeKeepsProducedVersion(t *testing.T) {
+	r := testUpcastRegistry(t)
+	c := NewProtobufEnvelopeCodec(r)
+
+	b, err := c.Encode(EventEnvelope{EventID: "e1", EventType: "Player", AggregateID: "a1", Version: 1, Payload: json.RawMessage(`{"playerId":"p1"}`)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	env, err := c.Decode(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if env.Version != 1 || string(env.Payload) != `{"playerId":"p1"}` {
+		t.Fatalf("envelope = v%d %s, want v1 {\"playerId\":\"p1\"}", env.Version, env.Payload)
+	}
+	got, err := r.Decode(env.EventType, env.Version, env.Payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.(*PlayerJoinedPayload).DisplayName != "anonymous" {
+		t.Fatalf("upcast = %+v", got)
+	}
+}
+
+func TestCheckRequiresUpcasterPath(t *testing.T) {
+	r := NewCodecRegistry()
+	if err := r.Register("Player", 1, playerV1{}, &arenapb.PlayerLeft{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Register("Player", 2, PlayerJoinedPayload{}, &arenapb.PlayerJoined{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Check(); err == nil {
+		t.Fatal("Check accepted v1 -> v2 without an upcaster")
+	}
+}
@@ -0,0 +1,38 @@
+package messaging
+
+import "errors"
+
+// Classificação de erros de processamento (consumer/projector):
+//   - permanent: determinístico (payload inválido, envelope incompleto); repetir não
+//     muda o resultado, vai direto para o DLQ.
+//   - transient: todo o resto (Redis fora, timeout); retry com backoff.
+const (
+	ClassPermanent = "permanent"
+	ClassTransient = "transient"
+)
+
+type permanentError struct{ err error }
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent marca err como não-retentável. nil continua nil.
+func Permanent(err error) error {
+	if err == nil || IsPermanent(err) {
+		return err
+	}
+	return &permanentError{err: err}
+}
+
+func IsPermanent(err error) bool {
+	var pe *permanentError
+	return errors.As(err, &pe)
+}
+
+// Classify retorna ClassPermanent ou ClassTransient.
+func Classify(err error) string {
+	if IsPermanent(err) {
+		return ClassPermanent
+	}
+	return ClassTransient
+}
@@ -5,11 +5,15 @@ import (
 	"time"
 )
 
+// EventEnvelope.Sequence é a posição do evento no aggregate (a versão do aggregate
+// depois dele): o projector ignora eventos que não são mais novos que o último
+// aplicado. 0 = envelope gravado antes da sequência (aplicado sem a verificação).
 type EventEnvelope struct {
 	EventID     string          `json:"eventId"`
 	EventType   string          `json:"eventType"`
 	AggregateID string          `json:"aggregateId"` // arenaId
 	OccurredAt  time.Time       `json:"occurredAt"`
-	Version     int             `json:"version"`
+	Version     int             `json:"version"` // versão do payload de EventType (ArenaCodecs)
+	Sequence    int64           `json:"sequence,omitempty"`
 	Payload     json.RawMessage `json:"payload"`
 }
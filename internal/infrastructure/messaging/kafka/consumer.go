@@ -4,7 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log"
+	"math/rand/v2"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/segmentio/kafka-go"
@@ -13,50 +17,281 @@ import (
 	"github.com/petri-board-arena/internal/infrastructure/projector"
 )
 
+// Headers das mensagens encaminhadas aos tópicos de retry.
+const (
+	HeaderRetryAttempts     = "x-retry-attempts"
+	HeaderRetryNotBefore    = "x-retry-not-before" // RFC3339Nano
+	HeaderOriginalTopic     = "x-original-topic"
+	HeaderOriginalPartition = "x-original-partition"
+	HeaderOriginalOffset    = "x-original-offset"
+	HeaderLastError         = "x-last-error"
+	HeaderErrorClass        = "x-error-class"
+	// HeaderRetryHeld marca o evento que não falhou: foi para o tier atrás de um evento
+	// anterior da mesma arena (ver RetryHolds).
+	HeaderRetryHeld = "x-retry-held"
+	// HeaderReplayedFromDLQ (dlq-replay -mode=republish): o evento é reaplicado mesmo
+	// atrás de eventos mais novos da arena (projector.Reapply).
+	HeaderReplayedFromDLQ = "replayedFromDlq"
+)
+
+// retryHeaders são escritos por forward; os demais headers da mensagem são copiados.
+var retryHeaders = map[string]bool{
+	HeaderRetryAttempts:     true,
+	HeaderRetryNotBefore:    true,
+	HeaderOriginalTopic:     true,
+	HeaderOriginalPartition: true,
+	HeaderOriginalOffset:    true,
+	HeaderLastError:         true,
+	HeaderErrorClass:        true,
+	HeaderRetryHeld:         true,
+}
+
+// Consumer projeta o tópico principal. Com RetryTiers configurado, uma falha
+// transiente não bloqueia a partição: a mensagem vai para <topic>.retry.<delay>,
+// onde um reader dedicado a reaplica depois do delay; esgotados os tiers (ou em
+// erro permanente) ela vai para o DLQ.
+//
+// Ordem por arena: enquanto um evento de uma arena está em retry, os seguintes dela
+// não são aplicados; entram na fila da arena (RetryHolds), seguem para o tier do
+// primeiro e só são aplicados quando chega a vez deles. Sem holds (nil) um evento em retry seria aplicado depois dos
+// seguintes e poderia, por exemplo, voltar uma arena FINISHED para RUNNING.
 type Consumer struct {
-	cfg       ConsumerConfig
-	reader    *kafka.Reader
-	dlqWriter *kafka.Writer
-	projector *projector.Projector
-}
-
-func NewConsumer(cfg ConsumerConfig, projector *projector.Projector) *Consumer {
-	r := kafka.NewReader(kafka.ReaderConfig{
-		Brokers:         cfg.KafkaBrokers,
-		Topic:           cfg.KafkaTopic,
-		GroupID:         cfg.KafkaGroupID,
-		MinBytes:        cfg.PollMinBytes,
-		MaxBytes:        cfg.PollMaxBytes,
-		ReadLagInterval: -1,
-		MaxWait:         250 * time.Millisecond,
-		CommitInterval:  cfg.CommitInterval,
-	})
+	cfg         ConsumerConfig
+	reader      *kafka.Reader
+	tierReaders []*kafka.Reader
+	retryWriter *kafka.Writer
+	dlqWriter   *kafka.Writer
+	projector   *projector.Projector
+	holds       *RetryHolds
+}
 
-	w := &kafka.Writer{
-		Addr:         kafka.TCP(cfg.KafkaBrokers...),
-		Topic:        cfg.KafkaDLQ,
-		Balancer:     &kafka.Hash{},
-		RequiredAcks: kafka.RequireOne,
+// NewConsumer: holds mantém a ordem por arena com RetryTiers; nil só serve sem tiers.
+func NewConsumer(cfg ConsumerConfig, projector *projector.Projector, holds *RetryHolds) *Consumer {
+	newReader := func(topic, group string) *kafka.Reader {
+		return kafka.NewReader(kafka.ReaderConfig{
+			Brokers:         cfg.KafkaBrokers,
+			Topic:           topic,
+			GroupID:         group,
+			MinBytes:        cfg.PollMinBytes,
+			MaxBytes:        cfg.PollMaxBytes,
+			ReadLagInterval: -1,
+			MaxWait:         250 * time.Millisecond,
+			CommitInterval:  cfg.CommitInterval,
+		})
 	}
 
-	return &Consumer{
+	c := &Consumer{
 		cfg:       cfg,
-		reader:    r,
-		dlqWriter: w,
+		reader:    newReader(cfg.KafkaTopic, cfg.KafkaGroupID),
 		projector: projector,
+		holds:     holds,
+		dlqWriter: &kafka.Writer{
+			Addr:         kafka.TCP(cfg.KafkaBrokers...),
+			Topic:        cfg.KafkaDLQ,
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireOne,
+		},
 	}
+
+	if len(cfg.RetryTiers) > 0 {
+		c.retryWriter = &kafka.Writer{
+			Addr:                   kafka.TCP(cfg.KafkaBrokers...),
+			Balancer:               &kafka.Hash{},
+			RequiredAcks:           kafka.RequireAll,
+			BatchTimeout:           10 * time.Millisecond,
+			AllowAutoTopicCreation: true,
+		}
+		for _, d := range cfg.RetryTiers {
+			topic := RetryTopic(cfg.KafkaTopic, d)
+			c.tierReaders = append(c.tierReaders, newReader(topic, cfg.KafkaGroupID+".retry."+tierLabel(d)))
+		}
+	}
+	return c
 }
 
 func (c *Consumer) Close() error {
 	_ = c.reader.Close()
+	for _, r := range c.tierReaders {
+		_ = r.Close()
+	}
+	if c.retryWriter != nil {
+		_ = c.retryWriter.Close()
+	}
 	_ = c.dlqWriter.Close()
 	return nil
 }
 
+// RetryTopic: <base>.retry.<delay> (ex.: petri.arena.events.v1.retry.30s).
+func RetryTopic(base string, delay time.Duration) string {
+	return base + ".retry." + tierLabel(delay)
+}
+
+func tierLabel(d time.Duration) string {
+	switch {
+	case d%time.Hour == 0:
+		return strconv.FormatInt(int64(d/time.Hour), 10) + "h"
+	case d%time.Minute == 0:
+		return strconv.FormatInt(int64(d/time.Minute), 10) + "m"
+	case d%time.Second == 0:
+		return strconv.FormatInt(int64(d/time.Second), 10) + "s"
+	default:
+		return strconv.FormatInt(d.Milliseconds(), 10) + "ms"
+	}
+}
+
+// Run consome o tópico principal e os tiers de retry até o ctx ser cancelado
+// ou um dos loops falhar (os demais são encerrados).
 func (c *Consumer) Run(ctx context.Context) error {
-	log.Printf("[worker] consuming topic=%s group=%s dlq=%s brokers=%v",
-		c.cfg.KafkaTopic, c.cfg.KafkaGroupID, c.cfg.KafkaDLQ, c.cfg.KafkaBrokers)
+	log.Printf("[worker] consuming topic=%s group=%s dlq=%s retryTiers=%v brokers=%v",
+		c.cfg.KafkaTopic, c.cfg.KafkaGroupID, c.cfg.KafkaDLQ, c.cfg.RetryTiers, c.cfg.KafkaBrokers)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	run := func(fn func(context.Context) error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := fn(ctx); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+				cancel()
+			}
+		}()
+	}
+
+	run(c.runMain)
+	for i := range c.tierReaders {
+		run(func(ctx context.Context) error { return c.runTier(ctx, i) })
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func (c *Consumer) runMain(ctx context.Context) error {
+	// com tiers o tópico principal tenta uma vez só e segue; sem tiers, retry inline (bloqueante)
+	inlineAttempts := c.cfg.MaxRetries
+	if len(c.tierReaders) > 0 {
+		inlineAttempts = 1
+	}
 
+	handle := func(msg kafka.Message) error {
+		if c.holding(msg) {
+			var (
+				tier int
+				held bool
+			)
+			err := c.withHoldRetry(ctx, func() (err error) {
+				tier, held, err = c.holds.Hold(ctx, string(msg.Key), originOf(msg).String())
+				return err
+			})
+			if err != nil {
+				return err
+			}
+			if held {
+				return c.forward(ctx, msg, originOf(msg), 0, tier, nil)
+			}
+		}
+
+		attempts, err := c.processWithRetry(ctx, msg, inlineAttempts)
+		if err == nil {
+			return nil
+		}
+		return c.handleFailure(ctx, msg, originOf(msg), attempts, 0, err)
+	}
+
+	if c.cfg.Workers > 1 {
+		return c.consumeParallel(ctx, c.reader, handle)
+	}
+	return c.consume(ctx, c.reader, handle)
+}
+
+func (c *Consumer) runTier(ctx context.Context, tier int) error {
+	return c.consume(ctx, c.tierReaders[tier], func(msg kafka.Message) error {
+		// mensagens de um tier têm o mesmo delay: estão em ordem de vencimento
+		if notBefore, err := time.Parse(time.RFC3339Nano, header(msg, HeaderRetryNotBefore)); err == nil {
+			if wait := time.Until(notBefore); wait > 0 {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(wait):
+				}
+			}
+		}
+
+		prev, _ := strconv.Atoi(header(msg, HeaderRetryAttempts))
+
+		// há eventos anteriores da arena na fila: este volta para o tier do primeiro
+		if c.holding(msg) {
+			var (
+				head int
+				wait bool
+			)
+			err := c.withHoldRetry(ctx, func() (err error) {
+				head, wait, err = c.holds.Turn(ctx, string(msg.Key), originOf(msg).String())
+				return err
+			})
+			if err != nil {
+				return err
+			}
+			if wait {
+				return c.forward(ctx, msg, originOf(msg), prev, head, nil)
+			}
+		}
+
+		attempts, err := c.processWithRetry(ctx, msg, 1)
+		if err == nil {
+			return c.release(ctx, msg)
+		}
+		return c.handleFailure(ctx, msg, originOf(msg), prev+attempts, tier+1, err)
+	})
+}
+
+// holding: eventos com key (AggregateID) participam da ordem por arena nos tiers.
+func (c *Consumer) holding(msg kafka.Message) bool {
+	return c.holds != nil && len(c.cfg.RetryTiers) > 0 && len(msg.Key) > 0
+}
+
+// release tira da fila o evento que saiu dos tiers; o seguinte da arena passa a ser o primeiro.
+func (c *Consumer) release(ctx context.Context, msg kafka.Message) error {
+	if !c.holding(msg) {
+		return nil
+	}
+	id := originOf(msg).String()
+	return c.withHoldRetry(ctx, func() error { return c.holds.Release(ctx, string(msg.Key), id) })
+}
+
+// withHoldRetry repete operações de RetryHolds em erro do Redis: sem o estado não dá
+// para decidir a ordem, então a mensagem espera (e, esgotadas as tentativas, o
+// consumer para sem comitar).
+func (c *Consumer) withHoldRetry(ctx context.Context, fn func() error) error {
+	attempts := max(c.cfg.MaxRetries, 1)
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = fn(); err == nil || attempt >= attempts {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff(c.cfg.RetryBackoff, c.cfg.RetryMaxBackoff, attempt)):
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("retry hold: %w", err)
+	}
+	return nil
+}
+
+// consume faz fetch → handle → commit. Se o handle falha por shutdown, não comita:
+// a mensagem volta no próximo start.
+func (c *Consumer) consume(ctx context.Context, r *kafka.Reader, handle func(kafka.Message) error) error {
 	for {
 		select {
 		case <-ctx.Done():
@@ -65,7 +300,7 @@ func (c *Consumer) Run(ctx context.Context) error {
 		}
 
 		readCtx, cancel := context.WithTimeout(ctx, c.cfg.ReadTimeout)
-		msg, err := c.reader.FetchMessage(readCtx)
+		msg, err := r.FetchMessage(readCtx)
 		cancel()
 
 		if err != nil {
@@ -78,60 +313,205 @@ func (c *Consumer) Run(ctx context.Context) error {
 			return err
 		}
 
-		if err := c.processWithRetry(ctx, msg); err != nil {
-			_ = c.sendDLQ(ctx, msg, err)
+		if err := handle(msg); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
 		}
 
-		if err := c.reader.CommitMessages(ctx, msg); err != nil {
+		if err := r.CommitMessages(ctx, msg); err != nil {
 			return err
 		}
 	}
 }
 
-func (c *Consumer) processWithRetry(ctx context.Context, msg kafka.Message) error {
-	var lastErr error
+// origin identifica a mensagem no tópico principal (preservada entre os tiers).
+type origin struct {
+	topic     string
+	partition int
+	offset    int64
+}
+
+// String identifica o evento na fila da arena (RetryHolds).
+func (o origin) String() string {
+	return fmt.Sprintf("%s/%d/%d", o.topic, o.partition, o.offset)
+}
 
-	for attempt := 1; attempt <= c.cfg.MaxRetries; attempt++ {
-		var ev messaging.EventEnvelope
-		if err := json.Unmarshal(msg.Value, &ev); err != nil {
+func originOf(msg kafka.Message) origin {
+	o := origin{topic: msg.Topic, partition: msg.Partition, offset: msg.Offset}
+	if t := header(msg, HeaderOriginalTopic); t != "" {
+		o.topic = t
+		o.partition, _ = strconv.Atoi(header(msg, HeaderOriginalPartition))
+		o.offset, _ = strconv.ParseInt(header(msg, HeaderOriginalOffset), 10, 64)
+	}
+	return o
+}
+
+// handleFailure encaminha para o tier nextTier ou, se for permanente / não houver
+// mais tiers, para o DLQ. nextTier > 0 = a mensagem já estava em um tier.
+func (c *Consumer) handleFailure(ctx context.Context, msg kafka.Message, o origin, attempts, nextTier int, cause error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	class := messaging.Classify(cause)
+	if messaging.IsPermanent(cause) || nextTier >= len(c.cfg.RetryTiers) {
+		log.Printf("[worker] %s event %s/%d/%d after %d attempt(s), sending to DLQ: %v",
+			class, o.topic, o.partition, o.offset, attempts, cause)
+		if err := c.sendDLQ(ctx, msg, o, cause, attempts); err != nil {
+			// sem DLQ não dá para seguir sem perder a mensagem
+			return fmt.Errorf("send dlq: %w", err)
+		}
+		// fora da fila (falha direto do tópico principal) o release não faz nada
+		return c.release(ctx, msg)
+	}
+
+	// registra antes de encaminhar: o reader do tier não pode ver o evento sem o hold
+	if c.holding(msg) {
+		id := o.String()
+		err := c.withHoldRetry(ctx, func() error { return c.holds.Park(ctx, string(msg.Key), id, nextTier) })
+		if err != nil {
 			return err
 		}
-		if ev.OccurredAt.IsZero() {
-			ev.OccurredAt = time.Now().UTC()
+		if err := c.forward(ctx, msg, o, attempts, nextTier, cause); err != nil {
+			if nextTier == 0 {
+				// o evento volta pelo tópico principal (não comitado); não fica na fila
+				_ = c.holds.Release(context.WithoutCancel(ctx), string(msg.Key), id)
+			}
+			return err
 		}
+		return nil
+	}
+	return c.forward(ctx, msg, o, attempts, nextTier, cause)
+}
 
-		if err := c.projector.Apply(ctx, ev); err == nil {
-			return nil
-		} else {
-			lastErr = err
+// forward publica a mensagem no tier; cause nil = evento retido atrás de um anterior
+// da mesma arena (não falhou).
+func (c *Consumer) forward(ctx context.Context, msg kafka.Message, o origin, attempts, tier int, cause error) error {
+	delay := c.cfg.RetryTiers[tier]
+	topic := RetryTopic(c.cfg.KafkaTopic, delay)
+	err := c.retryWriter.WriteMessages(ctx, kafka.Message{
+		Topic:   topic,
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: forwardHeaders(msg, o, attempts, time.Now().Add(delay), cause),
+	})
+	if err != nil {
+		return fmt.Errorf("forward to %s: %w", topic, err)
+	}
+	return nil
+}
+
+// forwardHeaders: os headers do produtor (eventId, eventType, contentType,
+// replayedFromDlq...) seguem; os de retry são reescritos a cada encaminhamento.
+func forwardHeaders(msg kafka.Message, o origin, attempts int, notBefore time.Time, cause error) []kafka.Header {
+	headers := make([]kafka.Header, 0, len(msg.Headers)+7)
+	for _, h := range msg.Headers {
+		if !retryHeaders[h.Key] {
+			headers = append(headers, h)
+		}
+	}
+	headers = append(headers,
+		kafka.Header{Key: HeaderRetryAttempts, Value: []byte(strconv.Itoa(attempts))},
+		kafka.Header{Key: HeaderRetryNotBefore, Value: []byte(notBefore.UTC().Format(time.RFC3339Nano))},
+		kafka.Header{Key: HeaderOriginalTopic, Value: []byte(o.topic)},
+		kafka.Header{Key: HeaderOriginalPartition, Value: []byte(strconv.Itoa(o.partition))},
+		kafka.Header{Key: HeaderOriginalOffset, Value: []byte(strconv.FormatInt(o.offset, 10))},
+	)
+	if cause != nil {
+		return append(headers,
+			kafka.Header{Key: HeaderLastError, Value: []byte(cause.Error())},
+			kafka.Header{Key: HeaderErrorClass, Value: []byte(messaging.Classify(cause))},
+		)
+	}
+	return append(headers, kafka.Header{Key: HeaderRetryHeld, Value: []byte("true")})
+}
+
+// processWithRetry aplica a mensagem; erros permanentes saem na hora, transientes
+// são repetidos até maxAttempts com backoff exponencial e jitter. Retorna quantas
+// tentativas foram feitas.
+func (c *Consumer) processWithRetry(ctx context.Context, msg kafka.Message, maxAttempts int) (int, error) {
+	// JSON ou protobuf conforme o header (sem header = JSON, produtores antigos)
+	ev, err := messaging.DecodeEnvelope(header(msg, messaging.HeaderContentType), msg.Value)
+	if err != nil {
+		return 1, messaging.Permanent(fmt.Errorf("decode envelope: %w", err))
+	}
+	if ev.OccurredAt.IsZero() {
+		ev.OccurredAt = time.Now().UTC()
+	}
+
+	maxAttempts = max(maxAttempts, 1)
+
+	apply := c.projector.Apply
+	if header(msg, HeaderReplayedFromDLQ) != "" {
+		apply = c.projector.Reapply
+	}
+
+	var lastErr error
+	attempt := 0
+	for attempt < maxAttempts {
+		attempt++
+
+		lastErr = apply(ctx, ev)
+		if lastErr == nil || messaging.IsPermanent(lastErr) {
+			return attempt, lastErr
+		}
+		if attempt >= maxAttempts {
+			break
 		}
 
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
-		case <-time.After(c.cfg.RetryBackoff * time.Duration(attempt)):
+			return attempt, ctx.Err()
+		case <-time.After(backoff(c.cfg.RetryBackoff, c.cfg.RetryMaxBackoff, attempt)):
 		}
 	}
-	return lastErr
+	return attempt, lastErr
 }
 
-func (c *Consumer) sendDLQ(ctx context.Context, msg kafka.Message, cause error) error {
-	key := msg.Key
+// backoff: full jitter sobre base*2^(attempt-1), limitado a maxDelay.
+func backoff(base, maxDelay time.Duration, attempt int) time.Duration {
+	if base <= 0 {
+		return 0
+	}
+	d := base << min(attempt-1, 30)
+	if d <= 0 || (maxDelay > 0 && d > maxDelay) {
+		d = maxDelay
+	}
+	return time.Duration(rand.Int64N(int64(d) + 1))
+}
 
-	dlqPayload := map[string]any{
-		"originalTopic":     c.cfg.KafkaTopic,
-		"originalPartition": msg.Partition,
-		"originalOffset":    msg.Offset,
-		"error":             cause.Error(),
-		"ts":                time.Now().UTC().Format(time.RFC3339Nano),
-		"value":             json.RawMessage(msg.Value),
+func (c *Consumer) sendDLQ(ctx context.Context, msg kafka.Message, o origin, cause error, attempts int) error {
+	dlqPayload := DLQRecord{
+		OriginalTopic:     o.topic,
+		OriginalPartition: o.partition,
+		OriginalOffset:    o.offset,
+		Error:             cause.Error(),
+		Classification:    messaging.Classify(cause),
+		Attempts:          attempts,
+		TS:                time.Now().UTC().Format(time.RFC3339Nano),
+		ContentType:       header(msg, messaging.HeaderContentType),
 	}
+	dlqPayload.SetOriginalValue(msg.Value)
 
-	b, _ := json.Marshal(dlqPayload)
+	b, err := json.Marshal(dlqPayload)
+	if err != nil {
+		return err
+	}
 
 	return c.dlqWriter.WriteMessages(ctx, kafka.Message{
-		Key:   key,
+		Key:   msg.Key,
 		Value: b,
 		Time:  time.Now(),
 	})
 }
+
+func header(msg kafka.Message, key string) string {
+	for _, h := range msg.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
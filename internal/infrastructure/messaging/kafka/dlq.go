@@ -0,0 +1,134 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// DLQRecord é o valor das mensagens publicadas no tópico de DLQ (ver Consumer.sendDLQ).
+// A key da mensagem de DLQ é a key original (aggregateId).
+type DLQRecord struct {
+	OriginalTopic     string `json:"originalTopic"`
+	OriginalPartition int    `json:"originalPartition"`
+	OriginalOffset    int64  `json:"originalOffset"`
+
+	// Classification (messaging.ClassPermanent | ClassTransient) e Attempts: tentativas antes do DLQ
+	Error          string `json:"error"`
+	Classification string `json:"classification,omitempty"`
+	Attempts       int    `json:"attempts,omitempty"`
+
+	TS string `json:"ts"` // RFC3339Nano
+
+	// ContentType do valor original (header contentType; vazio = JSON).
+	ContentType string `json:"contentType,omitempty"`
+
+	// RawValue guarda o valor original quando ele não é JSON válido (Value não o comporta).
+	Value    json.RawMessage `json:"value,omitempty"`
+	RawValue []byte          `json:"rawValue,omitempty"`
+}
+
+// SetOriginalValue preenche Value ou RawValue conforme o valor original seja JSON válido.
+func (r *DLQRecord) SetOriginalValue(v []byte) {
+	if json.Valid(v) {
+		r.Value = json.RawMessage(v)
+		return
+	}
+	r.RawValue = v
+}
+
+// OriginalValue é o valor da mensagem original, como foi consumido.
+func (r DLQRecord) OriginalValue() []byte {
+	if len(r.Value) > 0 {
+		return r.Value
+	}
+	return r.RawValue
+}
+
+func (r DLQRecord) Time() (time.Time, error) {
+	return time.Parse(time.RFC3339Nano, r.TS)
+}
+
+// DLQMessage é uma mensagem lida do DLQ com sua posição no próprio tópico de DLQ.
+type DLQMessage struct {
+	Partition int
+	Offset    int64
+	Key       []byte
+	Record    DLQRecord
+	// DecodeErr != nil quando o valor não é um DLQRecord válido (Record vem zerado).
+	DecodeErr error
+}
+
+// ReadDLQ percorre todas as partições do tópico do início até o high watermark
+// observado na chamada (mensagens novas não entram) e chama fn na ordem de cada
+// partição. Não usa consumer group: não interfere em offsets de ninguém.
+func ReadDLQ(ctx context.Context, brokers []string, topic string, fn func(DLQMessage) error) error {
+	if len(brokers) == 0 || topic == "" {
+		return errors.New("read dlq: brokers and topic are required")
+	}
+
+	conn, err := kafka.DialContext(ctx, "tcp", brokers[0])
+	if err != nil {
+		return fmt.Errorf("read dlq: dial: %w", err)
+	}
+	partitions, err := conn.ReadPartitions(topic)
+	_ = conn.Close()
+	if err != nil {
+		return fmt.Errorf("read dlq: partitions of %s: %w", topic, err)
+	}
+
+	for _, p := range partitions {
+		if err := readPartition(ctx, brokers, topic, p.ID, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readPartition(ctx context.Context, brokers []string, topic string, partition int, fn func(DLQMessage) error) error {
+	pc, err := kafka.DialLeader(ctx, "tcp", brokers[0], topic, partition)
+	if err != nil {
+		return fmt.Errorf("read dlq: leader %s/%d: %w", topic, partition, err)
+	}
+	first, last, err := pc.ReadOffsets()
+	_ = pc.Close()
+	if err != nil {
+		return fmt.Errorf("read dlq: offsets %s/%d: %w", topic, partition, err)
+	}
+	if first >= last {
+		return nil
+	}
+
+	r := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:   brokers,
+		Topic:     topic,
+		Partition: partition,
+		MaxWait:   250 * time.Millisecond,
+	})
+	defer r.Close()
+
+	if err := r.SetOffset(first); err != nil {
+		return fmt.Errorf("read dlq: seek %s/%d: %w", topic, partition, err)
+	}
+
+	for {
+		msg, err := r.ReadMessage(ctx)
+		if err != nil {
+			return fmt.Errorf("read dlq: %s/%d: %w", topic, partition, err)
+		}
+
+		m := DLQMessage{Partition: msg.Partition, Offset: msg.Offset, Key: msg.Key}
+		m.DecodeErr = json.Unmarshal(msg.Value, &m.Record)
+		if err := fn(m); err != nil {
+			return err
+		}
+
+		if msg.Offset+1 >= last {
+			return nil
+		}
+	}
+}
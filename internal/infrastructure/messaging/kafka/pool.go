@@ -0,0 +1,210 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// offsetTracker registra, por partição, os offsets buscados (em ordem) e os já
+// processados. O offset comitável é o maior processado sem nenhum buraco antes
+// dele: um crash nunca comita além de um evento ainda não processado.
+type offsetTracker struct {
+	mu         sync.Mutex
+	partitions map[int]*partitionOffsets
+}
+
+type partitionOffsets struct {
+	topic string
+	// gen muda quando a partição volta a um offset já visto (rebalance/redelivery):
+	// conclusões de mensagens da geração anterior são ignoradas.
+	gen      uint64
+	inFlight []int64 // ordem de fetch (crescente)
+	done     map[int64]bool
+	// committable: maior offset contíguo processado ainda não comitado (-1 = nada)
+	committable int64
+}
+
+func newOffsetTracker() *offsetTracker {
+	return &offsetTracker{partitions: map[int]*partitionOffsets{}}
+}
+
+// fetched registra a mensagem e devolve a geração da partição.
+func (t *offsetTracker) fetched(msg kafka.Message) uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p, ok := t.partitions[msg.Partition]
+	if !ok {
+		p = &partitionOffsets{topic: msg.Topic, done: map[int64]bool{}, committable: -1}
+		t.partitions[msg.Partition] = p
+	}
+	if n := len(p.inFlight); (n > 0 && msg.Offset <= p.inFlight[n-1]) || msg.Offset <= p.committable {
+		p.gen++
+		p.inFlight = nil
+		p.done = map[int64]bool{}
+		p.committable = -1
+	}
+	p.inFlight = append(p.inFlight, msg.Offset)
+	return p.gen
+}
+
+func (t *offsetTracker) processed(msg kafka.Message, gen uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p := t.partitions[msg.Partition]
+	if p == nil || p.gen != gen {
+		return
+	}
+	p.done[msg.Offset] = true
+	for len(p.inFlight) > 0 && p.done[p.inFlight[0]] {
+		p.committable = p.inFlight[0]
+		delete(p.done, p.inFlight[0])
+		p.inFlight = p.inFlight[1:]
+	}
+}
+
+// drain devolve uma mensagem por partição com o offset comitável e zera o pendente.
+func (t *offsetTracker) drain() []kafka.Message {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var out []kafka.Message
+	for partition, p := range t.partitions {
+		if p.committable < 0 {
+			continue
+		}
+		out = append(out, kafka.Message{Topic: p.topic, Partition: partition, Offset: p.committable})
+		p.committable = -1
+	}
+	return out
+}
+
+// consumeParallel distribui as mensagens entre Workers goroutines pelo hash da key
+// (AggregateID): eventos da mesma arena caem sempre no mesmo worker, em ordem.
+// Commits são periódicos (CommitInterval) e só até o offset contíguo processado.
+func (c *Consumer) consumeParallel(ctx context.Context, r *kafka.Reader, handle func(kafka.Message) error) error {
+	workers := c.cfg.Workers
+	tracker := newOffsetTracker()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		fatalErr error
+	)
+	fail := func(err error) {
+		errOnce.Do(func() { fatalErr = err })
+		cancel()
+	}
+
+	type job struct {
+		msg kafka.Message
+		gen uint64
+	}
+
+	queues := make([]chan job, workers)
+	for i := range queues {
+		queues[i] = make(chan job, c.cfg.WorkerQueueSize)
+		wg.Add(1)
+		go func(q <-chan job) {
+			defer wg.Done()
+			for j := range q {
+				msg := j.msg
+				if ctx.Err() != nil {
+					continue // shutdown: não processa nem marca (volta no próximo start)
+				}
+				if err := handle(msg); err != nil {
+					if ctx.Err() == nil {
+						fail(err)
+					}
+					continue
+				}
+				tracker.processed(msg, j.gen)
+			}
+		}(queues[i])
+	}
+
+	commit := func(commitCtx context.Context) error {
+		msgs := tracker.drain()
+		if len(msgs) == 0 {
+			return nil
+		}
+		return r.CommitMessages(commitCtx, msgs...)
+	}
+
+	commitEvery := c.cfg.CommitInterval
+	if commitEvery <= 0 {
+		commitEvery = time.Second
+	}
+	committerDone := make(chan struct{})
+	go func() {
+		defer close(committerDone)
+		ticker := time.NewTicker(commitEvery)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := commit(ctx); err != nil && ctx.Err() == nil {
+					fail(err)
+					return
+				}
+			}
+		}
+	}()
+
+	log.Printf("[worker] parallel consumption: topic=%s workers=%d", r.Config().Topic, workers)
+
+	for ctx.Err() == nil {
+		readCtx, readCancel := context.WithTimeout(ctx, c.cfg.ReadTimeout)
+		msg, err := r.FetchMessage(readCtx)
+		readCancel()
+
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+				continue
+			}
+			fail(err)
+			break
+		}
+
+		gen := tracker.fetched(msg)
+		select {
+		case queues[workerFor(msg, workers)] <- job{msg: msg, gen: gen}:
+		case <-ctx.Done():
+		}
+	}
+
+	for _, q := range queues {
+		close(q)
+	}
+	wg.Wait()
+	<-committerDone
+
+	// comita o que terminou antes do shutdown
+	commitCtx, commitCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer commitCancel()
+	if err := commit(commitCtx); err != nil && fatalErr == nil {
+		log.Printf("[worker] final commit: %v", err)
+	}
+	return fatalErr
+}
+
+func workerFor(msg kafka.Message, workers int) int {
+	if len(msg.Key) == 0 {
+		return msg.Partition % workers
+	}
+	h := fnv.New32a()
+	_, _ = h.Write(msg.Key)
+	return int(h.Sum32() % uint32(workers))
+}
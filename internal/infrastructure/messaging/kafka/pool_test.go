@@ -0,0 +1,116 @@
+package kafka
+
+import (
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+)
+
+func TestOffsetTracker(t *testing.T) {
+	// op: f = fetched (gen esperada), p = processed (gen em que a mensagem foi buscada), d = drain
+	type op struct {
+		kind      byte
+		partition int
+		offset    int64
+		gen       uint64
+		// want: offset comitável por partição no drain (ausente = nada a comitar)
+		want map[int]int64
+	}
+	f := func(partition int, offset int64, gen uint64) op {
+		return op{kind: 'f', partition: partition, offset: offset, gen: gen}
+	}
+	p := func(partition int, offset int64, gen uint64) op {
+		return op{kind: 'p', partition: partition, offset: offset, gen: gen}
+	}
+	d := func(want map[int]int64) op { return op{kind: 'd', want: want} }
+
+	tests := []struct {
+		name string
+		ops  []op
+	}{
+		{
+			name: "nothing processed",
+			ops:  []op{f(0, 10, 0), f(0, 11, 0), d(nil)},
+		},
+		{
+			name: "in order",
+			ops:  []op{f(0, 10, 0), f(0, 11, 0), p(0, 10, 0), p(0, 11, 0), d(map[int]int64{0: 11}), d(nil)},
+		},
+		{
+			name: "hole holds the commit back",
+			ops: []op{
+				f(0, 10, 0), f(0, 11, 0), f(0, 12, 0),
+				p(0, 11, 0), p(0, 12, 0), d(nil),
+				p(0, 10, 0), d(map[int]int64{0: 12}),
+			},
+		},
+		{
+			name: "contiguous prefix only",
+			ops:  []op{f(0, 10, 0), f(0, 11, 0), f(0, 12, 0), p(0, 10, 0), p(0, 12, 0), d(map[int]int64{0: 10})},
+		},
+		{
+			name: "offset gaps from compaction",
+			ops:  []op{f(0, 10, 0), f(0, 15, 0), p(0, 15, 0), p(0, 10, 0), d(map[int]int64{0: 15})},
+		},
+		{
+			name: "partitions are independent",
+			ops: []op{
+				f(0, 10, 0), f(1, 20, 0), f(0, 11, 0), f(1, 21, 0),
+				p(1, 20, 0), p(0, 11, 0), d(map[int]int64{1: 20}),
+				p(0, 10, 0), p(1, 21, 0), d(map[int]int64{0: 11, 1: 21}),
+			},
+		},
+		{
+			name: "redelivery starts a new generation",
+			ops: []op{
+				f(0, 10, 0), f(0, 11, 0),
+				// rebalance: a partição volta ao offset 10 antes de 10/11 terminarem
+				f(0, 10, 1),
+				// conclusões da geração anterior são ignoradas
+				p(0, 10, 0), p(0, 11, 0), d(nil),
+				f(0, 11, 1), p(0, 10, 1), p(0, 11, 1), d(map[int]int64{0: 11}),
+			},
+		},
+		{
+			name: "redelivery below the committable offset",
+			ops: []op{
+				f(0, 10, 0), f(0, 11, 0), p(0, 10, 0), p(0, 11, 0),
+				f(0, 11, 1), d(nil),
+				p(0, 11, 1), d(map[int]int64{0: 11}),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := newOffsetTracker()
+			for i, o := range tt.ops {
+				msg := kafka.Message{Topic: "arena", Partition: o.partition, Offset: o.offset}
+				switch o.kind {
+				case 'f':
+					if gen := tr.fetched(msg); gen != o.gen {
+						t.Fatalf("op %d: fetched(%d/%d) gen = %d, want %d", i, o.partition, o.offset, gen, o.gen)
+					}
+				case 'p':
+					tr.processed(msg, o.gen)
+				case 'd':
+					got := map[int]int64{}
+					for _, m := range tr.drain() {
+						if m.Topic != "arena" {
+							t.Fatalf("op %d: drained topic %q, want arena", i, m.Topic)
+						}
+						got[m.Partition] = m.Offset
+					}
+					if len(got) != len(o.want) {
+						t.Fatalf("op %d: drain = %v, want %v", i, got, o.want)
+					}
+					for partition, off := range o.want {
+						if got[partition] != off {
+							t.Fatalf("op %d: drain = %v, want %v", i, got, o.want)
+						}
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/petri-board-arena/internal/application/port"
+	"github.com/petri-board-arena/internal/infrastructure/messaging"
+)
+
+type ProducerConfig struct {
+	Brokers      []string
+	BatchTimeout time.Duration
+	WriteTimeout time.Duration
+	// Codec do valor publicado; nil publica o envelope JSON do outbox como está.
+	Codec messaging.EnvelopeCodec
+}
+
+// Producer publica eventos do outbox. A key da mensagem é o AggregateID e o
+// balancer Hash mantém todos os eventos de uma arena na mesma partição (ordem preservada).
+// O outbox guarda o envelope em JSON; com outro codec (protobuf) ele é recodificado
+// aqui e o header contentType diz ao consumidor como ler.
+type Producer struct {
+	writer *kafka.Writer
+	codec  messaging.EnvelopeCodec
+}
+
+func NewProducer(cfg ProducerConfig) *Producer {
+	w := &kafka.Writer{
+		Addr:         kafka.TCP(cfg.Brokers...),
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+		BatchTimeout: cfg.BatchTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+		// retries ficam com o outbox (backoff persistido)
+		MaxAttempts: 1,
+	}
+	return &Producer{writer: w, codec: cfg.Codec}
+}
+
+func (p *Producer) Close() error {
+	return p.writer.Close()
+}
+
+// Publish grava as mensagens em uma única chamada (ordem mantida por partição).
+// Retorna nil se todas foram aceitas; caso contrário um erro por evento (nil = publicado).
+// Os eventos chegam em ordem por aggregate: se um não puder ser codificado, ele e os
+// seguintes não são enviados (falham sem furar a ordem).
+func (p *Producer) Publish(ctx context.Context, events []port.OutboxEvent) []error {
+	msgs := make([]kafka.Message, 0, len(events))
+	var encodeErr error
+	for _, ev := range events {
+		m, err := p.toMessage(ev)
+		if err != nil {
+			encodeErr = fmt.Errorf("encode event %s: %w", ev.ID, err)
+			break
+		}
+		msgs = append(msgs, m)
+	}
+
+	var err error
+	if len(msgs) > 0 {
+		err = p.writer.WriteMessages(ctx, msgs...)
+	}
+	if err == nil && encodeErr == nil {
+		return nil
+	}
+
+	errs := make([]error, len(events))
+	var werrs kafka.WriteErrors
+	switch {
+	case err == nil:
+	case errors.As(err, &werrs) && len(werrs) == len(msgs):
+		copy(errs, werrs)
+	default:
+		for i := range msgs {
+			errs[i] = err
+		}
+	}
+	for i := len(msgs); i < len(events); i++ {
+		errs[i] = encodeErr
+	}
+	return errs
+}
+
+func (p *Producer) toMessage(ev port.OutboxEvent) (kafka.Message, error) {
+	value, contentType := []byte(ev.Payload), messaging.ContentTypeJSON
+	if p.codec != nil && p.codec.ContentType() != messaging.ContentTypeJSON {
+		env, err := messaging.JSONEnvelopeCodec{}.Decode(ev.Payload)
+		if err != nil {
+			return kafka.Message{}, fmt.Errorf("decode outbox envelope: %w", err)
+		}
+		if value, err = p.codec.Encode(env); err != nil {
+			return kafka.Message{}, err
+		}
+		contentType = p.codec.ContentType()
+	}
+
+	headers := []kafka.Header{
+		{Key: "eventId", Value: []byte(ev.ID.String())},
+		{Key: "eventType", Value: []byte(ev.EventType)},
+		{Key: "aggregateType", Value: []byte(ev.AggregateType)},
+		{Key: messaging.HeaderContentType, Value: []byte(contentType)},
+	}
+	optional := func(key string, v *string) {
+		if v != nil {
+			headers = append(headers, kafka.Header{Key: key, Value: []byte(*v)})
+		}
+	}
+	optional("correlationId", ev.CorrelationID)
+	optional("causationId", ev.CausationID)
+	optional("idempotencyKey", ev.IdempotencyKey)
+
+	// demais headers do outbox complementam os de rastreio (contentType é o do valor publicado)
+	var extra map[string]string
+	if len(ev.Headers) > 0 && json.Unmarshal(ev.Headers, &extra) == nil {
+		keys := make([]string, 0, len(extra))
+		for k := range extra {
+			if k != "eventType" && k != messaging.HeaderContentType {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			headers = append(headers, kafka.Header{Key: k, Value: []byte(extra[k])})
+		}
+	}
+
+	return kafka.Message{
+		Topic:   ev.Topic,
+		Key:     []byte(ev.AggregateID),
+		Value:   value,
+		Headers: headers,
+		Time:    ev.CreatedAt,
+	}, nil
+}
@@ -0,0 +1,156 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// retryHoldKeyPrefix: retry:hold:<aggregateId> = lista com os eventos da arena em retry,
+// na ordem original; retry:hold:<aggregateId>:tier = tier do primeiro deles.
+const (
+	retryHoldKeyPrefix  = "retry:hold:"
+	retryHoldTierSuffix = ":tier"
+)
+
+// maxHoldTxRetries: novas tentativas quando outro loop (principal ou tier) mexe na
+// mesma arena entre o WATCH e o EXEC.
+const maxHoldTxRetries = 5
+
+// RetryHolds guarda, por arena, a fila dos eventos que estão nos tópicos de retry, na
+// ordem em que chegaram ao tópico principal. Enquanto a fila não está vazia os eventos
+// seguintes da arena entram no fim dela, e um evento só é aplicado quando é o primeiro:
+// os outros voltam para o tier do primeiro e esperam a vez. A ordem vem da fila, não da
+// posição das mensagens nos tópicos de retry.
+//
+// O estado fica no Redis (sobrevive a restart e vale para vários workers) e expira em
+// TTL contado do último progresso do primeiro da fila: se ele se perder (ex.: crash entre
+// registrar e encaminhar), a arena volta a ser processada normalmente depois do TTL em
+// vez de travar para sempre.
+type RetryHolds struct {
+	rdb *redis.Client
+	ttl time.Duration
+}
+
+// NewRetryHolds usa como TTL o caminho mais longo de um evento pelos tiers, com folga.
+func NewRetryHolds(rdb *redis.Client, tiers []time.Duration) *RetryHolds {
+	ttl := time.Minute
+	for _, d := range tiers {
+		ttl += 2 * d
+	}
+	return &RetryHolds{rdb: rdb, ttl: ttl}
+}
+
+type holdState struct {
+	queue []string
+	tier  int
+}
+
+// Hold coloca o evento no fim da fila se a arena tiver eventos em retry e devolve o
+// tier do primeiro, para onde ele deve ir; held = false significa que pode ser aplicado.
+func (h *RetryHolds) Hold(ctx context.Context, aggregateID, eventID string) (tier int, held bool, err error) {
+	err = h.update(ctx, aggregateID, func(st holdState, pipe redis.Pipeliner, key string) {
+		tier, held = 0, false
+		if len(st.queue) == 0 {
+			return
+		}
+		tier, held = st.tier, true
+		if !slices.Contains(st.queue, eventID) {
+			pipe.RPush(ctx, key, eventID)
+		}
+	})
+	return tier, held, err
+}
+
+// Park registra que o evento foi para o tier por falha. Vindo do tópico principal ele
+// entra na fila; sendo o primeiro, o tier dele passa a ser o da fila.
+func (h *RetryHolds) Park(ctx context.Context, aggregateID, eventID string, tier int) error {
+	return h.update(ctx, aggregateID, func(st holdState, pipe redis.Pipeliner, key string) {
+		i := slices.Index(st.queue, eventID)
+		if i < 0 {
+			pipe.RPush(ctx, key, eventID)
+			if len(st.queue) > 0 {
+				return
+			}
+		} else if i > 0 {
+			return
+		}
+		pipe.Set(ctx, key+retryHoldTierSuffix, tier, 0)
+		h.refresh(ctx, pipe, key)
+	})
+}
+
+// Turn diz se o evento precisa esperar: wait = true quando há eventos da arena antes
+// dele na fila, com o tier do primeiro. Fora da fila (hold expirado) ele é aplicado.
+func (h *RetryHolds) Turn(ctx context.Context, aggregateID, eventID string) (headTier int, wait bool, err error) {
+	st, err := h.read(ctx, h.rdb, retryHoldKeyPrefix+aggregateID)
+	if err != nil {
+		return 0, false, err
+	}
+	if i := slices.Index(st.queue, eventID); i > 0 {
+		return st.tier, true, nil
+	}
+	return 0, false, nil
+}
+
+// Release tira da fila o evento que saiu dos tiers (aplicado ou enviado ao DLQ).
+func (h *RetryHolds) Release(ctx context.Context, aggregateID, eventID string) error {
+	return h.update(ctx, aggregateID, func(st holdState, pipe redis.Pipeliner, key string) {
+		i := slices.Index(st.queue, eventID)
+		switch {
+		case i < 0:
+		case len(st.queue) == 1:
+			pipe.Del(ctx, key, key+retryHoldTierSuffix)
+		default:
+			pipe.LRem(ctx, key, 1, eventID)
+			if i == 0 {
+				h.refresh(ctx, pipe, key)
+			}
+		}
+	})
+}
+
+func (h *RetryHolds) refresh(ctx context.Context, pipe redis.Pipeliner, key string) {
+	pipe.Expire(ctx, key, h.ttl)
+	pipe.Expire(ctx, key+retryHoldTierSuffix, h.ttl)
+}
+
+// update lê o estado sob WATCH e aplica as escritas de fn em MULTI/EXEC.
+func (h *RetryHolds) update(ctx context.Context, aggregateID string, fn func(st holdState, pipe redis.Pipeliner, key string)) error {
+	key := retryHoldKeyPrefix + aggregateID
+	for attempt := 0; attempt < maxHoldTxRetries; attempt++ {
+		err := h.rdb.Watch(ctx, func(tx *redis.Tx) error {
+			st, err := h.read(ctx, tx, key)
+			if err != nil {
+				return err
+			}
+			_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+				fn(st, pipe, key)
+				return nil
+			})
+			return err
+		}, key, key+retryHoldTierSuffix)
+		if !errors.Is(err, redis.TxFailedErr) {
+			return err
+		}
+	}
+	return fmt.Errorf("retry hold %s: too many concurrent updates", aggregateID)
+}
+
+func (h *RetryHolds) read(ctx context.Context, c redis.Cmdable, key string) (holdState, error) {
+	queue, err := c.LRange(ctx, key, 0, -1).Result()
+	if err != nil {
+		return holdState{}, err
+	}
+	tierVal, err := c.Get(ctx, key+retryHoldTierSuffix).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return holdState{}, err
+	}
+	tier, _ := strconv.Atoi(tierVal)
+	return holdState{queue: queue, tier: tier}, nil
+}
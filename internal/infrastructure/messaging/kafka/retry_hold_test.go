@@ -0,0 +1,203 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/segmentio/kafka-go"
+)
+
+func testHolds(t *testing.T) (*RetryHolds, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+	return NewRetryHolds(rdb, []time.Duration{time.Second, 10 * time.Second}), mr
+}
+
+// Percorre o caminho de uma arena: h falha e sobe pelos tiers; a fica retido no
+// tier 0 enquanto h já está no tier 1, e b chega depois direto no tier 1. A fila
+// garante h, a, b mesmo com b lido antes de a.
+func TestRetryHoldsKeepsAggregateOrder(t *testing.T) {
+	holds, mr := testHolds(t)
+	ctx := context.Background()
+	const agg = "arena-1"
+
+	mustHold := func(id string, wantTier int, wantHeld bool) {
+		t.Helper()
+		tier, held, err := holds.Hold(ctx, agg, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if held != wantHeld || (held && tier != wantTier) {
+			t.Fatalf("Hold(%s) = tier %d held %v, want tier %d held %v", id, tier, held, wantTier, wantHeld)
+		}
+	}
+	mustTurn := func(id string, wantTier int, wantWait bool) {
+		t.Helper()
+		tier, wait, err := holds.Turn(ctx, agg, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if wait != wantWait || (wait && tier != wantTier) {
+			t.Fatalf("Turn(%s) = tier %d wait %v, want tier %d wait %v", id, tier, wait, wantTier, wantWait)
+		}
+	}
+	must := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// sem nada em retry, o evento é aplicado
+	mustHold("h", 0, false)
+
+	// h falha no tópico principal e vai para o tier 0; a chega e fica atrás dele
+	must(holds.Park(ctx, agg, "h", 0))
+	mustHold("a", 0, true)
+
+	// h falha no tier 0 e sobe para o tier 1; a (ainda no tier 0) não é o primeiro
+	must(holds.Park(ctx, agg, "h", 1))
+	mustTurn("a", 1, true)
+
+	// b chega pelo tópico principal antes de a sair do tier 0 e vai para o tier 1
+	mustHold("b", 1, true)
+	// b é lido no tier 1 antes de a: espera
+	mustTurn("b", 1, true)
+	mustTurn("h", 0, false)
+
+	// h aplicado: a é o primeiro, b continua esperando
+	must(holds.Release(ctx, agg, "h"))
+	mustTurn("b", 1, true)
+	mustTurn("a", 0, false)
+	// novos eventos continuam retidos enquanto há fila
+	mustHold("c", 1, true)
+
+	for _, id := range []string{"a", "b", "c"} {
+		mustTurn(id, 0, false)
+		must(holds.Release(ctx, agg, id))
+	}
+	mustHold("d", 0, false)
+	if mr.Exists(retryHoldKeyPrefix+agg) || mr.Exists(retryHoldKeyPrefix+agg+retryHoldTierSuffix) {
+		t.Fatal("hold keys left behind after the last release")
+	}
+}
+
+func TestRetryHoldsHoldIsIdempotent(t *testing.T) {
+	holds, _ := testHolds(t)
+	ctx := context.Background()
+
+	if err := holds.Park(ctx, "arena-1", "h", 0); err != nil {
+		t.Fatal(err)
+	}
+	// a mesma mensagem reentregue pelo tópico principal não entra duas vezes na fila
+	for i := 0; i < 2; i++ {
+		if _, held, err := holds.Hold(ctx, "arena-1", "a"); err != nil || !held {
+			t.Fatalf("Hold = held %v err %v, want held", held, err)
+		}
+	}
+	for _, id := range []string{"h", "a"} {
+		if err := holds.Release(ctx, "arena-1", id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, held, err := holds.Hold(ctx, "arena-1", "b"); err != nil || held {
+		t.Fatalf("Hold after releasing the queue = held %v err %v, want not held", held, err)
+	}
+}
+
+func TestRetryHoldsExpire(t *testing.T) {
+	holds, mr := testHolds(t)
+	ctx := context.Background()
+
+	if err := holds.Park(ctx, "arena-1", "h", 0); err != nil {
+		t.Fatal(err)
+	}
+	// novos eventos retidos não renovam o TTL: só o progresso do primeiro
+	mr.FastForward(holds.ttl / 2)
+	if _, held, err := holds.Hold(ctx, "arena-1", "a"); err != nil || !held {
+		t.Fatalf("Hold = held %v err %v, want held", held, err)
+	}
+	// o primeiro se perdeu: depois do TTL a arena não fica travada
+	mr.FastForward(holds.ttl/2 + time.Second)
+
+	if _, held, err := holds.Hold(ctx, "arena-1", "b"); err != nil || held {
+		t.Fatalf("Hold after TTL = held %v err %v, want not held", held, err)
+	}
+	if _, wait, err := holds.Turn(ctx, "arena-1", "a"); err != nil || wait {
+		t.Fatalf("Turn after TTL = wait %v err %v, want applied", wait, err)
+	}
+}
+
+func TestRetryHoldsIsolatesAggregates(t *testing.T) {
+	holds, _ := testHolds(t)
+	ctx := context.Background()
+
+	if err := holds.Park(ctx, "arena-1", "h", 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, held, err := holds.Hold(ctx, "arena-2", "a"); err != nil || held {
+		t.Fatalf("Hold(arena-2) = held %v err %v, want not held", held, err)
+	}
+}
+
+func TestForwardHeadersKeepProducerHeaders(t *testing.T) {
+	msg := kafka.Message{Headers: []kafka.Header{
+		{Key: "eventId", Value: []byte("e1")},
+		{Key: "eventType", Value: []byte("ArenaStarted")},
+		{Key: "contentType", Value: []byte("application/x-protobuf")},
+		{Key: HeaderReplayedFromDLQ, Value: []byte("arena.dlq/0/7")},
+		// de um encaminhamento anterior: são reescritos
+		{Key: HeaderRetryAttempts, Value: []byte("1")},
+		{Key: HeaderLastError, Value: []byte("old")},
+	}}
+	o := origin{topic: "arena.events", partition: 2, offset: 40}
+
+	tests := []struct {
+		name  string
+		cause error
+		want  map[string]string
+	}{
+		{
+			name:  "failed",
+			cause: errors.New("redis down"),
+			want: map[string]string{
+				"eventId": "e1", "eventType": "ArenaStarted", "contentType": "application/x-protobuf",
+				HeaderReplayedFromDLQ: "arena.dlq/0/7", HeaderRetryAttempts: "2", HeaderLastError: "redis down",
+				HeaderOriginalTopic: "arena.events", HeaderOriginalPartition: "2", HeaderOriginalOffset: "40",
+			},
+		},
+		{
+			name: "held",
+			want: map[string]string{
+				"eventId": "e1", "contentType": "application/x-protobuf", HeaderRetryAttempts: "2",
+				HeaderRetryHeld: "true", HeaderLastError: "",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := map[string]string{}
+			count := map[string]int{}
+			for _, h := range forwardHeaders(msg, o, 2, time.Now(), tt.cause) {
+				got[h.Key] = string(h.Value)
+				count[h.Key]++
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Fatalf("header %s = %q, want %q (all: %v)", k, got[k], v, got)
+				}
+			}
+			for k, n := range count {
+				if n > 1 {
+					t.Fatalf("header %s appears %d times", k, n)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,351 @@
+package messaging
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Codificação protobuf dos payloads: cada (evento, versão) tem uma mensagem gerada de
+// proto/arena/v1/events.proto (arenapb) e o struct Go do payload é copiado de/para
+// ela pelo número de campo da tag `proto:"N"`. checkProtoMapping garante, no registro
+// do codec, que struct e .proto não divergem (número, nome JSON, tipo, presença).
+//
+// Mapeamento Go → proto:
+//   string → string; int/int64 → int64; float64 → double; bool → bool;
+//   time.Time → google.protobuf.Timestamp; struct → message; ponteiro → optional;
+//   slice → repeated; []byte/json.RawMessage → bytes.
+
+// ProtoField descreve um campo da mensagem protobuf (publicado no schema registry).
+type ProtoField struct {
+	Number   int          `json:"number"`
+	Name     string       `json:"name"`
+	Type     string       `json:"type"`
+	Repeated bool         `json:"repeated,omitempty"`
+	Optional bool         `json:"optional,omitempty"`
+	Message  []ProtoField `json:"message,omitempty"`
+}
+
+var timestampName = (&timestamppb.Timestamp{}).ProtoReflect().Descriptor().FullName()
+
+type protoFieldInfo struct {
+	number protoreflect.FieldNumber
+	index  int
+}
+
+func protoFields(t reflect.Type) ([]protoFieldInfo, error) {
+	var out []protoFieldInfo
+	seen := map[int]string{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("proto")
+		if tag == "" || !f.IsExported() {
+			continue
+		}
+		n, err := strconv.Atoi(tag)
+		if err != nil || !protoreflect.FieldNumber(n).IsValid() {
+			return nil, fmt.Errorf("%s.%s: invalid proto field number %q", t.Name(), f.Name, tag)
+		}
+		if other, ok := seen[n]; ok {
+			return nil, fmt.Errorf("%s: proto field number %d used by %s and %s", t.Name(), n, other, f.Name)
+		}
+		seen[n] = f.Name
+		out = append(out, protoFieldInfo{number: protoreflect.FieldNumber(n), index: i})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].number < out[j].number })
+	return out, nil
+}
+
+// ProtoDescriptorOf descreve a mensagem gerada md no formato do schema registry.
+func ProtoDescriptorOf(md protoreflect.MessageDescriptor) []ProtoField {
+	fields := md.Fields()
+	out := make([]ProtoField, 0, fields.Len())
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		pf := ProtoField{
+			Number:   int(fd.Number()),
+			Name:     fd.JSONName(),
+			Type:     protoKindName(fd),
+			Repeated: fd.IsList(),
+			Optional: fd.HasOptionalKeyword(),
+		}
+		if pf.Type == "message" {
+			pf.Message = ProtoDescriptorOf(fd.Message())
+		}
+		out = append(out, pf)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Number < out[j].Number })
+	return out
+}
+
+func protoKindName(fd protoreflect.FieldDescriptor) string {
+	switch fd.Kind() {
+	case protoreflect.MessageKind:
+		if fd.Message().FullName() == timestampName {
+			return "timestamp"
+		}
+		return "message"
+	case protoreflect.Int64Kind:
+		return "int64"
+	case protoreflect.Uint64Kind:
+		return "uint64"
+	}
+	return fd.Kind().String()
+}
+
+func goProtoTypeName(t reflect.Type) string {
+	switch {
+	case t == timeType:
+		return "timestamp"
+	case isBytes(t):
+		return "bytes"
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "int64"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "uint64"
+	case reflect.Float32, reflect.Float64:
+		return "double"
+	case reflect.Struct:
+		return "message"
+	}
+	return "unsupported:" + t.Kind().String()
+}
+
+func isBytes(t reflect.Type) bool {
+	return t == rawMessageType || (t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8)
+}
+
+func jsonName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return f.Name
+	}
+	return name
+}
+
+// checkProtoMapping confere o struct t contra a mensagem md nos dois sentidos: todo
+// campo com tag existe na mensagem com o mesmo nome JSON, tipo e presença (ponteiro =
+// optional), e todo campo da mensagem tem um campo Go (senão seria perdido na leitura).
+func checkProtoMapping(t reflect.Type, md protoreflect.MessageDescriptor) error {
+	fields, err := protoFields(t)
+	if err != nil {
+		return err
+	}
+	var problems []string
+	mapped := make(map[protoreflect.FieldNumber]bool, len(fields))
+	for _, fi := range fields {
+		f := t.Field(fi.index)
+		fd := md.Fields().ByNumber(fi.number)
+		if fd == nil {
+			problems = append(problems, fmt.Sprintf("%s.%s: field %d not in %s", t.Name(), f.Name, fi.number, md.FullName()))
+			continue
+		}
+		mapped[fi.number] = true
+
+		ft, optional := f.Type, false
+		if ft.Kind() == reflect.Pointer {
+			ft, optional = ft.Elem(), true
+		}
+		repeated := ft.Kind() == reflect.Slice && !isBytes(ft)
+		if repeated {
+			ft = ft.Elem()
+		}
+		switch {
+		case fd.JSONName() != jsonName(f):
+			problems = append(problems, fmt.Sprintf("%s.%s: json name %q, proto %s is %q", t.Name(), f.Name, jsonName(f), fd.FullName(), fd.JSONName()))
+		case goProtoTypeName(ft) != protoKindName(fd) || repeated != fd.IsList():
+			problems = append(problems, fmt.Sprintf("%s.%s: type %s, proto %s is %s", t.Name(), f.Name, goProtoTypeName(ft), fd.FullName(), protoKindName(fd)))
+		case optional != fd.HasOptionalKeyword():
+			problems = append(problems, fmt.Sprintf("%s.%s: optional=%t, proto %s optional=%t", t.Name(), f.Name, optional, fd.FullName(), fd.HasOptionalKeyword()))
+		case protoKindName(fd) == "message":
+			if err := checkProtoMapping(ft, fd.Message()); err != nil {
+				problems = append(problems, err.Error())
+			}
+		}
+	}
+	for i := 0; i < md.Fields().Len(); i++ {
+		if fd := md.Fields().Get(i); !mapped[fd.Number()] {
+			problems = append(problems, fmt.Sprintf("%s: proto field %s (%d) has no Go field", t.Name(), fd.FullName(), fd.Number()))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("proto mapping %s: %s", md.FullName(), strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// ----------------------------
+// Go struct → mensagem
+// ----------------------------
+
+// marshalPayload codifica v (ponteiro para o struct do payload) com a mensagem mt.
+func marshalPayload(v any, mt protoreflect.MessageType) ([]byte, error) {
+	m := mt.New()
+	if err := toProto(reflect.ValueOf(v).Elem(), m); err != nil {
+		return nil, err
+	}
+	return proto.Marshal(m.Interface())
+}
+
+// toProto segue o proto3: escalares zero não vão para o fio; ponteiros (optional)
+// preservam presença.
+func toProto(rv reflect.Value, m protoreflect.Message) error {
+	fields, err := protoFields(rv.Type())
+	if err != nil {
+		return err
+	}
+	for _, fi := range fields {
+		fd := m.Descriptor().Fields().ByNumber(fi.number)
+		if fd == nil {
+			return fmt.Errorf("%s: field %d not in %s", rv.Type().Name(), fi.number, m.Descriptor().FullName())
+		}
+		fv := rv.Field(fi.index)
+		present := false
+		if fv.Kind() == reflect.Pointer {
+			if fv.IsNil() {
+				continue
+			}
+			fv, present = fv.Elem(), true
+		}
+		if !present && fv.IsZero() {
+			continue
+		}
+
+		if fd.IsList() {
+			list := m.Mutable(fd).List()
+			for i := 0; i < fv.Len(); i++ {
+				val, err := protoValue(fv.Index(i), fd, list.NewElement)
+				if err != nil {
+					return err
+				}
+				list.Append(val)
+			}
+			continue
+		}
+		val, err := protoValue(fv, fd, func() protoreflect.Value { return m.NewField(fd) })
+		if err != nil {
+			return err
+		}
+		m.Set(fd, val)
+	}
+	return nil
+}
+
+func protoValue(v reflect.Value, fd protoreflect.FieldDescriptor, newMessage func() protoreflect.Value) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.MessageKind:
+		if fd.Message().FullName() == timestampName {
+			return protoreflect.ValueOfMessage(timestamppb.New(v.Interface().(time.Time)).ProtoReflect()), nil
+		}
+		msg := newMessage()
+		if err := toProto(v, msg.Message()); err != nil {
+			return protoreflect.Value{}, err
+		}
+		return msg, nil
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(v.String()), nil
+	case protoreflect.BoolKind:
+		return protoreflect.ValueOfBool(v.Bool()), nil
+	case protoreflect.Int64Kind:
+		return protoreflect.ValueOfInt64(v.Int()), nil
+	case protoreflect.Uint64Kind:
+		return protoreflect.ValueOfUint64(v.Uint()), nil
+	case protoreflect.DoubleKind:
+		return protoreflect.ValueOfFloat64(v.Float()), nil
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes(v.Bytes()), nil
+	}
+	return protoreflect.Value{}, fmt.Errorf("proto field %s: unsupported kind %s", fd.FullName(), fd.Kind())
+}
+
+// ----------------------------
+// mensagem → Go struct
+// ----------------------------
+
+// unmarshalPayload decodifica b com a mensagem mt em v (ponteiro para o struct do
+// payload). Campos desconhecidos (produtor mais novo) são ignorados.
+func unmarshalPayload(b []byte, mt protoreflect.MessageType, v any) error {
+	m := mt.New()
+	if err := proto.Unmarshal(b, m.Interface()); err != nil {
+		return err
+	}
+	return fromProto(m, reflect.ValueOf(v).Elem())
+}
+
+func fromProto(m protoreflect.Message, rv reflect.Value) error {
+	fields, err := protoFields(rv.Type())
+	if err != nil {
+		return err
+	}
+	for _, fi := range fields {
+		fd := m.Descriptor().Fields().ByNumber(fi.number)
+		if fd == nil {
+			return fmt.Errorf("%s: field %d not in %s", rv.Type().Name(), fi.number, m.Descriptor().FullName())
+		}
+		if !m.Has(fd) {
+			continue
+		}
+		fv := rv.Field(fi.index)
+		if fv.Kind() == reflect.Pointer {
+			fv.Set(reflect.New(fv.Type().Elem()))
+			fv = fv.Elem()
+		}
+
+		if fd.IsList() {
+			list := m.Get(fd).List()
+			s := reflect.MakeSlice(fv.Type(), list.Len(), list.Len())
+			for i := 0; i < list.Len(); i++ {
+				if err := setGoValue(s.Index(i), fd, list.Get(i)); err != nil {
+					return err
+				}
+			}
+			fv.Set(s)
+			continue
+		}
+		if err := setGoValue(fv, fd, m.Get(fd)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setGoValue(v reflect.Value, fd protoreflect.FieldDescriptor, val protoreflect.Value) error {
+	switch fd.Kind() {
+	case protoreflect.MessageKind:
+		if fd.Message().FullName() == timestampName {
+			var ts timestamppb.Timestamp
+			proto.Merge(&ts, val.Message().Interface())
+			v.Set(reflect.ValueOf(ts.AsTime().UTC()))
+			return nil
+		}
+		return fromProto(val.Message(), v)
+	case protoreflect.StringKind:
+		v.SetString(val.String())
+	case protoreflect.BoolKind:
+		v.SetBool(val.Bool())
+	case protoreflect.Int64Kind:
+		v.SetInt(val.Int())
+	case protoreflect.Uint64Kind:
+		v.SetUint(val.Uint())
+	case protoreflect.DoubleKind:
+		v.SetFloat(val.Float())
+	case protoreflect.BytesKind:
+		v.SetBytes(append([]byte(nil), val.Bytes()...))
+	default:
+		return fmt.Errorf("proto field %s: unsupported kind %s", fd.FullName(), fd.Kind())
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+// Package schemaregistry é um schema registry local baseado em arquivos: guarda o
+// schema publicado de cada (evento, versão) em <dir>/<Evento>/v<N>.json e confere se
+// os codecs do código continuam compatíveis com ele. Faz o papel de um registry
+// remoto (Confluent/Apicurio) em CI e testes.
+package schemaregistry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/petri-board-arena/internal/infrastructure/messaging"
+)
+
+var (
+	ErrNotRegistered = errors.New("schema not registered")
+	ErrIncompatible  = errors.New("incompatible schema")
+)
+
+// Schema é o conteúdo de um arquivo do registry. Protobuf vem do descriptor gerado de
+// proto/arena/v1 (ProtoMessage), não do struct Go.
+type Schema struct {
+	Subject      string                 `json:"subject"`
+	Version      int                    `json:"version"`
+	JSONSchema   *messaging.JSONSchema  `json:"jsonSchema"`
+	ProtoMessage string                 `json:"protoMessage,omitempty"`
+	Protobuf     []messaging.ProtoField `json:"protobuf"`
+}
+
+// SchemaOf monta o Schema de um codec registrado.
+func SchemaOf(c *messaging.EventCodec) Schema {
+	return Schema{
+		Subject:      c.Name,
+		Version:      c.Version,
+		JSONSchema:   c.Schema,
+		ProtoMessage: string(c.ProtoMessage()),
+		Protobuf:     c.ProtoDescriptor(),
+	}
+}
+
+type FileRegistry struct {
+	dir string
+}
+
+func NewFileRegistry(dir string) *FileRegistry { return &FileRegistry{dir: dir} }
+
+func (r *FileRegistry) path(subject string, version int) string {
+	return filepath.Join(r.dir, subject, "v"+strconv.Itoa(version)+".json")
+}
+
+// Get lê o schema de (subject, version); ErrNotRegistered se não existir.
+func (r *FileRegistry) Get(subject string, version int) (Schema, error) {
+	b, err := os.ReadFile(r.path(subject, version))
+	if errors.Is(err, os.ErrNotExist) {
+		return Schema{}, fmt.Errorf("%w: %s v%d", ErrNotRegistered, subject, version)
+	}
+	if err != nil {
+		return Schema{}, err
+	}
+	var s Schema
+	if err := json.Unmarshal(b, &s); err != nil {
+		return Schema{}, fmt.Errorf("%s: %w", r.path(subject, version), err)
+	}
+	return s, nil
+}
+
+// CheckCompatibility compara s com o schema já registrado da mesma versão.
+func (r *FileRegistry) CheckCompatibility(s Schema) error {
+	old, err := r.Get(s.Subject, s.Version)
+	if err != nil {
+		return err
+	}
+	if problems := Compare(old, s); len(problems) > 0 {
+		return fmt.Errorf("%w: %s v%d:\n  %s", ErrIncompatible, s.Subject, s.Version, strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+// Register grava s. Uma versão já registrada só pode ser sobrescrita por um schema
+// compatível (ex.: campo opcional novo).
+func (r *FileRegistry) Register(s Schema) error {
+	if err := r.CheckCompatibility(s); err != nil && !errors.Is(err, ErrNotRegistered) {
+		return err
+	}
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	p := r.path(s.Subject, s.Version)
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(p, append(b, '\n'), 0o644)
+}
+
+// CheckCodecs confere todos os codecs de reg contra o registry. Com write, registra
+// as versões novas (e atualiza as compatíveis); sem write, versão não registrada é erro.
+func (r *FileRegistry) CheckCodecs(reg *messaging.CodecRegistry, write bool) error {
+	var errs []error
+	for _, c := range reg.Codecs() {
+		s := SchemaOf(c)
+		var err error
+		if write {
+			err = r.Register(s)
+		} else {
+			err = r.CheckCompatibility(s)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Compare lista o que em next quebra produtores ou consumidores de old:
+//   - protobuf: número de campo removido ou reaproveitado com outro nome/tipo;
+//   - JSON: tipo alterado, campo novo obrigatório, ou campo obrigatório que deixou de ser.
+func Compare(old, next Schema) []string {
+	var problems []string
+	compareProto("", old.Protobuf, next.Protobuf, &problems)
+	compareJSON("$", old.JSONSchema, next.JSONSchema, &problems)
+	sort.Strings(problems)
+	return problems
+}
+
+func compareProto(path string, old, next []messaging.ProtoField, problems *[]string) {
+	byNumber := make(map[int]messaging.ProtoField, len(next))
+	for _, f := range next {
+		byNumber[f.Number] = f
+	}
+	for _, o := range old {
+		p := fmt.Sprintf("%s%d(%s)", path, o.Number, o.Name)
+		n, ok := byNumber[o.Number]
+		if !ok {
+			*problems = append(*problems, fmt.Sprintf("protobuf field %s removed (reserve the number instead of reusing it)", p))
+			continue
+		}
+		switch {
+		case n.Name != o.Name:
+			*problems = append(*problems, fmt.Sprintf("protobuf field %s renamed to %s", p, n.Name))
+		case n.Type != o.Type || n.Repeated != o.Repeated:
+			*problems = append(*problems, fmt.Sprintf("protobuf field %s changed type %s to %s", p, label(o), label(n)))
+		case o.Type == "message":
+			compareProto(p+".", o.Message, n.Message, problems)
+		}
+	}
+}
+
+func label(f messaging.ProtoField) string {
+	if f.Repeated {
+		return "repeated " + f.Type
+	}
+	return f.Type
+}
+
+func compareJSON(path string, old, next *messaging.JSONSchema, problems *[]string) {
+	if old == nil || next == nil {
+		return
+	}
+	if old.Type != next.Type {
+		*problems = append(*problems, fmt.Sprintf("json %s changed type %q to %q", path, old.Type, next.Type))
+		return
+	}
+	for _, req := range next.Required {
+		if !slices.Contains(old.Required, req) {
+			*problems = append(*problems, fmt.Sprintf("json %s.%s became required", path, req))
+		}
+	}
+	// consumidores da versão antiga exigem o campo
+	for _, req := range old.Required {
+		if !slices.Contains(next.Required, req) {
+			*problems = append(*problems, fmt.Sprintf("json %s.%s no longer required", path, req))
+		}
+	}
+	for name, o := range old.Properties {
+		if n, ok := next.Properties[name]; ok {
+			compareJSON(path+"."+name, o, n, problems)
+		}
+	}
+	compareJSON(path+"[]", old.Items, next.Items, problems)
+}
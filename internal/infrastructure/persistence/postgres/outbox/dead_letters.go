@@ -0,0 +1,231 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"github.com/petri-board-arena/internal/application/port"
+	"github.com/petri-board-arena/internal/runtime/requestctx"
+)
+
+const (
+	defaultDeadLetterLimit = 100
+	maxDeadLetterLimit     = 1000
+)
+
+var _ port.OutboxDeadLetterAdmin = (*Store)(nil)
+
+func (s *Store) ListDeadLetters(ctx context.Context, f port.OutboxDeadLetterFilter) ([]port.OutboxDeadLetter, error) {
+	where, args := deadLetterWhere(f)
+	args = append(args, limitOf(f), max(f.Offset, 0))
+
+	rows, err := s.q(ctx).QueryContext(ctx, fmt.Sprintf(`
+		SELECT
+			id, outbox_event_id, aggregate_type, aggregate_id, event_type, topic,
+			payload, headers, correlation_id, causation_id, idempotency_key,
+			attempts, last_error, created_at
+		FROM outbox_dead_letter
+		WHERE %s
+		ORDER BY created_at, id
+		LIMIT $%d OFFSET $%d
+	`, where, len(args)-1, len(args)), args...)
+	if err != nil {
+		return nil, fmt.Errorf("outbox dead letters: list: %w", err)
+	}
+	defer rows.Close()
+
+	var out []port.OutboxDeadLetter
+	for rows.Next() {
+		var (
+			dl      port.OutboxDeadLetter
+			payload []byte
+			headers []byte
+		)
+		err := rows.Scan(
+			&dl.ID, &dl.OutboxEventID, &dl.AggregateType, &dl.AggregateID, &dl.EventType, &dl.Topic,
+			&payload, &headers, &dl.CorrelationID, &dl.CausationID, &dl.IdempotencyKey,
+			&dl.Attempts, &dl.LastError, &dl.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("outbox dead letters: scan: %w", err)
+		}
+		dl.Payload = payload
+		dl.Headers = headers
+		out = append(out, dl)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("outbox dead letters: list: %w", err)
+	}
+	return out, nil
+}
+
+// RequeueDeadLetters reinsere em outbox_event com o id original (consumidores
+// deduplicam por eventId) e a mesma idempotency_key. O novo seq fica depois dos
+// eventos já publicados do aggregate: a ordem original não é recuperável.
+func (s *Store) RequeueDeadLetters(ctx context.Context, f port.OutboxDeadLetterFilter, actor, reason string) (int, error) {
+	return s.adminDeadLetters(ctx, port.DeadLetterRequeue, f, actor, reason, `
+		, requeued AS (
+			INSERT INTO outbox_event (
+				id, aggregate_type, aggregate_id, event_type, topic,
+				payload, headers, correlation_id, causation_id, idempotency_key,
+				status, attempts, next_attempt_at
+			)
+			SELECT
+				new_event_id, aggregate_type, aggregate_id, event_type, topic,
+				payload, headers, correlation_id, causation_id, idempotency_key,
+				'PENDING', 0, now()
+			FROM picked
+			ORDER BY created_at, id
+			RETURNING id
+		)`)
+}
+
+func (s *Store) PurgeDeadLetters(ctx context.Context, f port.OutboxDeadLetterFilter, actor, reason string) (int, error) {
+	return s.adminDeadLetters(ctx, port.DeadLetterPurge, f, actor, reason, "")
+}
+
+// adminDeadLetters seleciona (com lock), executa a etapa específica da ação,
+// audita e remove os dead letters em uma única instrução.
+func (s *Store) adminDeadLetters(
+	ctx context.Context,
+	action port.OutboxDeadLetterAction,
+	f port.OutboxDeadLetterFilter,
+	actor, reason, step string,
+) (int, error) {
+	op := strings.ToLower(string(action))
+	if f.Empty() {
+		return 0, fmt.Errorf("outbox dead letters: %s: %w", op, port.ErrEmptyDeadLetterFilter)
+	}
+	if strings.TrimSpace(actor) == "" {
+		return 0, fmt.Errorf("outbox dead letters: %s: actor is required", op)
+	}
+
+	where, args := deadLetterWhere(f)
+	n := len(args)
+	args = append(args, limitOf(f), string(action), actor, nullIfEmpty(reason), correlationID(ctx))
+
+	outboxEventID := "NULL::uuid"
+	if action == port.DeadLetterRequeue {
+		outboxEventID = "new_event_id"
+	}
+
+	var affected int
+	err := s.withinTx(ctx, func(q queryer) error {
+		return q.QueryRowContext(ctx, fmt.Sprintf(`
+			WITH picked AS (
+				SELECT d.*, COALESCE(d.outbox_event_id, gen_random_uuid()) AS new_event_id
+				FROM outbox_dead_letter d
+				WHERE %[1]s
+				ORDER BY d.created_at, d.id
+				LIMIT $%[2]d
+				FOR UPDATE SKIP LOCKED
+			)%[3]s
+			, audit AS (
+				INSERT INTO outbox_dead_letter_audit (
+					action, dead_letter_id, outbox_event_id,
+					aggregate_type, aggregate_id, event_type, idempotency_key, attempts, last_error,
+					actor, reason, correlation_id
+				)
+				SELECT
+					$%[4]d, id, %[5]s,
+					aggregate_type, aggregate_id, event_type, idempotency_key, attempts, last_error,
+					$%[6]d, $%[7]d, $%[8]d
+				FROM picked
+			), removed AS (
+				DELETE FROM outbox_dead_letter d
+				USING picked p
+				WHERE d.id = p.id
+				RETURNING d.id
+			)
+			SELECT count(*) FROM removed
+		`, where, n+1, step, n+2, outboxEventID, n+3, n+4, n+5), args...).Scan(&affected)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("outbox dead letters: %s: %w", op, err)
+	}
+
+	log.Printf("[outbox-admin] action=%s actor=%q reason=%q affected=%d filter=%s",
+		action, actor, reason, affected, describeFilter(f))
+	return affected, nil
+}
+
+// deadLetterWhere monta o WHERE parametrizado ($1..$n) a partir do filtro.
+func deadLetterWhere(f port.OutboxDeadLetterFilter) (string, []any) {
+	conds := []string{"TRUE"}
+	var args []any
+	add := func(cond string, v any) {
+		args = append(args, v)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+
+	if len(f.IDs) > 0 {
+		ids := make([]string, len(f.IDs))
+		for i, id := range f.IDs {
+			ids[i] = id.String()
+		}
+		add("id = ANY($%d::uuid[])", pq.StringArray(ids))
+	}
+	if f.AggregateID != nil {
+		add("aggregate_id = $%d", *f.AggregateID)
+	}
+	if f.EventType != nil {
+		add("event_type = $%d", *f.EventType)
+	}
+	if f.From != nil {
+		add("created_at >= $%d", f.From.UTC())
+	}
+	if f.To != nil {
+		add("created_at < $%d", f.To.UTC())
+	}
+	return strings.Join(conds, " AND "), args
+}
+
+func limitOf(f port.OutboxDeadLetterFilter) int {
+	switch {
+	case f.Limit <= 0:
+		return defaultDeadLetterLimit
+	case f.Limit > maxDeadLetterLimit:
+		return maxDeadLetterLimit
+	default:
+		return f.Limit
+	}
+}
+
+func describeFilter(f port.OutboxDeadLetterFilter) string {
+	var parts []string
+	if len(f.IDs) > 0 {
+		parts = append(parts, fmt.Sprintf("ids=%v", f.IDs))
+	}
+	if f.AggregateID != nil {
+		parts = append(parts, "aggregateId="+*f.AggregateID)
+	}
+	if f.EventType != nil {
+		parts = append(parts, "eventType="+*f.EventType)
+	}
+	if f.From != nil {
+		parts = append(parts, "from="+f.From.UTC().Format("2006-01-02T15:04:05Z"))
+	}
+	if f.To != nil {
+		parts = append(parts, "to="+f.To.UTC().Format("2006-01-02T15:04:05Z"))
+	}
+	parts = append(parts, fmt.Sprintf("limit=%d", limitOf(f)))
+	return "{" + strings.Join(parts, " ") + "}"
+}
+
+func correlationID(ctx context.Context) any {
+	if id, ok := requestctx.CorrelationIDFrom(ctx); ok {
+		return id
+	}
+	return nil
+}
+
+func nullIfEmpty(s string) any {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	return s
+}
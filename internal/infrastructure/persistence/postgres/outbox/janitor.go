@@ -0,0 +1,227 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type JanitorMode string
+
+const (
+	JanitorDelete  JanitorMode = "delete"
+	JanitorArchive JanitorMode = "archive"
+)
+
+const archivePartitionPrefix = "outbox_event_archive_y"
+
+type JanitorConfig struct {
+	Mode JanitorMode
+	// Retention: eventos PUBLISHED há mais tempo que isso saem de outbox_event.
+	Retention time.Duration
+	// BatchSize limita as linhas por transação (locks curtos); MaxBatches limita cada execução.
+	BatchSize  int
+	MaxBatches int
+	// ArchiveRetention remove partições mensais do arquivo mais antigas que isso (0 = mantém).
+	ArchiveRetention time.Duration
+}
+
+type JanitorResult struct {
+	Purged            int64
+	Archived          int64
+	Batches           int
+	DroppedPartitions []string
+}
+
+// Janitor limpa outbox_event. Cada lote roda em sua própria transação com
+// SKIP LOCKED, então pode rodar em paralelo ao relay (e em várias instâncias).
+type Janitor struct {
+	db  *sql.DB
+	cfg JanitorConfig
+}
+
+func NewJanitor(db *sql.DB, cfg JanitorConfig) (*Janitor, error) {
+	if cfg.Mode != JanitorDelete && cfg.Mode != JanitorArchive {
+		return nil, fmt.Errorf("outbox janitor: invalid mode %q (delete|archive)", cfg.Mode)
+	}
+	if cfg.Retention <= 0 || cfg.BatchSize <= 0 || cfg.MaxBatches <= 0 {
+		return nil, errors.New("outbox janitor: retention, batchSize and maxBatches must be > 0")
+	}
+	return &Janitor{db: db, cfg: cfg}, nil
+}
+
+func (j *Janitor) Mode() JanitorMode { return j.cfg.Mode }
+
+func (j *Janitor) Run(ctx context.Context) (JanitorResult, error) {
+	var res JanitorResult
+	cutoff := time.Now().UTC().Add(-j.cfg.Retention)
+
+	if j.cfg.Mode == JanitorArchive {
+		if err := j.ensurePartitions(ctx, cutoff); err != nil {
+			return res, fmt.Errorf("outbox janitor: partitions: %w", err)
+		}
+	}
+
+	for res.Batches < j.cfg.MaxBatches {
+		n, err := j.runBatch(ctx, cutoff)
+		if err != nil {
+			return res, fmt.Errorf("outbox janitor: batch %d: %w", res.Batches+1, err)
+		}
+		res.Batches++
+		res.Purged += n
+		if j.cfg.Mode == JanitorArchive {
+			res.Archived += n
+		}
+		if n < int64(j.cfg.BatchSize) {
+			break
+		}
+	}
+
+	if j.cfg.Mode == JanitorArchive && j.cfg.ArchiveRetention > 0 {
+		dropped, err := j.dropExpiredPartitions(ctx, time.Now().UTC().Add(-j.cfg.ArchiveRetention))
+		res.DroppedPartitions = dropped
+		if err != nil {
+			return res, fmt.Errorf("outbox janitor: drop partitions: %w", err)
+		}
+	}
+	return res, nil
+}
+
+// runBatch remove (ou move para o arquivo) até BatchSize linhas em uma única instrução.
+func (j *Janitor) runBatch(ctx context.Context, cutoff time.Time) (int64, error) {
+	const victims = `
+		WITH victims AS (
+			SELECT id
+			FROM outbox_event
+			WHERE status = 'PUBLISHED' AND published_at < $1
+			ORDER BY published_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)`
+
+	query := victims + `
+		DELETE FROM outbox_event o
+		USING victims v
+		WHERE o.id = v.id`
+
+	if j.cfg.Mode == JanitorArchive {
+		query = victims + `, moved AS (
+			DELETE FROM outbox_event o
+			USING victims v
+			WHERE o.id = v.id
+			RETURNING o.*
+		)
+		INSERT INTO outbox_event_archive (
+			id, seq, aggregate_type, aggregate_id, event_type, topic,
+			payload, headers, correlation_id, causation_id, idempotency_key,
+			attempts, published_at, created_at
+		)
+		SELECT
+			id, seq, aggregate_type, aggregate_id, event_type, topic,
+			payload, headers, correlation_id, causation_id, idempotency_key,
+			attempts, published_at, created_at
+		FROM moved`
+	}
+
+	res, err := j.db.ExecContext(ctx, query, cutoff, j.cfg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// ensurePartitions cria as partições mensais do evento publicado mais antigo até o cutoff.
+func (j *Janitor) ensurePartitions(ctx context.Context, cutoff time.Time) error {
+	var oldest sql.NullTime
+	err := j.db.QueryRowContext(ctx, `
+		SELECT min(published_at)
+		FROM outbox_event
+		WHERE status = 'PUBLISHED' AND published_at < $1
+	`, cutoff).Scan(&oldest)
+	if err != nil {
+		return err
+	}
+	if !oldest.Valid {
+		return nil
+	}
+
+	for m := monthStart(oldest.Time); !m.After(cutoff); m = m.AddDate(0, 1, 0) {
+		_, err := j.db.ExecContext(ctx, fmt.Sprintf(`
+			CREATE TABLE IF NOT EXISTS %s
+			PARTITION OF outbox_event_archive
+			FOR VALUES FROM (%s) TO (%s)`,
+			pq.QuoteIdentifier(partitionName(m)),
+			pq.QuoteLiteral(m.Format(time.RFC3339)),
+			pq.QuoteLiteral(m.AddDate(0, 1, 0).Format(time.RFC3339)),
+		))
+		if err != nil {
+			return fmt.Errorf("create partition %s: %w", partitionName(m), err)
+		}
+	}
+	return nil
+}
+
+// dropExpiredPartitions remove partições mensais cujo mês inteiro é anterior a before.
+func (j *Janitor) dropExpiredPartitions(ctx context.Context, before time.Time) ([]string, error) {
+	rows, err := j.db.QueryContext(ctx, `
+		SELECT c.relname
+		FROM pg_inherits i
+		JOIN pg_class c ON c.oid = i.inhrelid
+		JOIN pg_class p ON p.oid = i.inhparent
+		WHERE p.relname = 'outbox_event_archive'
+	`)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var dropped []string
+	for _, name := range names {
+		m, ok := partitionMonth(name)
+		if !ok || m.AddDate(0, 1, 0).After(before) {
+			continue
+		}
+		if _, err := j.db.ExecContext(ctx, `DROP TABLE IF EXISTS `+pq.QuoteIdentifier(name)); err != nil {
+			return dropped, fmt.Errorf("drop partition %s: %w", name, err)
+		}
+		dropped = append(dropped, name)
+	}
+	return dropped, nil
+}
+
+func monthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func partitionName(month time.Time) string {
+	return fmt.Sprintf("%s%04dm%02d", archivePartitionPrefix, month.Year(), int(month.Month()))
+}
+
+func partitionMonth(name string) (time.Time, bool) {
+	if !strings.HasPrefix(name, archivePartitionPrefix) {
+		return time.Time{}, false
+	}
+	m, err := time.Parse("2006m01", strings.TrimPrefix(name, archivePartitionPrefix))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return m, true
+}
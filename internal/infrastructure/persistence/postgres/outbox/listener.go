@@ -0,0 +1,74 @@
+package outbox
+
+import (
+	"log"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// NotifyChannel é o canal do trigger trg_notify_outbox_event (migration 000005).
+const NotifyChannel = "outbox_event"
+
+// listenerPingInterval detecta conexões mortas que o TCP ainda não reportou.
+const listenerPingInterval = 90 * time.Second
+
+// Listener converte NOTIFY outbox_event em sinais de wake-up para o relay.
+// Sinais são coalescidos (buffer 1): o relay drena tudo a cada wake-up.
+type Listener struct {
+	l    *pq.Listener
+	wake chan struct{}
+	done chan struct{}
+}
+
+func NewListener(dsn string, minReconnect, maxReconnect time.Duration) (*Listener, error) {
+	wake := make(chan struct{}, 1)
+
+	l := pq.NewListener(dsn, minReconnect, maxReconnect, func(ev pq.ListenerEventType, err error) {
+		switch ev {
+		case pq.ListenerEventDisconnected, pq.ListenerEventConnectionAttemptFailed:
+			log.Printf("[outbox-listener] connection lost (%v); relying on interval polling", err)
+		case pq.ListenerEventReconnected:
+			log.Printf("[outbox-listener] reconnected")
+		}
+	})
+	if err := l.Listen(NotifyChannel); err != nil {
+		_ = l.Close()
+		return nil, err
+	}
+
+	ln := &Listener{l: l, wake: wake, done: make(chan struct{})}
+	go ln.loop()
+	return ln, nil
+}
+
+// Wakeups recebe um sinal a cada NOTIFY e após cada reconexão (notificações podem ter se perdido).
+func (ln *Listener) Wakeups() <-chan struct{} { return ln.wake }
+
+func (ln *Listener) Close() error {
+	close(ln.done)
+	return ln.l.Close()
+}
+
+func (ln *Listener) loop() {
+	ticker := time.NewTicker(listenerPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ln.done:
+			return
+		case _, ok := <-ln.l.Notify:
+			if !ok {
+				return
+			}
+			// nil = reconexão; também acorda para cobrir o que chegou enquanto estava fora
+			select {
+			case ln.wake <- struct{}{}:
+			default:
+			}
+		case <-ticker.C:
+			go func() { _ = ln.l.Ping() }()
+		}
+	}
+}
@@ -0,0 +1,460 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/petri-board-arena/internal/application/port"
+	"github.com/petri-board-arena/internal/infrastructure/persistence/postgres"
+)
+
+var ErrEventNotLocked = errors.New("outbox event is not locked for processing")
+
+const (
+	defaultMaxAttempts = 10
+	// maxBackoff limita o crescimento exponencial entre tentativas.
+	maxBackoff = time.Hour
+)
+
+// eventColumns segue a ordem de scanEvent.
+var eventColumns = []string{
+	"id", "seq", "aggregate_type", "aggregate_id", "event_type", "topic",
+	"payload", "headers", "correlation_id", "causation_id", "idempotency_key",
+	"status", "attempts", "max_attempts", "next_attempt_at", "published_at", "last_error",
+	"locked_by", "locked_at", "lock_expires_at", "created_at", "updated_at",
+}
+
+type Store struct {
+	db *sql.DB
+}
+
+func NewStore(db *sql.DB) *Store { return &Store{db: db} }
+
+var _ port.OutboxStore = (*Store)(nil)
+
+type queryer interface {
+	QueryContext(context.Context, string, ...any) (*sql.Rows, error)
+	QueryRowContext(context.Context, string, ...any) *sql.Row
+	ExecContext(context.Context, string, ...any) (sql.Result, error)
+}
+
+func (s *Store) q(ctx context.Context) queryer {
+	if tx, ok := postgres.TxFrom(ctx); ok {
+		return tx
+	}
+	return s.db
+}
+
+// withinTx reaproveita a transação do context ou abre uma própria.
+func (s *Store) withinTx(ctx context.Context, fn func(q queryer) error) error {
+	if tx, ok := postgres.TxFrom(ctx); ok {
+		return fn(tx)
+	}
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// ----------------------------
+// Enqueue
+// ----------------------------
+
+func (s *Store) Enqueue(ctx context.Context, p port.OutboxEnqueueParams) error {
+	if p.ID == uuid.Nil {
+		return errors.New("outbox enqueue: id is required")
+	}
+	maxAttempts := p.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxAttempts
+	}
+	var nextAttemptAt any
+	if !p.NextAttemptAt.IsZero() {
+		nextAttemptAt = p.NextAttemptAt.UTC()
+	}
+
+	_, err := s.q(ctx).ExecContext(ctx, `
+		INSERT INTO outbox_event (
+			id, aggregate_type, aggregate_id, event_type, topic,
+			payload, headers, correlation_id, causation_id, idempotency_key,
+			max_attempts, next_attempt_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, COALESCE($12, now()))
+	`,
+		p.ID, p.AggregateType, p.AggregateID, p.EventType, p.Topic,
+		[]byte(p.Payload), jsonOrEmpty(p.Headers), p.CorrelationID, p.CausationID, p.IdempotencyKey,
+		maxAttempts, nextAttemptAt,
+	)
+	if err != nil {
+		return fmt.Errorf("outbox enqueue: %w", err)
+	}
+	return nil
+}
+
+// ----------------------------
+// Locking
+// ----------------------------
+
+// LockBatch marca como PROCESSING (lock de WorkerID por LockTTL) os eventos
+// PENDING/FAILED cujo next_attempt_at já passou e os PROCESSING cujo lock expirou
+// (worker morto). SKIP LOCKED permite vários workers concorrentes sem disputar
+// as mesmas linhas.
+//
+// Ordem por aggregate: um evento só é elegível se nenhum anterior (seq menor) do
+// mesmo aggregate estiver pendente e inelegível (reservado por outro worker ou
+// aguardando backoff). O advisory lock por aggregate impede que dois workers
+// reservem partes diferentes da fila do mesmo aggregate ao mesmo tempo.
+//
+// Antes de reservar, os locks expirados consomem uma tentativa (reclaimExpired), então
+// MaxAttempts também vale para eventos que derrubam o relay.
+func (s *Store) LockBatch(ctx context.Context, p port.OutboxLockParams) ([]port.OutboxEvent, error) {
+	if p.WorkerID == "" || p.BatchSize <= 0 || p.LockTTL <= 0 {
+		return nil, errors.New("outbox lock: workerID, batchSize and lockTTL are required")
+	}
+
+	var out []port.OutboxEvent
+	err := s.withinTx(ctx, func(q queryer) error {
+		if err := reclaimExpired(ctx, q); err != nil {
+			return fmt.Errorf("reclaim expired: %w", err)
+		}
+
+		rows, err := q.QueryContext(ctx, `
+			WITH due AS (
+				SELECT e.id
+				FROM outbox_event e
+				WHERE `+eligible("e")+`
+				  AND NOT EXISTS (
+					SELECT 1
+					FROM outbox_event prev
+					WHERE prev.aggregate_type = e.aggregate_type
+					  AND prev.aggregate_id = e.aggregate_id
+					  AND prev.seq < e.seq
+					  AND prev.status <> 'PUBLISHED'
+					  AND NOT (`+eligible("prev")+`)
+				  )
+				  AND pg_try_advisory_xact_lock(hashtextextended(e.aggregate_type || ':' || e.aggregate_id, 0))
+				ORDER BY e.seq
+				LIMIT $1
+				FOR UPDATE SKIP LOCKED
+			)
+			UPDATE outbox_event o SET
+				status          = 'PROCESSING',
+				locked_by       = $2,
+				locked_at       = now(),
+				lock_expires_at = now() + $3 * interval '1 millisecond'
+			FROM due
+			WHERE o.id = due.id
+			RETURNING `+columns("o"),
+			p.BatchSize, p.WorkerID, p.LockTTL.Milliseconds(),
+		)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		out = make([]port.OutboxEvent, 0, p.BatchSize)
+		for rows.Next() {
+			ev, err := scanEvent(rows)
+			if err != nil {
+				return fmt.Errorf("scan: %w", err)
+			}
+			out = append(out, ev)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("outbox lock: %w", err)
+	}
+
+	// RETURNING não garante ordem
+	sort.Slice(out, func(i, j int) bool { return out[i].Seq < out[j].Seq })
+	return out, nil
+}
+
+// reclaimExpired conta como tentativa o lock que expirou (o worker morreu ou travou
+// com o evento): sem isso um evento que derruba o relay seria reservado para sempre.
+// O evento volta como FAILED já elegível; ao atingir max_attempts vai para o dead-letter.
+func reclaimExpired(ctx context.Context, q queryer) error {
+	rows, err := q.QueryContext(ctx, `
+		WITH expired AS (
+			SELECT id
+			FROM outbox_event
+			WHERE status = 'PROCESSING' AND lock_expires_at < now()
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE outbox_event o SET
+			status          = 'FAILED',
+			attempts        = o.attempts + 1,
+			last_error      = 'lock expired (locked by ' || COALESCE(o.locked_by, '?') || ')',
+			next_attempt_at = now(),
+			locked_by       = NULL,
+			locked_at       = NULL,
+			lock_expires_at = NULL
+		FROM expired
+		WHERE o.id = expired.id
+		RETURNING `+columns("o"),
+	)
+	if err != nil {
+		return err
+	}
+
+	var exhausted []port.OutboxEvent
+	for rows.Next() {
+		ev, err := scanEvent(rows)
+		if err != nil {
+			rows.Close()
+			return fmt.Errorf("scan: %w", err)
+		}
+		if ev.Attempts >= ev.MaxAttempts {
+			exhausted = append(exhausted, ev)
+		}
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, ev := range exhausted {
+		if err := moveToDeadLetter(ctx, q, deadLetterFrom(ev, deref(ev.LastError))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ----------------------------
+// Publish outcome
+// ----------------------------
+
+func (s *Store) MarkPublished(ctx context.Context, eventID uuid.UUID, workerID string) error {
+	res, err := s.q(ctx).ExecContext(ctx, `
+		UPDATE outbox_event SET
+			status          = 'PUBLISHED',
+			published_at    = now(),
+			last_error      = NULL,
+			locked_by       = NULL,
+			locked_at       = NULL,
+			lock_expires_at = NULL
+		WHERE id = $1 AND `+lockedBy(2)+`
+	`, eventID, workerID)
+	if err != nil {
+		return fmt.Errorf("outbox mark published: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrEventNotLocked
+	}
+	return nil
+}
+
+// Release devolve os eventos para PENDING sem consumir tentativa (ex.: um evento
+// anterior do mesmo aggregate falhou e os seguintes não podem ser publicados antes dele).
+func (s *Store) Release(ctx context.Context, workerID string, eventIDs ...uuid.UUID) error {
+	if len(eventIDs) == 0 {
+		return nil
+	}
+	ids := make([]string, len(eventIDs))
+	for i, id := range eventIDs {
+		ids[i] = id.String()
+	}
+
+	_, err := s.q(ctx).ExecContext(ctx, `
+		UPDATE outbox_event SET
+			status          = 'PENDING',
+			locked_by       = NULL,
+			locked_at       = NULL,
+			lock_expires_at = NULL
+		WHERE id = ANY($1::uuid[]) AND `+lockedBy(2)+`
+	`, pq.StringArray(ids), workerID)
+	if err != nil {
+		return fmt.Errorf("outbox release: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed incrementa attempts e agenda a próxima tentativa em
+// now() + BaseBackoff * 2^(attempts-1), limitado a maxBackoff. Quando attempts
+// atinge max_attempts o evento é movido para outbox_dead_letter.
+func (s *Store) MarkFailed(ctx context.Context, p port.OutboxMarkFailedParams) (bool, error) {
+	if p.BaseBackoff <= 0 {
+		return false, errors.New("outbox mark failed: baseBackoff must be > 0")
+	}
+
+	deadLettered := false
+	err := s.withinTx(ctx, func(q queryer) error {
+		row := q.QueryRowContext(ctx, `
+			UPDATE outbox_event SET
+				status          = 'FAILED',
+				attempts        = attempts + 1,
+				last_error      = $2,
+				next_attempt_at = now() + LEAST($3 * power(2, attempts), $4) * interval '1 millisecond',
+				locked_by       = NULL,
+				locked_at       = NULL,
+				lock_expires_at = NULL
+			WHERE id = $1 AND `+lockedBy(5)+`
+			RETURNING `+columns(""),
+			p.EventID, p.LastErrorMsg, p.BaseBackoff.Milliseconds(), maxBackoff.Milliseconds(), p.WorkerID,
+		)
+
+		ev, err := scanEvent(row)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrEventNotLocked
+			}
+			return err
+		}
+
+		if ev.Attempts < ev.MaxAttempts {
+			return nil
+		}
+
+		deadLettered = true
+		return moveToDeadLetter(ctx, q, deadLetterFrom(ev, p.LastErrorMsg))
+	})
+	if err != nil {
+		return false, fmt.Errorf("outbox mark failed: %w", err)
+	}
+	return deadLettered, nil
+}
+
+// MoveToDeadLetter grava o dead-letter e remove o evento de outbox_event (se OutboxEventID vier preenchido).
+func (s *Store) MoveToDeadLetter(ctx context.Context, p port.OutboxDeadLetterParams) error {
+	err := s.withinTx(ctx, func(q queryer) error {
+		return moveToDeadLetter(ctx, q, p)
+	})
+	if err != nil {
+		return fmt.Errorf("outbox dead letter: %w", err)
+	}
+	return nil
+}
+
+func moveToDeadLetter(ctx context.Context, q queryer, p port.OutboxDeadLetterParams) error {
+	id := p.ID
+	if id == uuid.Nil {
+		id = uuid.New()
+	}
+
+	_, err := q.ExecContext(ctx, `
+		INSERT INTO outbox_dead_letter (
+			id, outbox_event_id, aggregate_type, aggregate_id, event_type, topic,
+			payload, headers, correlation_id, causation_id, idempotency_key,
+			attempts, last_error
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`,
+		id, p.OutboxEventID, p.AggregateType, p.AggregateID, p.EventType, p.Topic,
+		[]byte(p.Payload), jsonOrEmpty(p.Headers), p.CorrelationID, p.CausationID, p.IdempotencyKey,
+		p.Attempts, p.LastError,
+	)
+	if err != nil {
+		return err
+	}
+
+	if p.OutboxEventID != nil {
+		if _, err := q.ExecContext(ctx, `DELETE FROM outbox_event WHERE id = $1`, *p.OutboxEventID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ----------------------------
+// Helpers
+// ----------------------------
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanEvent(sc scanner) (port.OutboxEvent, error) {
+	var (
+		ev      port.OutboxEvent
+		payload []byte
+		headers []byte
+		status  string
+	)
+	err := sc.Scan(
+		&ev.ID, &ev.Seq, &ev.AggregateType, &ev.AggregateID, &ev.EventType, &ev.Topic,
+		&payload, &headers, &ev.CorrelationID, &ev.CausationID, &ev.IdempotencyKey,
+		&status, &ev.Attempts, &ev.MaxAttempts, &ev.NextAttemptAt, &ev.PublishedAt, &ev.LastError,
+		&ev.LockedBy, &ev.LockedAt, &ev.LockExpiresAt, &ev.CreatedAt, &ev.UpdatedAt,
+	)
+	if err != nil {
+		return port.OutboxEvent{}, err
+	}
+	ev.Payload = json.RawMessage(payload)
+	ev.Headers = json.RawMessage(headers)
+	ev.Status = port.OutboxStatus(status)
+	return ev, nil
+}
+
+func deadLetterFrom(ev port.OutboxEvent, lastErr string) port.OutboxDeadLetterParams {
+	id := ev.ID
+	return port.OutboxDeadLetterParams{
+		ID:             uuid.New(),
+		OutboxEventID:  &id,
+		AggregateType:  ev.AggregateType,
+		AggregateID:    ev.AggregateID,
+		EventType:      ev.EventType,
+		Topic:          ev.Topic,
+		Payload:        ev.Payload,
+		Headers:        ev.Headers,
+		CorrelationID:  ev.CorrelationID,
+		CausationID:    ev.CausationID,
+		IdempotencyKey: ev.IdempotencyKey,
+		Attempts:       ev.Attempts,
+		LastError:      lastErr,
+	}
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func jsonOrEmpty(b json.RawMessage) []byte {
+	if len(b) == 0 {
+		return []byte("{}")
+	}
+	return b
+}
+
+// eligible é o predicado de "pronto para reserva" sobre o alias informado.
+func eligible(alias string) string {
+	return fmt.Sprintf(`((%[1]s.status IN ('PENDING', 'FAILED') AND %[1]s.next_attempt_at <= now())
+				OR (%[1]s.status = 'PROCESSING' AND %[1]s.lock_expires_at < now()))`, alias)
+}
+
+// lockedBy restringe ao evento reservado pelo worker do parâmetro $n (vazio não verifica o dono).
+func lockedBy(n int) string {
+	return fmt.Sprintf(`status = 'PROCESSING' AND ($%[1]d = '' OR locked_by = $%[1]d)`, n)
+}
+
+// columns monta a lista de colunas, qualificada pelo alias quando informado.
+func columns(alias string) string {
+	if alias == "" {
+		return strings.Join(eventColumns, ", ")
+	}
+	qualified := make([]string, len(eventColumns))
+	for i, c := range eventColumns {
+		qualified[i] = alias + "." + c
+	}
+	return strings.Join(qualified, ", ")
+}
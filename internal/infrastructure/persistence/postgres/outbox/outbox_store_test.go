@@ -0,0 +1,169 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	_ "github.com/lib/pq"
+
+	"github.com/petri-board-arena/internal/application/port"
+)
+
+// Testes de integração: OUTBOX_TEST_DATABASE_URL aponta para um banco descartável com
+// as migrations de escrita aplicadas (make migrate-write-up). Sem a variável, pulam.
+func testStore(t *testing.T) (*Store, *sql.DB) {
+	t.Helper()
+	dsn := os.Getenv("OUTBOX_TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("OUTBOX_TEST_DATABASE_URL not set")
+	}
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := db.Ping(); err != nil {
+		t.Fatal(err)
+	}
+	return NewStore(db), db
+}
+
+// enqueue grava n eventos de um aggregate novo (aggregate_type único por teste).
+func enqueue(t *testing.T, s *Store, db *sql.DB, n, maxAttempts int) (aggregateType string, ids []uuid.UUID) {
+	t.Helper()
+	ctx := context.Background()
+	aggregateType = "test-" + uuid.NewString()
+	aggregateID := uuid.NewString()
+	for i := 0; i < n; i++ {
+		id := uuid.New()
+		err := s.Enqueue(ctx, port.OutboxEnqueueParams{
+			ID:            id,
+			AggregateType: aggregateType,
+			AggregateID:   aggregateID,
+			EventType:     "TestEvent",
+			Topic:         "test",
+			Payload:       []byte(`{}`),
+			MaxAttempts:   maxAttempts,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+	t.Cleanup(func() {
+		_, _ = db.Exec(`DELETE FROM outbox_event WHERE aggregate_type = $1`, aggregateType)
+		_, _ = db.Exec(`DELETE FROM outbox_dead_letter WHERE aggregate_type = $1`, aggregateType)
+	})
+	return aggregateType, ids
+}
+
+// lockOwn reserva e devolve só os eventos do aggregate do teste.
+func lockOwn(t *testing.T, s *Store, aggregateType, worker string) []port.OutboxEvent {
+	t.Helper()
+	batch, err := s.LockBatch(context.Background(), port.OutboxLockParams{
+		WorkerID:  worker,
+		BatchSize: 1000,
+		LockTTL:   time.Minute,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var own []port.OutboxEvent
+	for _, ev := range batch {
+		if ev.AggregateType == aggregateType {
+			own = append(own, ev)
+		}
+	}
+	return own
+}
+
+func expireLocks(t *testing.T, db *sql.DB, aggregateType string) {
+	t.Helper()
+	_, err := db.Exec(`
+		UPDATE outbox_event SET lock_expires_at = now() - interval '1 second'
+		WHERE aggregate_type = $1 AND status = 'PROCESSING'`, aggregateType)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLockBatch(t *testing.T) {
+	s, db := testStore(t)
+
+	t.Run("locks in seq order", func(t *testing.T) {
+		typ, ids := enqueue(t, s, db, 3, 10)
+		got := lockOwn(t, s, typ, "w1")
+		if len(got) != len(ids) {
+			t.Fatalf("locked %d events, want %d", len(got), len(ids))
+		}
+		for i, ev := range got {
+			if ev.ID != ids[i] || ev.Status != port.OutboxProcessing || ev.Attempts != 0 {
+				t.Fatalf("event %d = %s %s attempts=%d, want %s PROCESSING attempts=0", i, ev.ID, ev.Status, ev.Attempts, ids[i])
+			}
+		}
+		if again := lockOwn(t, s, typ, "w2"); len(again) != 0 {
+			t.Fatalf("w2 locked %d events already locked by w1", len(again))
+		}
+	})
+
+	t.Run("later events wait for a locked predecessor", func(t *testing.T) {
+		typ, ids := enqueue(t, s, db, 2, 10)
+		got := lockOwn(t, s, typ, "w1")
+		if len(got) != 2 {
+			t.Fatalf("locked %d events, want 2", len(got))
+		}
+		if err := s.Release(context.Background(), "w1", ids[1]); err != nil {
+			t.Fatal(err)
+		}
+		if again := lockOwn(t, s, typ, "w2"); len(again) != 0 {
+			t.Fatalf("w2 locked %s while %s is still locked by w1", again[0].ID, ids[0])
+		}
+	})
+
+	t.Run("expired lock consumes an attempt", func(t *testing.T) {
+		typ, ids := enqueue(t, s, db, 1, 10)
+		lockOwn(t, s, typ, "w1")
+		expireLocks(t, db, typ)
+
+		got := lockOwn(t, s, typ, "w2")
+		if len(got) != 1 || got[0].ID != ids[0] {
+			t.Fatalf("reclaimed %v, want %s", got, ids[0])
+		}
+		if got[0].Attempts != 1 || got[0].LastError == nil {
+			t.Fatalf("reclaimed attempts=%d lastError=%v, want 1 and the lock error", got[0].Attempts, got[0].LastError)
+		}
+		if got[0].LockedBy == nil || *got[0].LockedBy != "w2" {
+			t.Fatalf("reclaimed lockedBy=%v, want w2", got[0].LockedBy)
+		}
+	})
+
+	t.Run("expired lock at max attempts is dead-lettered", func(t *testing.T) {
+		typ, ids := enqueue(t, s, db, 1, 2)
+		for attempt := 1; attempt <= 2; attempt++ {
+			if got := lockOwn(t, s, typ, "w1"); len(got) != 1 {
+				t.Fatalf("attempt %d: locked %d events, want 1", attempt, len(got))
+			}
+			expireLocks(t, db, typ)
+		}
+
+		if got := lockOwn(t, s, typ, "w1"); len(got) != 0 {
+			t.Fatalf("locked %s after max attempts", got[0].ID)
+		}
+
+		var (
+			attempts  int
+			lastError string
+		)
+		err := db.QueryRow(`SELECT attempts, last_error FROM outbox_dead_letter WHERE outbox_event_id = $1`, ids[0]).Scan(&attempts, &lastError)
+		if err != nil {
+			t.Fatalf("dead letter of %s: %v", ids[0], err)
+		}
+		if attempts != 2 || lastError == "" {
+			t.Fatalf("dead letter attempts=%d lastError=%q, want 2 and the lock error", attempts, lastError)
+		}
+	})
+}
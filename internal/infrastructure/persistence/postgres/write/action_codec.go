@@ -0,0 +1,111 @@
+package write
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/petri-board-arena/internal/domain/arena"
+)
+
+type areaDTO struct {
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+type temperatureDTO struct {
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit"`
+}
+
+type pointDTO struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// actionPayloadDTO achata a union de payloads (coluna arena_scheduled_action.payload);
+// o type da ação diz quais campos valem.
+type actionPayloadDTO struct {
+	Area             *areaDTO        `json:"area,omitempty"`
+	Amount           int             `json:"amount,omitempty"`
+	Kind             string          `json:"kind,omitempty"`
+	Concentration    float64         `json:"concentration,omitempty"`
+	Temperature      *temperatureDTO `json:"temperature,omitempty"`
+	Position         *pointDTO       `json:"position,omitempty"`
+	GenomeTemplateID *string         `json:"genomeTemplateId,omitempty"`
+}
+
+func ActionPayloadToJSON(p arena.ActionPayload) ([]byte, error) {
+	var dto actionPayloadDTO
+	switch v := p.(type) {
+	case arena.AddNutrientsPayload:
+		dto.Area = toAreaDTO(v.Area)
+		dto.Amount = v.Amount
+	case arena.DropAntibioticPayload:
+		dto.Area = toAreaDTO(v.Area)
+		dto.Kind = string(v.Kind)
+		dto.Concentration = v.Concentration
+	case arena.SetTemperaturePayload:
+		dto.Temperature = &temperatureDTO{Value: v.Temperature.Value, Unit: string(v.Temperature.Unit)}
+	case arena.SpawnOrganismPayload:
+		dto.Kind = string(v.Kind)
+		dto.Position = &pointDTO{X: v.Position.X, Y: v.Position.Y}
+		dto.GenomeTemplateID = v.GenomeTemplateID
+	default:
+		return nil, fmt.Errorf("marshal action payload: unsupported %T", p)
+	}
+
+	b, err := json.Marshal(dto)
+	if err != nil {
+		return nil, fmt.Errorf("marshal action payload: %w", err)
+	}
+	return b, nil
+}
+
+func ActionPayloadFromJSON(t arena.ActionType, b []byte) (arena.ActionPayload, error) {
+	var dto actionPayloadDTO
+	if err := json.Unmarshal(b, &dto); err != nil {
+		return nil, fmt.Errorf("unmarshal action payload: %w", err)
+	}
+
+	switch t {
+	case arena.ActionAddNutrients:
+		return arena.AddNutrientsPayload{Area: fromAreaDTO(dto.Area), Amount: dto.Amount}, nil
+	case arena.ActionDropAntibiotic:
+		return arena.DropAntibioticPayload{
+			Area:          fromAreaDTO(dto.Area),
+			Kind:          arena.AntibioticKind(dto.Kind),
+			Concentration: dto.Concentration,
+		}, nil
+	case arena.ActionSetTemperature:
+		if dto.Temperature == nil {
+			return nil, fmt.Errorf("unmarshal action payload: %s without temperature", t)
+		}
+		return arena.SetTemperaturePayload{Temperature: arena.Temperature{
+			Value: dto.Temperature.Value,
+			Unit:  arena.TemperatureUnit(dto.Temperature.Unit),
+		}}, nil
+	case arena.ActionSpawnOrganism:
+		p := arena.SpawnOrganismPayload{
+			Kind:             arena.OrganismKind(dto.Kind),
+			GenomeTemplateID: dto.GenomeTemplateID,
+		}
+		if dto.Position != nil {
+			p.Position = arena.Point{X: dto.Position.X, Y: dto.Position.Y}
+		}
+		return p, nil
+	}
+	return nil, fmt.Errorf("unmarshal action payload: unknown action type %q", t)
+}
+
+func toAreaDTO(a arena.Area) *areaDTO {
+	return &areaDTO{X: a.X, Y: a.Y, Width: a.Width, Height: a.Height}
+}
+
+func fromAreaDTO(a *areaDTO) arena.Area {
+	if a == nil {
+		return arena.Area{}
+	}
+	return arena.Area{X: a.X, Y: a.Y, Width: a.Width, Height: a.Height}
+}
@@ -5,40 +5,43 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	uuid "github.com/google/uuid"
 
+	"github.com/petri-board-arena/internal/application/port/repository"
 	"github.com/petri-board-arena/internal/domain/arena"
 	"github.com/petri-board-arena/internal/infrastructure/persistence/postgres"
 )
 
-var ErrArenaNotFound = errors.New("arena not found")
+var ErrArenaNotFound = repository.ErrArenaNotFound
 
+// ArenaRepo persiste o aggregate inteiro: arena (+config), arena_player e
+// arena_scheduled_action. Save grava nas tabelas filhas só as mudanças dos eventos
+// pendentes e usa arena.version para concorrência otimista.
 type ArenaRepo struct {
 	db *sql.DB
 }
 
 func NewArenaRepo(db *sql.DB) *ArenaRepo { return &ArenaRepo{db: db} }
 
-func (r *ArenaRepo) GetByID(ctx context.Context, id arena.ID) (*arena.Arena, error) {
-	if tx, ok := postgres.TxFrom(ctx); ok {
-		return r.getByID(ctx, tx, id, true)
-	}
-	return r.getByID(ctx, r.db, id, false)
-}
-
 type queryer interface {
+	QueryContext(context.Context, string, ...any) (*sql.Rows, error)
 	QueryRowContext(context.Context, string, ...any) *sql.Row
 	ExecContext(context.Context, string, ...any) (sql.Result, error)
 }
 
-func (r *ArenaRepo) getByID(ctx context.Context, q queryer, id arena.ID, forUpdate bool) (*arena.Arena, error) {
-	lock := ""
-	if forUpdate {
-		lock = " FOR UPDATE"
+// GetByID não trava a linha: conflitos são detectados no Save pela versão
+// (ErrConcurrencyConflict) e o handler recarrega e tenta de novo.
+func (r *ArenaRepo) GetByID(ctx context.Context, id arena.ID) (*arena.Arena, error) {
+	if tx, ok := postgres.TxFrom(ctx); ok {
+		return r.getByID(ctx, tx, id)
 	}
+	return r.getByID(ctx, r.db, id)
+}
 
+func (r *ArenaRepo) getByID(ctx context.Context, q queryer, id arena.ID) (*arena.Arena, error) {
 	row := q.QueryRowContext(ctx, `
 		SELECT
 			id,
@@ -48,9 +51,10 @@ func (r *ArenaRepo) getByID(ctx context.Context, q queryer, id arena.ID, forUpda
 			started_at,
 			finished_at,
 			tick,
-			config_json
-		FROM arenas
-		WHERE id = $1`+lock, id,
+			config,
+			version
+		FROM arena
+		WHERE id = $1`, id,
 	)
 
 	var (
@@ -62,6 +66,7 @@ func (r *ArenaRepo) getByID(ctx context.Context, q queryer, id arena.ID, forUpda
 		finishedAt sql.NullTime
 		tick       int64
 		configJSON []byte
+		version    int64
 	)
 
 	if err := row.Scan(
@@ -73,6 +78,7 @@ func (r *ArenaRepo) getByID(ctx context.Context, q queryer, id arena.ID, forUpda
 		&finishedAt,
 		&tick,
 		&configJSON,
+		&version,
 	); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrArenaNotFound
@@ -82,7 +88,7 @@ func (r *ArenaRepo) getByID(ctx context.Context, q queryer, id arena.ID, forUpda
 
 	cfg, err := ConfigFromJSON(configJSON)
 	if err != nil {
-		return nil, fmt.Errorf("decode config_json: %w", err)
+		return nil, fmt.Errorf("decode config: %w", err)
 	}
 
 	st, err := arena.ParseStatus(statusStr)
@@ -90,17 +96,13 @@ func (r *ArenaRepo) getByID(ctx context.Context, q queryer, id arena.ID, forUpda
 		return nil, fmt.Errorf("parse status: %w", err)
 	}
 
-	var (
-		sAt *time.Time
-		fAt *time.Time
-	)
-	if startedAt.Valid {
-		t := startedAt.Time.UTC()
-		sAt = &t
+	players, err := r.loadPlayers(ctx, q, id)
+	if err != nil {
+		return nil, err
 	}
-	if finishedAt.Valid {
-		t := finishedAt.Time.UTC()
-		fAt = &t
+	scheduled, err := r.loadScheduled(ctx, q, id)
+	if err != nil {
+		return nil, err
 	}
 
 	a, err := arena.Rehydrate(arena.RehydrateState{
@@ -108,12 +110,13 @@ func (r *ArenaRepo) getByID(ctx context.Context, q queryer, id arena.ID, forUpda
 		Name:       name,
 		Status:     st,
 		CreatedAt:  createdAt.UTC(),
-		StartedAt:  sAt,
-		FinishedAt: fAt,
+		StartedAt:  utcPtr(startedAt),
+		FinishedAt: utcPtr(finishedAt),
 		Tick:       tick,
 		Config:     cfg,
-		Players:    nil,
-		Scheduled:  nil,
+		Players:    players,
+		Scheduled:  scheduled,
+		Version:    version,
 	})
 	if err != nil {
 		return nil, err
@@ -122,20 +125,234 @@ func (r *ArenaRepo) getByID(ctx context.Context, q queryer, id arena.ID, forUpda
 	return a, nil
 }
 
+func (r *ArenaRepo) loadPlayers(ctx context.Context, q queryer, id arena.ID) ([]arena.Player, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT player_id, display_name, role, joined_at
+		FROM arena_player
+		WHERE arena_id = $1
+		ORDER BY joined_at, player_id
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("load players: %w", err)
+	}
+	defer rows.Close()
+
+	var out []arena.Player
+	for rows.Next() {
+		var (
+			pid      uuid.UUID
+			p        arena.Player
+			role     string
+			joinedAt time.Time
+		)
+		if err := rows.Scan(&pid, &p.DisplayName, &role, &joinedAt); err != nil {
+			return nil, fmt.Errorf("load players: %w", err)
+		}
+		p.ID = arena.PlayerID(pid)
+		p.Role = arena.PlayerRole(role)
+		p.JoinedAt = joinedAt.UTC()
+		out = append(out, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("load players: %w", err)
+	}
+	return out, nil
+}
+
+func (r *ArenaRepo) loadScheduled(ctx context.Context, q queryer, id arena.ID) (map[int64][]arena.PlayerAction, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT id, player_id, type, apply_at_tick, submitted_at, payload
+		FROM arena_scheduled_action
+		WHERE arena_id = $1
+		ORDER BY apply_at_tick, seq
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("load scheduled actions: %w", err)
+	}
+	defer rows.Close()
+
+	out := make(map[int64][]arena.PlayerAction)
+	for rows.Next() {
+		var (
+			aid, pid    uuid.UUID
+			typ         string
+			applyAt     int64
+			submittedAt time.Time
+			payload     []byte
+		)
+		if err := rows.Scan(&aid, &pid, &typ, &applyAt, &submittedAt, &payload); err != nil {
+			return nil, fmt.Errorf("load scheduled actions: %w", err)
+		}
+		p, err := ActionPayloadFromJSON(arena.ActionType(typ), payload)
+		if err != nil {
+			return nil, fmt.Errorf("action %s: %w", aid, err)
+		}
+		out[applyAt] = append(out[applyAt], arena.PlayerAction{
+			ID:          arena.ActionID(aid),
+			Type:        arena.ActionType(typ),
+			PlayerID:    arena.PlayerID(pid),
+			SubmittedAt: submittedAt.UTC(),
+			ApplyAtTick: applyAt,
+			Payload:     p,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("load scheduled actions: %w", err)
+	}
+	return out, nil
+}
+
+// Save grava com a versão esperada (a.Version()) e, se deu certo, avança a versão do
+// aggregate: uma por evento pendente (como no event store), para que cada evento
+// publicado tenha a sua sequência; um Save sem eventos avança uma.
 func (r *ArenaRepo) Save(ctx context.Context, a *arena.Arena) error {
+	step := max(int64(len(a.PendingEvents())), 1)
+	err := r.withinTx(ctx, func(q queryer) error {
+		return r.save(ctx, q, a, step)
+	})
+	if err != nil {
+		return err
+	}
+	a.SetVersion(a.Version() + step)
+	return nil
+}
+
+// withinTx usa a tx do contexto (UnitOfWork) ou abre uma própria: o Save grava
+// várias tabelas e não pode ficar pela metade.
+func (r *ArenaRepo) withinTx(ctx context.Context, fn func(q queryer) error) error {
 	if tx, ok := postgres.TxFrom(ctx); ok {
-		return r.save(ctx, tx, a)
+		return fn(tx)
+	}
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *ArenaRepo) save(ctx context.Context, q queryer, a *arena.Arena, step int64) error {
+	configJSON, err := ConfigToJSON(a.Config())
+	if err != nil {
+		return err
+	}
+
+	// a linha da arena vai primeiro: o UPDATE condicional serializa saves
+	// concorrentes e o perdedor sai antes de mexer nas tabelas filhas
+	var res sql.Result
+	expected := a.Version()
+	if expected == 0 {
+		res, err = q.ExecContext(ctx, `
+			INSERT INTO arena (id, name, status, created_at, started_at, finished_at, tick, config, version, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW())
+			ON CONFLICT (id) DO NOTHING
+		`, a.ID(), a.Name(), string(a.Status()), a.CreatedAt(), a.StartedAt(), a.FinishedAt(), a.Tick(), configJSON, step)
+	} else {
+		res, err = q.ExecContext(ctx, `
+			UPDATE arena SET
+			  name        = $2,
+			  status      = $3,
+			  started_at  = $4,
+			  finished_at = $5,
+			  tick        = $6,
+			  config      = $7,
+			  version     = version + $9,
+			  updated_at  = NOW()
+			WHERE id = $1 AND version = $8
+		`, a.ID(), a.Name(), string(a.Status()), a.StartedAt(), a.FinishedAt(), a.Tick(), configJSON, expected, step)
+	}
+	if err != nil {
+		return fmt.Errorf("save arena: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("save arena: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("save arena %s (version %d): %w", a.ID(), expected, repository.ErrConcurrencyConflict)
+	}
+
+	return r.saveChanges(ctx, q, a)
+}
+
+// saveChanges grava nas tabelas filhas só o que os eventos pendentes mudaram, na
+// ordem em que aconteceram (espelha arena.Apply); um Save sem eventos de jogador
+// ou de ação não toca arena_player nem arena_scheduled_action.
+func (r *ArenaRepo) saveChanges(ctx context.Context, q queryer, a *arena.Arena) error {
+	for _, e := range a.PendingEvents() {
+		var err error
+		switch ev := e.(type) {
+		case arena.PlayerJoined:
+			_, err = q.ExecContext(ctx, `
+				INSERT INTO arena_player (arena_id, player_id, display_name, role, joined_at)
+				VALUES ($1, $2, $3, $4, $5)
+			`, a.ID(), uuid.UUID(ev.PlayerID), ev.DisplayName, string(ev.Role), ev.OccurredAt())
+
+		case arena.PlayerLeft:
+			err = savePlayerLeft(ctx, q, a.ID(), ev)
+
+		case arena.ActionSubmitted:
+			// ações são imutáveis: entram uma vez, na ordem de submissão (seq)
+			ac := ev.Action
+			var payload []byte
+			if payload, err = ActionPayloadToJSON(ac.Payload); err != nil {
+				return fmt.Errorf("action %s: %w", uuid.UUID(ac.ID), err)
+			}
+			_, err = q.ExecContext(ctx, `
+				INSERT INTO arena_scheduled_action (id, arena_id, player_id, type, apply_at_tick, submitted_at, payload)
+				VALUES ($1, $2, $3, $4, $5, $6, $7)
+			`, uuid.UUID(ac.ID), a.ID(), uuid.UUID(ac.PlayerID), string(ac.Type), ac.ApplyAtTick, ac.SubmittedAt, payload)
+
+		case arena.TickAdvanced:
+			// aplicadas no tick (arena.dropScheduledUpTo)
+			_, err = q.ExecContext(ctx, `
+				DELETE FROM arena_scheduled_action WHERE arena_id = $1 AND apply_at_tick <= $2
+			`, a.ID(), ev.Tick)
+		}
+		if err != nil {
+			return fmt.Errorf("save %s: %w", e.EventName(), err)
+		}
 	}
-	return r.save(ctx, r.db, a)
+	return nil
 }
 
-func (r *ArenaRepo) save(ctx context.Context, q queryer, a *arena.Arena) error {
+// savePlayerLeft: as ações agendadas do jogador saem junto (arena.dropScheduledOf).
+func savePlayerLeft(ctx context.Context, q queryer, id arena.ID, ev arena.PlayerLeft) error {
+	if _, err := q.ExecContext(ctx, `
+		DELETE FROM arena_player WHERE arena_id = $1 AND player_id = $2
+	`, id, uuid.UUID(ev.PlayerID)); err != nil {
+		return err
+	}
+	if _, err := q.ExecContext(ctx, `
+		DELETE FROM arena_scheduled_action WHERE arena_id = $1 AND player_id = $2
+	`, id, uuid.UUID(ev.PlayerID)); err != nil {
+		return err
+	}
+	if ev.PromotedAdminID == nil {
+		return nil
+	}
 	_, err := q.ExecContext(ctx, `
-		INSERT INTO arenas (id, name, status, created_at)
-		VALUES ($1, $2, $3, $4)
-		ON CONFLICT (id) DO UPDATE SET
-		  name = EXCLUDED.name,
-		  status = EXCLUDED.status
-	`, a.ID(), a.Name(), a.Status(), a.CreatedAt())
+		UPDATE arena_player SET role = $3 WHERE arena_id = $1 AND player_id = $2
+	`, id, uuid.UUID(*ev.PromotedAdminID), string(arena.RoleAdmin))
 	return err
 }
+
+func sortedTicks(m map[int64][]arena.PlayerAction) []int64 {
+	ticks := make([]int64, 0, len(m))
+	for t := range m {
+		ticks = append(ticks, t)
+	}
+	sort.Slice(ticks, func(i, j int) bool { return ticks[i] < ticks[j] })
+	return ticks
+}
+
+func utcPtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	u := t.Time.UTC()
+	return &u
+}
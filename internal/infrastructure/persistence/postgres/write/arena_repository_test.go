@@ -0,0 +1,87 @@
+package write
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/petri-board-arena/internal/domain/arena"
+)
+
+// execRecorder guarda o começo de cada comando; saveChanges só usa ExecContext.
+type execRecorder struct {
+	queryer
+	stmts []string
+}
+
+func (r *execRecorder) ExecContext(_ context.Context, query string, _ ...any) (sql.Result, error) {
+	r.stmts = append(r.stmts, strings.Join(strings.Fields(query)[:3], " "))
+	return nil, nil
+}
+
+func TestSaveChangesWritesOnlyPendingEvents(t *testing.T) {
+	at := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	admin := arena.Player{ID: arena.PlayerID(uuid.New()), DisplayName: "alice", Role: arena.RoleAdmin, JoinedAt: at}
+	bob := arena.Player{ID: arena.PlayerID(uuid.New()), DisplayName: "bob", Role: arena.RolePlayer, JoinedAt: at.Add(time.Second)}
+	a, err := arena.Rehydrate(arena.RehydrateState{
+		ID: uuid.New(), Name: "petri", Status: arena.StatusRunning, CreatedAt: at, Tick: 4,
+		Config: arena.Config{
+			TickMillis: 100, Width: 10, Height: 10, MaxOrganisms: 10, SnapshotEveryTicks: 10,
+			Temperature: arena.Temperature{Unit: arena.TempC, Value: 20},
+		},
+		Players: []arena.Player{admin, bob},
+		Scheduled: map[int64][]arena.PlayerAction{5: {{
+			ID: arena.ActionID(uuid.New()), Type: arena.ActionAddNutrients, PlayerID: bob.ID, ApplyAtTick: 5,
+			Payload: arena.AddNutrientsPayload{Area: arena.Area{Width: 1, Height: 1}, Amount: 1},
+		}}},
+		Version: 3,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// sem eventos: as tabelas filhas ficam como estão
+	q := &execRecorder{}
+	if err := (&ArenaRepo{}).saveChanges(context.Background(), q, a); err != nil {
+		t.Fatal(err)
+	}
+	if len(q.stmts) != 0 {
+		t.Fatalf("statements without events = %v, want none", q.stmts)
+	}
+
+	if _, err := a.SubmitAction(arena.PlayerAction{
+		ID: arena.ActionID(uuid.New()), Type: arena.ActionSetTemperature, PlayerID: admin.ID,
+		Payload: arena.SetTemperaturePayload{Temperature: arena.Temperature{Unit: arena.TempC, Value: 30}},
+	}, at); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.Join(arena.PlayerID(uuid.New()), "carol", at.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Leave(admin.ID, at); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.AdvanceTick(at); err != nil {
+		t.Fatal(err)
+	}
+
+	q = &execRecorder{}
+	if err := (&ArenaRepo{}).saveChanges(context.Background(), q, a); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"INSERT INTO arena_scheduled_action", // ActionSubmitted
+		"INSERT INTO arena_player",           // PlayerJoined
+		"DELETE FROM arena_player",           // PlayerLeft
+		"DELETE FROM arena_scheduled_action",
+		"UPDATE arena_player SET",            // bob promovido a admin
+		"DELETE FROM arena_scheduled_action", // TickAdvanced
+	}
+	if strings.Join(q.stmts, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("statements:\n%s\nwant:\n%s", strings.Join(q.stmts, "\n"), strings.Join(want, "\n"))
+	}
+}
@@ -19,6 +19,9 @@ type arenaConfigDTO struct {
 		Value float64 `json:"value"`
 		Unit  string  `json:"unit"`
 	} `json:"temperature"`
+	MaxTicks          int64 `json:"maxTicks"`
+	MaxPlayers        int   `json:"maxPlayers"`
+	MaxActionsPerTick int   `json:"maxActionsPerTick"`
 }
 
 func ConfigFromJSON(b []byte) (arena.Config, error) {
@@ -39,6 +42,9 @@ func ConfigFromJSON(b []byte) (arena.Config, error) {
 			Value: dto.Temperature.Value,
 			Unit:  arena.TemperatureUnit(dto.Temperature.Unit), // ajuste se for enum forte
 		},
+		MaxTicks:          dto.MaxTicks,
+		MaxPlayers:        dto.MaxPlayers,
+		MaxActionsPerTick: dto.MaxActionsPerTick,
 	}
 
 	if err := cfg.Validate(); err != nil {
@@ -46,3 +52,26 @@ func ConfigFromJSON(b []byte) (arena.Config, error) {
 	}
 	return cfg, nil
 }
+
+func ConfigToJSON(c arena.Config) ([]byte, error) {
+	dto := arenaConfigDTO{
+		TickMillis:         c.TickMillis,
+		Width:              c.Width,
+		Height:             c.Height,
+		DiffusionRate:      c.DiffusionRate,
+		MutationRate:       c.MutationRate,
+		MaxOrganisms:       c.MaxOrganisms,
+		SnapshotEveryTicks: c.SnapshotEveryTicks,
+		MaxTicks:           c.MaxTicks,
+		MaxPlayers:         c.MaxPlayers,
+		MaxActionsPerTick:  c.MaxActionsPerTick,
+	}
+	dto.Temperature.Value = c.Temperature.Value
+	dto.Temperature.Unit = string(c.Temperature.Unit)
+
+	b, err := json.Marshal(dto)
+	if err != nil {
+		return nil, fmt.Errorf("marshal arena config: %w", err)
+	}
+	return b, nil
+}
@@ -0,0 +1,230 @@
+package write
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/petri-board-arena/internal/application/port/repository"
+	"github.com/petri-board-arena/internal/domain/arena"
+	"github.com/petri-board-arena/internal/infrastructure/config"
+	"github.com/petri-board-arena/internal/infrastructure/messaging"
+	"github.com/petri-board-arena/internal/infrastructure/persistence/postgres"
+)
+
+// DefaultSnapshotEvery: eventos entre dois snapshots quando não configurado.
+const DefaultSnapshotEvery = 100
+
+// pqUniqueViolation: outro Save já gravou a mesma (arena_id, sequence).
+const pqUniqueViolation = "23505"
+
+// EventSourcedArenaRepo persiste a arena como eventos em arena_event e reconstrói o
+// aggregate com arena.Replay (snapshot em arena_snapshot + eventos posteriores).
+// A versão do aggregate é a sequence do último evento.
+type EventSourcedArenaRepo struct {
+	db            *sql.DB
+	snapshotEvery int
+}
+
+func NewEventSourcedArenaRepo(db *sql.DB, snapshotEvery int) *EventSourcedArenaRepo {
+	if snapshotEvery <= 0 {
+		snapshotEvery = DefaultSnapshotEvery
+	}
+	return &EventSourcedArenaRepo{db: db, snapshotEvery: snapshotEvery}
+}
+
+func (r *EventSourcedArenaRepo) GetByID(ctx context.Context, id arena.ID) (*arena.Arena, error) {
+	if tx, ok := postgres.TxFrom(ctx); ok {
+		return r.getByID(ctx, tx, id)
+	}
+	return r.getByID(ctx, r.db, id)
+}
+
+func (r *EventSourcedArenaRepo) getByID(ctx context.Context, q queryer, id arena.ID) (*arena.Arena, error) {
+	snapshot, from, err := r.loadSnapshot(ctx, q, id)
+	if err != nil {
+		return nil, err
+	}
+
+	events, last, err := r.loadEvents(ctx, q, id, from)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot == nil && len(events) == 0 {
+		return nil, ErrArenaNotFound
+	}
+	if last == 0 {
+		last = from
+	}
+
+	a, err := arena.Replay(snapshot, events)
+	if err != nil {
+		return nil, fmt.Errorf("arena %s: %w", id, err)
+	}
+	a.SetVersion(last)
+	return a, nil
+}
+
+// loadSnapshot devolve o snapshot (nil se não houver) e a sequence que ele cobre.
+func (r *EventSourcedArenaRepo) loadSnapshot(ctx context.Context, q queryer, id arena.ID) (*arena.RehydrateState, int64, error) {
+	var (
+		seq   int64
+		state []byte
+	)
+	err := q.QueryRowContext(ctx, `
+		SELECT sequence, state
+		FROM arena_snapshot
+		WHERE arena_id = $1
+	`, id).Scan(&seq, &state)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("load snapshot: %w", err)
+	}
+
+	s, err := SnapshotFromJSON(state)
+	if err != nil {
+		return nil, 0, fmt.Errorf("arena %s snapshot %d: %w", id, seq, err)
+	}
+	s.Version = seq
+	return &s, seq, nil
+}
+
+// loadEvents lê os eventos com sequence > after, em ordem, e devolve a última sequence lida.
+func (r *EventSourcedArenaRepo) loadEvents(ctx context.Context, q queryer, id arena.ID, after int64) ([]arena.Event, int64, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT sequence, event_type, schema_version, payload, occurred_at
+		FROM arena_event
+		WHERE arena_id = $1 AND sequence > $2
+		ORDER BY sequence
+	`, id, after)
+	if err != nil {
+		return nil, 0, fmt.Errorf("load events: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		out  []arena.Event
+		last int64
+	)
+	for rows.Next() {
+		var (
+			seq        int64
+			typ        string
+			version    int
+			payload    []byte
+			occurredAt time.Time
+		)
+		if err := rows.Scan(&seq, &typ, &version, &payload, &occurredAt); err != nil {
+			return nil, 0, fmt.Errorf("load events: %w", err)
+		}
+		// buraco na sequência = store corrompido; melhor falhar do que montar estado errado
+		if want := max(last, after) + 1; seq != want {
+			return nil, 0, fmt.Errorf("arena %s: event sequence gap: got %d, want %d", id, seq, want)
+		}
+		ev, err := messaging.DecodeArenaEvent(typ, version, id, occurredAt, payload)
+		if err != nil {
+			return nil, 0, fmt.Errorf("arena %s event %d: %w", id, seq, err)
+		}
+		out = append(out, ev)
+		last = seq
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("load events: %w", err)
+	}
+	return out, last, nil
+}
+
+// Save anexa os eventos pendentes a partir de a.Version()+1. Se outro Save já usou
+// alguma dessas sequences, a PK estoura e o erro vira ErrConcurrencyConflict.
+func (r *EventSourcedArenaRepo) Save(ctx context.Context, a *arena.Arena) error {
+	events := a.PendingEvents()
+	if len(events) == 0 {
+		return nil
+	}
+
+	expected := a.Version()
+	err := r.withinTx(ctx, func(q queryer) error {
+		return r.save(ctx, q, a, expected, events)
+	})
+	if err != nil {
+		return err
+	}
+	a.SetVersion(expected + int64(len(events)))
+	return nil
+}
+
+func (r *EventSourcedArenaRepo) withinTx(ctx context.Context, fn func(q queryer) error) error {
+	if tx, ok := postgres.TxFrom(ctx); ok {
+		return fn(tx)
+	}
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *EventSourcedArenaRepo) save(ctx context.Context, q queryer, a *arena.Arena, expected int64, events []arena.Event) error {
+	seq := expected
+	for _, ev := range events {
+		seq++
+		payload, err := messaging.EncodeArenaEvent(ev)
+		if err != nil {
+			return fmt.Errorf("append %s: %w", ev.EventName(), err)
+		}
+		if _, err := q.ExecContext(ctx, `
+			INSERT INTO arena_event (arena_id, sequence, event_type, schema_version, payload, occurred_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`, a.ID(), seq, ev.EventName(), messaging.ArenaPayloadVersion(ev.EventName()), []byte(payload), ev.OccurredAt()); err != nil {
+			var pqErr *pq.Error
+			if errors.As(err, &pqErr) && pqErr.Code == pqUniqueViolation {
+				return fmt.Errorf("append arena %s (version %d): %w", a.ID(), expected, repository.ErrConcurrencyConflict)
+			}
+			return fmt.Errorf("append %s: %w", ev.EventName(), err)
+		}
+	}
+
+	// snapshot ao cruzar um múltiplo de snapshotEvery
+	if seq/int64(r.snapshotEvery) > expected/int64(r.snapshotEvery) {
+		return r.saveSnapshot(ctx, q, a, seq)
+	}
+	return nil
+}
+
+func (r *EventSourcedArenaRepo) saveSnapshot(ctx context.Context, q queryer, a *arena.Arena, seq int64) error {
+	state, err := SnapshotToJSON(a.State())
+	if err != nil {
+		return fmt.Errorf("snapshot arena %s: %w", a.ID(), err)
+	}
+	// nunca volta para um snapshot mais antigo
+	if _, err := q.ExecContext(ctx, `
+		INSERT INTO arena_snapshot (arena_id, sequence, state, created_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (arena_id) DO UPDATE SET
+		  sequence   = EXCLUDED.sequence,
+		  state      = EXCLUDED.state,
+		  created_at = EXCLUDED.created_at
+		WHERE arena_snapshot.sequence < EXCLUDED.sequence
+	`, a.ID(), seq, state); err != nil {
+		return fmt.Errorf("snapshot arena %s: %w", a.ID(), err)
+	}
+	return nil
+}
+
+// NewArenaRepository escolhe a persistência da arena conforme postgres.arenaStore.
+func NewArenaRepository(db *sql.DB, cfg config.Postgres) repository.ArenaWriteRepository {
+	if cfg.ArenaStore == config.ArenaStoreEvent {
+		return NewEventSourcedArenaRepo(db, cfg.SnapshotEvery)
+	}
+	return NewArenaRepo(db)
+}
@@ -0,0 +1,127 @@
+package write
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/petri-board-arena/internal/domain/arena"
+	"github.com/petri-board-arena/internal/infrastructure/messaging"
+)
+
+// arenaSnapshotDTO é o formato de arena_snapshot.state (arena.RehydrateState em JSON).
+// A versão não entra: ela é a coluna sequence do snapshot.
+type arenaSnapshotDTO struct {
+	ID         string                    `json:"id"`
+	Name       string                    `json:"name"`
+	Status     string                    `json:"status"`
+	CreatedAt  time.Time                 `json:"createdAt"`
+	StartedAt  *time.Time                `json:"startedAt,omitempty"`
+	FinishedAt *time.Time                `json:"finishedAt,omitempty"`
+	Tick       int64                     `json:"tick"`
+	Config     json.RawMessage           `json:"config"`
+	Players    []playerDTO               `json:"players"`
+	Scheduled  []messaging.ActionPayload `json:"scheduled"`
+}
+
+type playerDTO struct {
+	ID          string    `json:"id"`
+	DisplayName string    `json:"displayName"`
+	Role        string    `json:"role"`
+	JoinedAt    time.Time `json:"joinedAt"`
+}
+
+func SnapshotToJSON(s arena.RehydrateState) ([]byte, error) {
+	cfg, err := ConfigToJSON(s.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	dto := arenaSnapshotDTO{
+		ID:         s.ID.String(),
+		Name:       s.Name,
+		Status:     string(s.Status),
+		CreatedAt:  s.CreatedAt,
+		StartedAt:  s.StartedAt,
+		FinishedAt: s.FinishedAt,
+		Tick:       s.Tick,
+		Config:     cfg,
+		Players:    make([]playerDTO, 0, len(s.Players)),
+		Scheduled:  []messaging.ActionPayload{},
+	}
+	for _, p := range s.Players {
+		dto.Players = append(dto.Players, playerDTO{
+			ID:          uuid.UUID(p.ID).String(),
+			DisplayName: p.DisplayName,
+			Role:        string(p.Role),
+			JoinedAt:    p.JoinedAt,
+		})
+	}
+	// ordem de submissão preservada dentro de cada tick
+	for _, tick := range sortedTicks(s.Scheduled) {
+		for _, ac := range s.Scheduled[tick] {
+			dto.Scheduled = append(dto.Scheduled, messaging.ActionToPayload(ac))
+		}
+	}
+
+	b, err := json.Marshal(dto)
+	if err != nil {
+		return nil, fmt.Errorf("marshal arena snapshot: %w", err)
+	}
+	return b, nil
+}
+
+func SnapshotFromJSON(b []byte) (arena.RehydrateState, error) {
+	var dto arenaSnapshotDTO
+	if err := json.Unmarshal(b, &dto); err != nil {
+		return arena.RehydrateState{}, fmt.Errorf("unmarshal arena snapshot: %w", err)
+	}
+
+	id, err := uuid.Parse(dto.ID)
+	if err != nil {
+		return arena.RehydrateState{}, fmt.Errorf("snapshot id: %w", err)
+	}
+	st, err := arena.ParseStatus(dto.Status)
+	if err != nil {
+		return arena.RehydrateState{}, fmt.Errorf("snapshot status: %w", err)
+	}
+	cfg, err := ConfigFromJSON(dto.Config)
+	if err != nil {
+		return arena.RehydrateState{}, fmt.Errorf("snapshot config: %w", err)
+	}
+
+	s := arena.RehydrateState{
+		ID:         id,
+		Name:       dto.Name,
+		Status:     st,
+		CreatedAt:  dto.CreatedAt.UTC(),
+		StartedAt:  dto.StartedAt,
+		FinishedAt: dto.FinishedAt,
+		Tick:       dto.Tick,
+		Config:     cfg,
+		Players:    make([]arena.Player, 0, len(dto.Players)),
+		Scheduled:  make(map[int64][]arena.PlayerAction),
+	}
+	for _, p := range dto.Players {
+		pid, err := uuid.Parse(p.ID)
+		if err != nil {
+			return arena.RehydrateState{}, fmt.Errorf("snapshot player id: %w", err)
+		}
+		s.Players = append(s.Players, arena.Player{
+			ID:          arena.PlayerID(pid),
+			DisplayName: p.DisplayName,
+			Role:        arena.PlayerRole(p.Role),
+			JoinedAt:    p.JoinedAt.UTC(),
+		})
+	}
+	for _, p := range dto.Scheduled {
+		ac, err := messaging.PayloadToAction(p)
+		if err != nil {
+			return arena.RehydrateState{}, fmt.Errorf("snapshot action %s: %w", p.ID, err)
+		}
+		s.Scheduled[ac.ApplyAtTick] = append(s.Scheduled[ac.ApplyAtTick], ac)
+	}
+	return s, nil
+}
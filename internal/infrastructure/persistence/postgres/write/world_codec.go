@@ -0,0 +1,147 @@
+package write
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/petri-board-arena/internal/domain/arena"
+	"github.com/petri-board-arena/internal/domain/simulation"
+)
+
+// arenaWorldDTO é o formato de arena_world.state (simulation.State em JSON).
+type arenaWorldDTO struct {
+	Tick           int64           `json:"tick"`
+	Config         json.RawMessage `json:"config"`
+	Ambient        float64         `json:"ambient"`
+	Organisms      []organismDTO   `json:"organisms"`
+	Nutrients      []float64       `json:"nutrients"`
+	Antibiotic     [][]float64     `json:"antibiotic"`
+	Temperature    []float64       `json:"temperature"`
+	NextOrganismID int64           `json:"nextOrganismId"`
+	Seed           uint64          `json:"seed,string"`
+}
+
+type organismDTO struct {
+	ID               int64     `json:"id"`
+	ParentID         int64     `json:"parentId,omitempty"`
+	Kind             string    `json:"kind"`
+	X                int       `json:"x"`
+	Y                int       `json:"y"`
+	Energy           float64   `json:"energy"`
+	Age              int64     `json:"age"`
+	Generation       int       `json:"generation"`
+	Uptake           float64   `json:"uptake"`
+	ThermalOptimum   float64   `json:"thermalOptimum"`
+	ThermalTolerance float64   `json:"thermalTolerance"`
+	Resistance       []float64 `json:"resistance"`
+}
+
+func WorldToJSON(s simulation.State) ([]byte, error) {
+	cfg, err := ConfigToJSON(s.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	dto := arenaWorldDTO{
+		Tick:           s.Tick,
+		Config:         cfg,
+		Ambient:        s.Ambient,
+		Organisms:      make([]organismDTO, 0, len(s.Organisms)),
+		Nutrients:      s.Nutrients,
+		Antibiotic:     make([][]float64, 0, len(s.Antibiotic)),
+		Temperature:    s.Temperature,
+		NextOrganismID: s.NextOrganismID,
+		Seed:           s.Seed,
+	}
+	for _, layer := range s.Antibiotic {
+		dto.Antibiotic = append(dto.Antibiotic, layer)
+	}
+	for _, o := range s.Organisms {
+		dto.Organisms = append(dto.Organisms, organismDTO{
+			ID:               o.ID,
+			ParentID:         o.ParentID,
+			Kind:             string(o.Kind),
+			X:                o.X,
+			Y:                o.Y,
+			Energy:           o.Energy,
+			Age:              o.Age,
+			Generation:       o.Generation,
+			Uptake:           o.Genome.Uptake,
+			ThermalOptimum:   o.Genome.ThermalOptimum,
+			ThermalTolerance: o.Genome.ThermalTolerance,
+			Resistance:       o.Genome.Resistance[:],
+		})
+	}
+
+	b, err := json.Marshal(dto)
+	if err != nil {
+		return nil, fmt.Errorf("marshal arena world: %w", err)
+	}
+	return b, nil
+}
+
+// WorldFromJSON confere o tamanho das camadas contra a config: um mundo corrompido
+// vira erro aqui em vez de index out of range no Step.
+func WorldFromJSON(b []byte) (simulation.State, error) {
+	var dto arenaWorldDTO
+	if err := json.Unmarshal(b, &dto); err != nil {
+		return simulation.State{}, fmt.Errorf("unmarshal arena world: %w", err)
+	}
+	cfg, err := ConfigFromJSON(dto.Config)
+	if err != nil {
+		return simulation.State{}, fmt.Errorf("world config: %w", err)
+	}
+
+	s := simulation.State{
+		Tick:           dto.Tick,
+		Config:         cfg,
+		Ambient:        dto.Ambient,
+		Organisms:      make([]simulation.Organism, 0, len(dto.Organisms)),
+		Nutrients:      dto.Nutrients,
+		Temperature:    dto.Temperature,
+		NextOrganismID: dto.NextOrganismID,
+		Seed:           dto.Seed,
+	}
+
+	cells := cfg.Width * cfg.Height
+	if len(dto.Antibiotic) != len(s.Antibiotic) {
+		return simulation.State{}, fmt.Errorf("world antibiotic: %d layers, want %d", len(dto.Antibiotic), len(s.Antibiotic))
+	}
+	copy(s.Antibiotic[:], dto.Antibiotic)
+	layers := map[string][]float64{"nutrients": s.Nutrients, "temperature": s.Temperature}
+	for i, layer := range s.Antibiotic {
+		layers[fmt.Sprintf("antibiotic[%d]", i)] = layer
+	}
+	for name, layer := range layers {
+		if len(layer) != cells {
+			return simulation.State{}, fmt.Errorf("world %s: %d cells, want %d", name, len(layer), cells)
+		}
+	}
+
+	for _, o := range dto.Organisms {
+		if o.X < 0 || o.Y < 0 || o.X >= cfg.Width || o.Y >= cfg.Height {
+			return simulation.State{}, fmt.Errorf("world organism %d: position (%d,%d) outside the grid", o.ID, o.X, o.Y)
+		}
+		org := simulation.Organism{
+			ID:         o.ID,
+			ParentID:   o.ParentID,
+			Kind:       arena.OrganismKind(o.Kind),
+			X:          o.X,
+			Y:          o.Y,
+			Energy:     o.Energy,
+			Age:        o.Age,
+			Generation: o.Generation,
+			Genome: simulation.Genome{
+				Uptake:           o.Uptake,
+				ThermalOptimum:   o.ThermalOptimum,
+				ThermalTolerance: o.ThermalTolerance,
+			},
+		}
+		if len(o.Resistance) != len(org.Genome.Resistance) {
+			return simulation.State{}, fmt.Errorf("world organism %d: %d resistance genes, want %d", o.ID, len(o.Resistance), len(org.Genome.Resistance))
+		}
+		copy(org.Genome.Resistance[:], o.Resistance)
+		s.Organisms = append(s.Organisms, org)
+	}
+	return s, nil
+}
@@ -0,0 +1,56 @@
+package write
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/petri-board-arena/internal/domain/arena"
+	"github.com/petri-board-arena/internal/domain/simulation"
+)
+
+func testWorld(t *testing.T) simulation.State {
+	t.Helper()
+	s, err := simulation.NewState(arena.Config{
+		TickMillis: 100, Width: 4, Height: 3, MaxOrganisms: 10, SnapshotEveryTicks: 10,
+		Temperature: arena.Temperature{Unit: arena.TempC, Value: 20},
+	}, 1<<63+7) // seed acima de 2^53: sobrevive só como string no JSON
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Tick = 9
+	s.NextOrganismID = 3
+	s.Antibiotic[1][5] = 0.25
+	s.Organisms = []simulation.Organism{{
+		ID: 2, ParentID: 1, Kind: arena.KindBacteria, X: 3, Y: 2, Energy: 4.5, Age: 6, Generation: 2,
+		Genome: simulation.Genome{Uptake: 0.4, ThermalOptimum: 30, ThermalTolerance: 5, Resistance: [3]float64{0.1, 0.2, 0.3}},
+	}}
+	return s
+}
+
+func TestWorldJSONRoundTrip(t *testing.T) {
+	want := testWorld(t)
+	b, err := WorldToJSON(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := WorldFromJSON(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("round trip:\n got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestWorldFromJSONRejectsMismatchedGrid(t *testing.T) {
+	s := testWorld(t)
+	s.Nutrients = s.Nutrients[:5]
+	b, err := WorldToJSON(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := WorldFromJSON(b); err == nil || !strings.Contains(err.Error(), "nutrients") {
+		t.Fatalf("err = %v, want nutrients size error", err)
+	}
+}
@@ -0,0 +1,61 @@
+package write
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/petri-board-arena/internal/application/port/repository"
+	"github.com/petri-board-arena/internal/domain/arena"
+	"github.com/petri-board-arena/internal/domain/simulation"
+	"github.com/petri-board-arena/internal/infrastructure/persistence/postgres"
+)
+
+// ArenaWorldRepo persiste o mundo simulado em arena_world (uma linha por arena,
+// sobrescrita a cada tick). Vale para os dois arena stores.
+type ArenaWorldRepo struct {
+	db *sql.DB
+}
+
+func NewArenaWorldRepo(db *sql.DB) *ArenaWorldRepo { return &ArenaWorldRepo{db: db} }
+
+func (r *ArenaWorldRepo) q(ctx context.Context) queryer {
+	if tx, ok := postgres.TxFrom(ctx); ok {
+		return tx
+	}
+	return r.db
+}
+
+func (r *ArenaWorldRepo) GetWorld(ctx context.Context, id arena.ID) (simulation.State, error) {
+	var state []byte
+	err := r.q(ctx).QueryRowContext(ctx, `SELECT state FROM arena_world WHERE arena_id = $1`, id).Scan(&state)
+	if errors.Is(err, sql.ErrNoRows) {
+		return simulation.State{}, repository.ErrWorldNotFound
+	}
+	if err != nil {
+		return simulation.State{}, fmt.Errorf("load world: %w", err)
+	}
+	s, err := WorldFromJSON(state)
+	if err != nil {
+		return simulation.State{}, fmt.Errorf("arena %s: %w", id, err)
+	}
+	return s, nil
+}
+
+func (r *ArenaWorldRepo) SaveWorld(ctx context.Context, id arena.ID, s simulation.State) error {
+	state, err := WorldToJSON(s)
+	if err != nil {
+		return err
+	}
+	_, err = r.q(ctx).ExecContext(ctx, `
+		INSERT INTO arena_world (arena_id, tick, state, updated_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (arena_id) DO UPDATE
+		SET tick = EXCLUDED.tick, state = EXCLUDED.state, updated_at = EXCLUDED.updated_at
+	`, id, s.Tick, state)
+	if err != nil {
+		return fmt.Errorf("save world: %w", err)
+	}
+	return nil
+}
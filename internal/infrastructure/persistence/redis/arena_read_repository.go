@@ -0,0 +1,268 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/petri-board-arena/internal/application/port/repository"
+	"github.com/petri-board-arena/internal/application/query/dto"
+	"github.com/petri-board-arena/internal/domain/arena"
+	"github.com/petri-board-arena/internal/infrastructure/messaging"
+)
+
+// Chaves escritas pelo projector (internal/infrastructure/projector).
+const (
+	arenaKeyPrefix  = "arena:"
+	statusKeyPrefix = "arenas:status:"
+	createdAtZSet   = "arenas:created_at"
+	// arena:<id>:players: hash playerId -> JSON do jogador
+	playersKeySuffix = ":players"
+
+	// scanChunk: ids lidos por vez do sorted set quando há filtro por nome
+	scanChunk = 500
+)
+
+// ArenaReadRepo lê o read model de arenas projetado no Redis.
+type ArenaReadRepo struct {
+	rdb *goredis.Client
+}
+
+func NewArenaReadRepo(rdb *goredis.Client) *ArenaReadRepo { return &ArenaReadRepo{rdb: rdb} }
+
+func (r *ArenaReadRepo) GetArena(ctx context.Context, id string) (*dto.ArenaView, error) {
+	out, err := r.load(ctx, []string{id}, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(out) == 0 {
+		return nil, repository.ErrArenaNotFound
+	}
+	return &out[0], nil
+}
+
+// ListArenas pagina pelo sorted set arenas:created_at (mais recentes primeiro).
+//   - sem filtro: total = ZCARD e a página vem direto do ZREVRANGE;
+//   - só status: total = SCARD do set do status, percorrendo o sorted set até preencher a página;
+//   - nameContains: percorre o sorted set inteiro (em blocos) para contar o total real.
+func (r *ArenaReadRepo) ListArenas(ctx context.Context, f dto.ArenaFilter) ([]dto.ArenaView, int, error) {
+	if f.Limit <= 0 {
+		return []dto.ArenaView{}, 0, nil
+	}
+	name := strings.ToLower(strings.TrimSpace(f.NameContains))
+
+	if f.Status == nil && name == "" {
+		total, err := r.rdb.ZCard(ctx, createdAtZSet).Result()
+		if err != nil {
+			return nil, 0, err
+		}
+		ids, err := r.rdb.ZRevRange(ctx, createdAtZSet, int64(f.Offset), int64(f.Offset+f.Limit-1)).Result()
+		if err != nil {
+			return nil, 0, err
+		}
+		out, err := r.load(ctx, ids, true)
+		return out, int(total), err
+	}
+
+	// só status: o total sai do set e dá para parar assim que a página encher
+	total := -1
+	if name == "" {
+		n, err := r.rdb.SCard(ctx, statusKeyPrefix+*f.Status).Result()
+		if err != nil {
+			return nil, 0, err
+		}
+		total = int(n)
+	}
+
+	var (
+		page    []string
+		matched int
+	)
+	for start := int64(0); ; start += scanChunk {
+		ids, err := r.rdb.ZRevRange(ctx, createdAtZSet, start, start+scanChunk-1).Result()
+		if err != nil {
+			return nil, 0, err
+		}
+		if len(ids) == 0 {
+			break
+		}
+
+		pipe := r.rdb.Pipeline()
+		cmds := make([]*goredis.SliceCmd, len(ids))
+		for i, id := range ids {
+			cmds[i] = pipe.HMGet(ctx, arenaKeyPrefix+id, "name", "status")
+		}
+		if _, err := pipe.Exec(ctx); err != nil {
+			return nil, 0, err
+		}
+
+		for i, id := range ids {
+			vals := cmds[i].Val()
+			n, _ := vals[0].(string)
+			s, _ := vals[1].(string)
+			if n == "" && s == "" {
+				continue // hash removido; índice desatualizado
+			}
+			if f.Status != nil && s != *f.Status {
+				continue
+			}
+			if name != "" && !strings.Contains(strings.ToLower(n), name) {
+				continue
+			}
+			if matched >= f.Offset && len(page) < f.Limit {
+				page = append(page, id)
+			}
+			matched++
+		}
+
+		if total >= 0 && len(page) == f.Limit {
+			break
+		}
+		if len(ids) < scanChunk {
+			break
+		}
+	}
+	if total < 0 {
+		total = matched
+	}
+
+	out, err := r.load(ctx, page, true)
+	return out, total, err
+}
+
+// load busca os hashes na ordem dos ids, ignorando os que sumiram entre as leituras.
+// Com skipInvalid (listagens), uma arena corrompida no read model é logada e pulada
+// em vez de derrubar a página inteira.
+func (r *ArenaReadRepo) load(ctx context.Context, ids []string, skipInvalid bool) ([]dto.ArenaView, error) {
+	out := make([]dto.ArenaView, 0, len(ids))
+	if len(ids) == 0 {
+		return out, nil
+	}
+
+	pipe := r.rdb.Pipeline()
+	cmds := make([]*goredis.MapStringStringCmd, len(ids))
+	players := make([]*goredis.MapStringStringCmd, len(ids))
+	for i, id := range ids {
+		cmds[i] = pipe.HGetAll(ctx, arenaKeyPrefix+id)
+		players[i] = pipe.HGetAll(ctx, arenaKeyPrefix+id+playersKeySuffix)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	for i, id := range ids {
+		h := cmds[i].Val()
+		if len(h) == 0 {
+			continue
+		}
+		v, err := arenaFromHash(id, h)
+		if err == nil {
+			v.Players, err = playersFromHash(id, players[i].Val())
+		}
+		if err != nil {
+			if !skipInvalid {
+				return nil, err
+			}
+			log.Printf("[redis] skipping invalid arena read model: %v", err)
+			continue
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+func arenaFromHash(id string, h map[string]string) (dto.ArenaView, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return dto.ArenaView{}, fmt.Errorf("arena %s: invalid id: %w", id, err)
+	}
+	v := dto.ArenaView{
+		ID:     id,
+		Name:   h["name"],
+		Status: h["status"],
+	}
+
+	var err error
+	if v.CreatedAt, err = parseTime(h["createdAt"]); err != nil {
+		return v, fmt.Errorf("arena %s createdAt: %w", id, err)
+	}
+	if v.StartedAt, err = parseOptionalTime(h["startedAt"]); err != nil {
+		return v, fmt.Errorf("arena %s startedAt: %w", id, err)
+	}
+	if v.FinishedAt, err = parseOptionalTime(h["finishedAt"]); err != nil {
+		return v, fmt.Errorf("arena %s finishedAt: %w", id, err)
+	}
+	if t := h["tick"]; t != "" {
+		if v.Tick, err = strconv.ParseInt(t, 10, 64); err != nil {
+			return v, fmt.Errorf("arena %s tick: %w", id, err)
+		}
+	}
+	if c := h["configJson"]; c != "" && c != "null" {
+		var pl messaging.ConfigPayload
+		if err := json.Unmarshal([]byte(c), &pl); err != nil {
+			return v, fmt.Errorf("arena %s config: %w", id, err)
+		}
+		v.Config = messaging.PayloadToConfig(pl)
+	}
+	return v, nil
+}
+
+// playerEntry é o valor de arena:<id>:players gravado pelo projector.
+type playerEntry struct {
+	ID          string    `json:"id"`
+	DisplayName string    `json:"displayName"`
+	Role        string    `json:"role"`
+	JoinedAt    time.Time `json:"joinedAt"`
+}
+
+func playersFromHash(arenaID string, h map[string]string) ([]arena.Player, error) {
+	out := make([]arena.Player, 0, len(h))
+	for pid, raw := range h {
+		var e playerEntry
+		if err := json.Unmarshal([]byte(raw), &e); err != nil {
+			return nil, fmt.Errorf("arena %s player %s: %w", arenaID, pid, err)
+		}
+		id, err := uuid.Parse(e.ID)
+		if err != nil {
+			return nil, fmt.Errorf("arena %s player %s: invalid id: %w", arenaID, pid, err)
+		}
+		out = append(out, arena.Player{
+			ID:          arena.PlayerID(id),
+			DisplayName: e.DisplayName,
+			Role:        arena.PlayerRole(e.Role),
+			JoinedAt:    e.JoinedAt,
+		})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].JoinedAt.Equal(out[j].JoinedAt) {
+			return out[i].JoinedAt.Before(out[j].JoinedAt)
+		}
+		return uuid.UUID(out[i].ID).String() < uuid.UUID(out[j].ID).String()
+	})
+	return out, nil
+}
+
+func parseTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339Nano, s)
+}
+
+func parseOptionalTime(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
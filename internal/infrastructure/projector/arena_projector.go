@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -12,20 +14,50 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
+// Chaves por arena além do hash arena:<id> (lidas pelo ArenaReadRepo):
+//   - arena:<id>:players          hash playerId -> JSON do jogador;
+//   - arena:<id>:actions          stream com o log de ações submetidas;
+//   - arena:<id>:pending          sorted set actionId (score = applyAtTick);
+//   - arena:<id>:pending:actions  hash actionId -> JSON da ação pendente;
+//   - arena:<id>:players:sequence hash playerId -> Sequence do último evento do jogador;
+//   - arena:<id>:replayed         set com as Sequences aplicadas por replay do DLQ.
+const (
+	// sequenceField (no hash arena:<id>) é a Sequence do último evento aplicado;
+	// statusSequenceField/configSequenceField, a do último que escreveu status/config.
+	sequenceField       = "sequence"
+	statusSequenceField = "statusSequence"
+	configSequenceField = "configSequence"
+
+	playersKeySuffix        = ":players"
+	playerSequenceKeySuffix = ":players:sequence"
+	actionLogKeySuffix      = ":actions"
+	pendingKeySuffix        = ":pending"
+	pendingActionsKeySuffix = ":pending:actions"
+	replayedKeySuffix       = ":replayed"
+)
+
 type Projector struct {
-	rdb *redis.Client
-	cfg config.WorkerConfig
+	rdb    *redis.Client
+	cfg    config.Worker
+	codecs *messaging.CodecRegistry
 }
 
-func NewProjector(rdb *redis.Client, cfg config.WorkerConfig) *Projector {
-	return &Projector{rdb: rdb, cfg: cfg}
+func NewProjector(rdb *redis.Client, cfg config.Worker) *Projector {
+	return &Projector{rdb: rdb, cfg: cfg, codecs: messaging.ArenaCodecs()}
 }
 
 // Apply: roteia por EventType e escreve no Redis.
 // IMPORTANT: mantém idempotência por eventId (SETNX).
+// Erros de conteúdo (envelope/payload) voltam como messaging.Permanent; erros do
+// Redis voltam como estão (transient) e a marca de idempotência é removida para
+// que o retry reaplique o evento.
 func (p *Projector) Apply(ctx context.Context, ev messaging.EventEnvelope) error {
+	return p.apply(ctx, ev, false)
+}
+
+func (p *Projector) apply(ctx context.Context, ev messaging.EventEnvelope, replay bool) error {
 	if ev.EventID == "" || ev.EventType == "" || ev.AggregateID == "" {
-		return errors.New("invalid event envelope: missing required fields")
+		return messaging.Permanent(errors.New("invalid event envelope: missing required fields"))
 	}
 
 	// --- idempotência (at-least-once safe) ---
@@ -39,36 +71,147 @@ func (p *Projector) Apply(ctx context.Context, ev messaging.EventEnvelope) error
 		return nil
 	}
 
+	if err := p.route(ctx, ev, replay); err != nil {
+		// sem a marca, o retry (ou o replay do DLQ) processa de novo
+		if delErr := p.rdb.Del(context.WithoutCancel(ctx), idKey).Err(); delErr != nil {
+			return errors.Join(err, delErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// route decodifica o payload pelo registry (upcast até a versão atual + schema) e
+// aplica o evento sob WATCH de arena:<id>: as leituras dos handlers enxergam o estado
+// em que a sequência foi conferida e as escritas, com a nova sequência, vão em um
+// único MULTI/EXEC. Evento com Sequence que não é mais novo que o último aplicado
+// (reentrega fora de ordem) é ignorado.
+//
+// No replay do DLQ o evento nunca foi aplicado (foi para o DLQ e os seguintes da arena
+// passaram na frente), então a verificação é pela Sequence em arena:<id>:replayed: cada
+// evento é reaplicado uma vez. Status, config e jogadores guardam a Sequence de quem os
+// escreveu por último, para o evento atrasado não sobrescrever um estado mais novo.
+func (p *Projector) route(ctx context.Context, ev messaging.EventEnvelope, replay bool) error {
+	payload, err := p.codecs.Decode(ev.EventType, ev.Version, ev.Payload)
+	switch {
+	case errors.Is(err, messaging.ErrUnknownEvent):
+		// evento desconhecido: não falha o consumer (você pode logar/metricar)
+		return nil
+	case err != nil:
+		// versão mais nova que a deste worker ou payload fora do schema: vai para o
+		// DLQ e pode ser reaplicado (dlq-replay) depois do deploy
+		return messaging.Permanent(err)
+	}
+
+	arenaKey := "arena:" + ev.AggregateID
+	replayedKey := arenaKey + replayedKeySuffix
+	return p.rdb.Watch(ctx, func(tx *redis.Tx) error {
+		newest := true
+		if ev.Sequence > 0 {
+			last, err := tx.HGet(ctx, arenaKey, sequenceField).Int64()
+			if err != nil && !errors.Is(err, redis.Nil) {
+				return err
+			}
+			newest = err != nil || ev.Sequence > last
+			if !newest && !replay {
+				return nil
+			}
+			if replay {
+				done, err := tx.SIsMember(ctx, replayedKey, ev.Sequence).Result()
+				if err != nil {
+					return err
+				}
+				if done {
+					return nil
+				}
+			}
+		}
+
+		pipe := tx.TxPipeline()
+		if err := p.project(ctx, tx, pipe, ev, payload); err != nil {
+			return err
+		}
+		if ev.Sequence > 0 {
+			if newest {
+				pipe.HSet(ctx, arenaKey, sequenceField, ev.Sequence)
+			}
+			if replay {
+				pipe.SAdd(ctx, replayedKey, ev.Sequence)
+			}
+		}
+		_, err := pipe.Exec(ctx)
+		return err
+	}, arenaKey, replayedKey)
+}
+
+// project despacha com o tipo já resolvido: os handlers leem por rd e enfileiram as
+// escritas em pipe (executado por route).
+func (p *Projector) project(ctx context.Context, rd redis.Cmdable, pipe redis.Pipeliner, ev messaging.EventEnvelope, payload any) error {
 	switch ev.EventType {
 	case "ArenaCreated":
-		return p.onArenaCreated(ctx, ev)
+		return p.onArenaCreated(ctx, rd, pipe, ev, payload.(*messaging.ArenaCreatedPayload))
 	case "ArenaStarted":
-		return p.onArenaStatus(ctx, ev, "RUNNING")
+		return p.onArenaStatus(ctx, rd, pipe, ev, "RUNNING")
 	case "ArenaPaused":
-		return p.onArenaStatus(ctx, ev, "PAUSED")
+		return p.onArenaStatus(ctx, rd, pipe, ev, "PAUSED")
 	case "ArenaResumed":
-		return p.onArenaStatus(ctx, ev, "RUNNING")
-	case "ArenaStopped", "ArenaFinished":
-		return p.onArenaStatus(ctx, ev, "FINISHED")
+		return p.onArenaStatus(ctx, rd, pipe, ev, "RUNNING")
+	case "ArenaStopped":
+		return p.onArenaStatus(ctx, rd, pipe, ev, "FINISHED")
+	case "ArenaFinished":
+		if err := p.onArenaStatus(ctx, rd, pipe, ev, "FINISHED"); err != nil {
+			return err
+		}
+		return p.setTick(ctx, rd, pipe, ev.AggregateID, payload.(*messaging.ArenaFinishedPayload).Tick)
+	case "PlayerJoined":
+		return p.onPlayerJoined(ctx, rd, pipe, ev, payload.(*messaging.PlayerJoinedPayload))
+	case "PlayerLeft":
+		return p.onPlayerLeft(ctx, rd, pipe, ev, payload.(*messaging.PlayerLeftPayload))
+	case "ArenaConfigUpdated":
+		return p.onConfigUpdated(ctx, rd, pipe, ev, payload.(*messaging.ArenaConfigUpdatedPayload))
+	case "ActionSubmitted":
+		return p.onActionSubmitted(ctx, rd, pipe, ev, payload.(*messaging.ActionSubmittedPayload))
+	case "TickAdvanced":
+		return p.onTickAdvanced(ctx, rd, pipe, ev, payload.(*messaging.TickAdvancedPayload))
 	default:
-		// evento desconhecido: não falha o consumer (você pode logar/metricar)
+		// registrado mas sem projeção
 		return nil
 	}
 }
 
-// payload esperado (exemplo mínimo; ajuste para seu schema)
-type arenaCreatedPayload struct {
-	Name   string          `json:"name"`
-	Config json.RawMessage `json:"config"`
+// Reapply reprocessa um evento do DLQ: remove a marca de idempotência (gravada por
+// versões antigas mesmo com a projeção falhando) e aplica mesmo que eventos mais novos
+// da arena já tenham sido aplicados (ver route).
+func (p *Projector) Reapply(ctx context.Context, ev messaging.EventEnvelope) error {
+	if ev.EventID == "" {
+		return messaging.Permanent(errors.New("invalid event envelope: missing eventId"))
+	}
+	if err := p.rdb.Del(ctx, "processed:event:"+ev.EventID).Err(); err != nil {
+		return err
+	}
+	return p.apply(ctx, ev, true)
 }
 
-func (p *Projector) onArenaCreated(ctx context.Context, ev messaging.EventEnvelope) error {
-	var pl arenaCreatedPayload
-	if err := json.Unmarshal(ev.Payload, &pl); err != nil {
-		return err
+// latest diz se ev é o evento mais novo a escrever o campo de key (a Sequence fica em
+// seqKey/seqField) e, se for, enfileira a nova Sequence. Sem Sequence (legado) escreve sempre.
+func latest(ctx context.Context, rd redis.Cmdable, pipe redis.Pipeliner, ev messaging.EventEnvelope, seqKey, seqField string) (bool, error) {
+	if ev.Sequence <= 0 {
+		return true, nil
 	}
+	last, err := rd.HGet(ctx, seqKey, seqField).Int64()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return false, err
+	}
+	if err == nil && ev.Sequence <= last {
+		return false, nil
+	}
+	pipe.HSet(ctx, seqKey, seqField, ev.Sequence)
+	return true, nil
+}
+
+func (p *Projector) onArenaCreated(ctx context.Context, rd redis.Cmdable, pipe redis.Pipeliner, ev messaging.EventEnvelope, pl *messaging.ArenaCreatedPayload) error {
 	if strings.TrimSpace(pl.Name) == "" {
-		return errors.New("ArenaCreated payload missing name")
+		return messaging.Permanent(errors.New("ArenaCreated payload missing name"))
 	}
 
 	arenaKey := "arena:" + ev.AggregateID
@@ -77,39 +220,258 @@ func (p *Projector) onArenaCreated(ctx context.Context, ev messaging.EventEnvelo
 
 	createdAtScore := float64(ev.OccurredAt.Unix())
 
-	pipe := p.rdb.TxPipeline()
-	pipe.HSet(ctx, arenaKey, map[string]any{
-		"id":         ev.AggregateID,
-		"name":       pl.Name,
-		"status":     "PENDING",
-		"createdAt":  ev.OccurredAt.UTC().Format(time.RFC3339Nano),
-		"updatedAt":  time.Now().UTC().Format(time.RFC3339Nano),
-		"configJson": string(pl.Config), // guarda como string; alternativa: RedisJSON
-	})
-	pipe.SAdd(ctx, statusKey, ev.AggregateID)
-	pipe.ZAdd(ctx, createdZ, redis.Z{Score: createdAtScore, Member: ev.AggregateID})
+	configJSON, err := json.Marshal(pl.Config)
+	if err != nil {
+		return messaging.Permanent(fmt.Errorf("ArenaCreated config: %w", err))
+	}
+
+	fields := map[string]any{
+		"id":        ev.AggregateID,
+		"name":      pl.Name,
+		"createdAt": ev.OccurredAt.UTC().Format(time.RFC3339Nano),
+		"updatedAt": time.Now().UTC().Format(time.RFC3339Nano),
+	}
+	// replay atrasado do ArenaCreated não volta status/config escritos por eventos mais novos
+	statusLatest, err := latest(ctx, rd, pipe, ev, arenaKey, statusSequenceField)
+	if err != nil {
+		return err
+	}
+	if statusLatest {
+		fields["status"] = "PENDING"
+		pipe.SAdd(ctx, statusKey, ev.AggregateID)
+	}
+	configLatest, err := latest(ctx, rd, pipe, ev, arenaKey, configSequenceField)
+	if err != nil {
+		return err
+	}
+	if configLatest {
+		fields["configJson"] = string(configJSON) // guarda como string; alternativa: RedisJSON
+	}
 
-	_, err := pipe.Exec(ctx)
-	return err
+	pipe.HSet(ctx, arenaKey, fields)
+	pipe.ZAdd(ctx, createdZ, redis.Z{Score: createdAtScore, Member: ev.AggregateID})
+	return nil
 }
 
-func (p *Projector) onArenaStatus(ctx context.Context, ev messaging.EventEnvelope, newStatus string) error {
+func (p *Projector) onArenaStatus(ctx context.Context, rd redis.Cmdable, pipe redis.Pipeliner, ev messaging.EventEnvelope, newStatus string) error {
 	arenaKey := "arena:" + ev.AggregateID
 
+	if ok, err := latest(ctx, rd, pipe, ev, arenaKey, statusSequenceField); err != nil || !ok {
+		return err
+	}
+
 	// lê status atual (para mover entre sets)
-	oldStatus, _ := p.rdb.HGet(ctx, arenaKey, "status").Result()
+	oldStatus, err := rd.HGet(ctx, arenaKey, "status").Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return err
+	}
 
-	pipe := p.rdb.TxPipeline()
 	pipe.HSet(ctx, arenaKey, map[string]any{
 		"status":    newStatus,
 		"updatedAt": time.Now().UTC().Format(time.RFC3339Nano),
 	})
+	switch ev.EventType {
+	case "ArenaStarted":
+		// resume não conta: startedAt é o primeiro start
+		pipe.HSetNX(ctx, arenaKey, "startedAt", ev.OccurredAt.UTC().Format(time.RFC3339Nano))
+	case "ArenaStopped", "ArenaFinished":
+		pipe.HSet(ctx, arenaKey, "finishedAt", ev.OccurredAt.UTC().Format(time.RFC3339Nano))
+	}
 
 	if oldStatus != "" && oldStatus != newStatus {
 		pipe.SRem(ctx, "arenas:status:"+oldStatus, ev.AggregateID)
 	}
 	pipe.SAdd(ctx, "arenas:status:"+newStatus, ev.AggregateID)
+	return nil
+}
+
+// playerEntry é o valor de arena:<id>:players.
+type playerEntry struct {
+	ID          string    `json:"id"`
+	DisplayName string    `json:"displayName"`
+	Role        string    `json:"role"`
+	JoinedAt    time.Time `json:"joinedAt"`
+}
+
+func (p *Projector) onPlayerJoined(ctx context.Context, rd redis.Cmdable, pipe redis.Pipeliner, ev messaging.EventEnvelope, pl *messaging.PlayerJoinedPayload) error {
+	arenaKey := "arena:" + ev.AggregateID
+	// replay atrasado não devolve à arena um jogador que já saiu
+	if ok, err := latest(ctx, rd, pipe, ev, arenaKey+playerSequenceKeySuffix, pl.PlayerID); err != nil || !ok {
+		return err
+	}
+
+	b, err := json.Marshal(playerEntry{
+		ID:          pl.PlayerID,
+		DisplayName: pl.DisplayName,
+		Role:        pl.Role,
+		JoinedAt:    ev.OccurredAt.UTC(),
+	})
+	if err != nil {
+		return messaging.Permanent(fmt.Errorf("PlayerJoined player: %w", err))
+	}
+
+	pipe.HSet(ctx, arenaKey+playersKeySuffix, pl.PlayerID, string(b))
+	pipe.HSet(ctx, arenaKey, "updatedAt", time.Now().UTC().Format(time.RFC3339Nano))
+	return nil
+}
+
+// onPlayerLeft espelha o domínio: remove o jogador, as ações pendentes dele e
+// promove o novo admin, se houver.
+func (p *Projector) onPlayerLeft(ctx context.Context, rd redis.Cmdable, pipe redis.Pipeliner, ev messaging.EventEnvelope, pl *messaging.PlayerLeftPayload) error {
+	arenaKey := "arena:" + ev.AggregateID
+	playersKey := arenaKey + playersKeySuffix
+
+	if ok, err := latest(ctx, rd, pipe, ev, arenaKey+playerSequenceKeySuffix, pl.PlayerID); err != nil || !ok {
+		return err
+	}
+
+	var promoted string
+	if pl.PromotedAdminID != nil {
+		raw, err := rd.HGet(ctx, playersKey, *pl.PromotedAdminID).Result()
+		switch {
+		case errors.Is(err, redis.Nil):
+			// jogador promovido não projetado: nada a atualizar
+		case err != nil:
+			return err
+		default:
+			var e playerEntry
+			if err := json.Unmarshal([]byte(raw), &e); err != nil {
+				return messaging.Permanent(fmt.Errorf("player %s read model: %w", *pl.PromotedAdminID, err))
+			}
+			e.Role = "ADMIN"
+			b, err := json.Marshal(e)
+			if err != nil {
+				return messaging.Permanent(fmt.Errorf("player %s read model: %w", *pl.PromotedAdminID, err))
+			}
+			promoted = string(b)
+		}
+	}
+
+	pending, err := rd.HGetAll(ctx, arenaKey+pendingActionsKeySuffix).Result()
+	if err != nil {
+		return err
+	}
+	var dropped []string
+	for id, raw := range pending {
+		var a messaging.ActionPayload
+		if json.Unmarshal([]byte(raw), &a) == nil && a.PlayerID == pl.PlayerID {
+			dropped = append(dropped, id)
+		}
+	}
+
+	pipe.HDel(ctx, playersKey, pl.PlayerID)
+	if promoted != "" {
+		pipe.HSet(ctx, playersKey, *pl.PromotedAdminID, promoted)
+	}
+	p.dropPending(ctx, pipe, arenaKey, dropped)
+	pipe.HSet(ctx, arenaKey, "updatedAt", time.Now().UTC().Format(time.RFC3339Nano))
+	return nil
+}
+
+func (p *Projector) onConfigUpdated(ctx context.Context, rd redis.Cmdable, pipe redis.Pipeliner, ev messaging.EventEnvelope, pl *messaging.ArenaConfigUpdatedPayload) error {
+	if ok, err := latest(ctx, rd, pipe, ev, "arena:"+ev.AggregateID, configSequenceField); err != nil || !ok {
+		return err
+	}
+
+	configJSON, err := json.Marshal(pl.Config)
+	if err != nil {
+		return messaging.Permanent(fmt.Errorf("ArenaConfigUpdated config: %w", err))
+	}
+	pipe.HSet(ctx, "arena:"+ev.AggregateID, map[string]any{
+		"configJson": string(configJSON),
+		"updatedAt":  time.Now().UTC().Format(time.RFC3339Nano),
+	})
+	return nil
+}
 
-	_, err := pipe.Exec(ctx)
-	return err
+// onActionSubmitted grava a ação no log (stream) e a agenda em pending até o tick dela;
+// se o tick já passou (replay atrasado), a ação só entra no log.
+func (p *Projector) onActionSubmitted(ctx context.Context, rd redis.Cmdable, pipe redis.Pipeliner, ev messaging.EventEnvelope, pl *messaging.ActionSubmittedPayload) error {
+	act := pl.Action
+	if act.ID == "" {
+		return messaging.Permanent(errors.New("ActionSubmitted payload missing action id"))
+	}
+	b, err := json.Marshal(act)
+	if err != nil {
+		return messaging.Permanent(fmt.Errorf("ActionSubmitted action: %w", err))
+	}
+
+	arenaKey := "arena:" + ev.AggregateID
+	pipe.XAdd(ctx, &redis.XAddArgs{
+		Stream: arenaKey + actionLogKeySuffix,
+		MaxLen: int64(p.cfg.ActionLogMaxLen),
+		Approx: true,
+		Values: map[string]any{
+			"eventId":     ev.EventID,
+			"actionId":    act.ID,
+			"type":        act.Type,
+			"playerId":    act.PlayerID,
+			"applyAtTick": act.ApplyAtTick,
+			"submittedAt": act.SubmittedAt.UTC().Format(time.RFC3339Nano),
+			"action":      string(b),
+		},
+	})
+
+	tick, err := rd.HGet(ctx, arenaKey, "tick").Int64()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return err
+	}
+	if err == nil && act.ApplyAtTick <= tick {
+		return nil
+	}
+	pipe.ZAdd(ctx, arenaKey+pendingKeySuffix, redis.Z{Score: float64(act.ApplyAtTick), Member: act.ID})
+	pipe.HSet(ctx, arenaKey+pendingActionsKeySuffix, act.ID, string(b))
+	return nil
+}
+
+// onTickAdvanced atualiza o tick e tira de pending as ações com applyAtTick <= tick
+// (o domínio já as aplicou).
+func (p *Projector) onTickAdvanced(ctx context.Context, rd redis.Cmdable, pipe redis.Pipeliner, ev messaging.EventEnvelope, pl *messaging.TickAdvancedPayload) error {
+	if pl.Tick < 0 {
+		return messaging.Permanent(fmt.Errorf("TickAdvanced payload has negative tick %d", pl.Tick))
+	}
+	if err := p.setTick(ctx, rd, pipe, ev.AggregateID, pl.Tick); err != nil {
+		return err
+	}
+
+	arenaKey := "arena:" + ev.AggregateID
+	due, err := rd.ZRangeByScore(ctx, arenaKey+pendingKeySuffix, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: strconv.FormatInt(pl.Tick, 10),
+	}).Result()
+	if err != nil {
+		return err
+	}
+	p.dropPending(ctx, pipe, arenaKey, due)
+	return nil
+}
+
+// setTick só avança o tick: um evento antigo sem Sequence (envelope legado) reentregue
+// não faz o read model voltar.
+func (p *Projector) setTick(ctx context.Context, rd redis.Cmdable, pipe redis.Pipeliner, aggregateID string, tick int64) error {
+	arenaKey := "arena:" + aggregateID
+	cur, err := rd.HGet(ctx, arenaKey, "tick").Int64()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return err
+	}
+	if err == nil && cur >= tick {
+		return nil
+	}
+	pipe.HSet(ctx, arenaKey, map[string]any{
+		"tick":      tick,
+		"updatedAt": time.Now().UTC().Format(time.RFC3339Nano),
+	})
+	return nil
+}
+
+func (p *Projector) dropPending(ctx context.Context, pipe redis.Pipeliner, arenaKey string, actionIDs []string) {
+	if len(actionIDs) == 0 {
+		return
+	}
+	members := make([]any, len(actionIDs))
+	for i, id := range actionIDs {
+		members[i] = id
+	}
+	pipe.ZRem(ctx, arenaKey+pendingKeySuffix, members...)
+	pipe.HDel(ctx, arenaKey+pendingActionsKeySuffix, actionIDs...)
 }
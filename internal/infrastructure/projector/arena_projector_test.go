@@ -0,0 +1,177 @@
+package projector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/petri-board-arena/internal/domain/arena"
+	"github.com/petri-board-arena/internal/infrastructure/config"
+	"github.com/petri-board-arena/internal/infrastructure/messaging"
+)
+
+func testProjector(t *testing.T) (*Projector, *redis.Client) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+	return NewProjector(rdb, config.Worker{IdempotencyTTL: time.Hour}), rdb
+}
+
+// envelope monta o envelope como o outbox publisher (eventId novo a cada chamada).
+func envelope(t *testing.T, ev arena.Event, seq int64) messaging.EventEnvelope {
+	t.Helper()
+	payload, err := messaging.EncodeArenaEvent(ev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return messaging.EventEnvelope{
+		EventID:     uuid.NewString(),
+		EventType:   ev.EventName(),
+		AggregateID: ev.ArenaID().String(),
+		OccurredAt:  ev.OccurredAt(),
+		Version:     messaging.ArenaPayloadVersion(ev.EventName()),
+		Sequence:    seq,
+		Payload:     payload,
+	}
+}
+
+func TestApplySkipsEventsOlderThanTheLastApplied(t *testing.T) {
+	p, rdb := testProjector(t)
+	ctx := context.Background()
+
+	id := arena.ID(uuid.New())
+	at := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	base := arena.NewBaseEvent(id, at)
+	created := arena.ArenaCreated{BaseEvent: base, Name: "petri", Config: arena.Config{
+		TickMillis: 100, Width: 10, Height: 10, MaxOrganisms: 10, SnapshotEveryTicks: 10,
+		Temperature: arena.Temperature{Unit: arena.TempC, Value: 20},
+	}}
+	started := envelope(t, arena.ArenaStarted{BaseEvent: base}, 2)
+
+	for _, ev := range []messaging.EventEnvelope{
+		envelope(t, created, 1),
+		envelope(t, arena.ArenaStopped{BaseEvent: base}, 3),
+		// ArenaStarted chega depois do ArenaStopped (retry, replay do DLQ)
+		started,
+	} {
+		if err := p.Apply(ctx, ev); err != nil {
+			t.Fatalf("apply %s: %v", ev.EventType, err)
+		}
+	}
+
+	arenaKey := "arena:" + id.String()
+	got, err := rdb.HMGet(ctx, arenaKey, "status", sequenceField, "startedAt").Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got[0] != "FINISHED" || got[1] != "3" || got[2] != nil {
+		t.Fatalf("status, sequence, startedAt = %v, want FINISHED, 3, <nil>", got)
+	}
+	if ok, _ := rdb.SIsMember(ctx, "arenas:status:RUNNING", id.String()).Result(); ok {
+		t.Fatal("stale ArenaStarted moved the arena back to the RUNNING set")
+	}
+
+	// replay do DLQ do mesmo evento é aplicado, mas não volta o status mais novo
+	if err := p.Reapply(ctx, started); err != nil {
+		t.Fatal(err)
+	}
+	if status, _ := rdb.HGet(ctx, arenaKey, "status").Result(); status != "FINISHED" {
+		t.Fatalf("status after replay = %s, want FINISHED", status)
+	}
+}
+
+// Um evento vai para o DLQ, os seguintes da arena são aplicados e depois o
+// dlq-replay o reaplica.
+func TestReapplyDeadLetteredEventAfterNewerOnes(t *testing.T) {
+	p, rdb := testProjector(t)
+	ctx := context.Background()
+
+	id := arena.ID(uuid.New())
+	at := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	base := arena.NewBaseEvent(id, at)
+	created := arena.ArenaCreated{BaseEvent: base, Name: "petri", Config: arena.Config{
+		TickMillis: 100, Width: 10, Height: 10, MaxOrganisms: 10, SnapshotEveryTicks: 10,
+		Temperature: arena.Temperature{Unit: arena.TempC, Value: 20},
+	}}
+	alice, bob := arena.PlayerID(uuid.New()), arena.PlayerID(uuid.New())
+	action := arena.PlayerAction{
+		ID: arena.ActionID(uuid.New()), Type: arena.ActionAddNutrients, PlayerID: alice,
+		SubmittedAt: at, ApplyAtTick: 5,
+		Payload: arena.AddNutrientsPayload{Area: arena.Area{Width: 1, Height: 1}, Amount: 1},
+	}
+
+	joinedBob := envelope(t, arena.PlayerJoined{BaseEvent: base, PlayerID: bob, DisplayName: "bob", Role: arena.RolePlayer}, 3)
+	submitted := envelope(t, arena.ActionSubmitted{BaseEvent: base, Action: action}, 4)
+	for _, ev := range []messaging.EventEnvelope{
+		envelope(t, created, 1),
+		envelope(t, arena.PlayerJoined{BaseEvent: base, PlayerID: alice, DisplayName: "alice", Role: arena.RoleAdmin}, 2),
+		// 3 e 4 foram para o DLQ; 5 e 6 passaram na frente
+		envelope(t, arena.PlayerLeft{BaseEvent: base, PlayerID: bob}, 5),
+		envelope(t, arena.TickAdvanced{BaseEvent: base, Tick: 1}, 6),
+	} {
+		if err := p.Apply(ctx, ev); err != nil {
+			t.Fatalf("apply %s: %v", ev.EventType, err)
+		}
+	}
+
+	// replay do DLQ (duas vezes: o segundo não aplica de novo)
+	for i := 0; i < 2; i++ {
+		for _, ev := range []messaging.EventEnvelope{joinedBob, submitted} {
+			if err := p.Reapply(ctx, ev); err != nil {
+				t.Fatalf("reapply %s: %v", ev.EventType, err)
+			}
+		}
+	}
+
+	arenaKey := "arena:" + id.String()
+	if n, _ := rdb.XLen(ctx, arenaKey+actionLogKeySuffix).Result(); n != 1 {
+		t.Fatalf("action log has %d entries, want the replayed action once", n)
+	}
+	if ok, _ := rdb.HExists(ctx, arenaKey+pendingActionsKeySuffix, uuid.UUID(action.ID).String()).Result(); !ok {
+		t.Fatal("replayed action for tick 5 is not pending at tick 1")
+	}
+	// bob saiu depois (sequência 5): o PlayerJoined atrasado não o devolve à arena
+	if ok, _ := rdb.HExists(ctx, arenaKey+playersKeySuffix, uuid.UUID(bob).String()).Result(); ok {
+		t.Fatal("late PlayerJoined brought back a player that already left")
+	}
+	if seq, _ := rdb.HGet(ctx, arenaKey, sequenceField).Int64(); seq != 6 {
+		t.Fatalf("sequence = %d, want 6 (replay must not rewind it)", seq)
+	}
+
+	// o fluxo normal continua ignorando reentregas antigas
+	if err := p.Apply(ctx, envelope(t, arena.ActionSubmitted{BaseEvent: base, Action: action}, 4)); err != nil {
+		t.Fatal(err)
+	}
+	if n, _ := rdb.XLen(ctx, arenaKey+actionLogKeySuffix).Result(); n != 1 {
+		t.Fatalf("action log has %d entries after a stale redelivery, want 1", n)
+	}
+}
+
+func TestApplyWithoutSequence(t *testing.T) {
+	p, rdb := testProjector(t)
+	ctx := context.Background()
+
+	id := arena.ID(uuid.New())
+	base := arena.NewBaseEvent(id, time.Now())
+	arenaKey := "arena:" + id.String()
+	if err := rdb.HSet(ctx, arenaKey, "status", "PENDING", sequenceField, 5).Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	// envelope legado (sem Sequence): aplicado sem a verificação e sem mexer na sequência
+	if err := p.Apply(ctx, envelope(t, arena.ArenaStarted{BaseEvent: base}, 0)); err != nil {
+		t.Fatal(err)
+	}
+	got, err := rdb.HMGet(ctx, arenaKey, "status", sequenceField).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got[0] != "RUNNING" || got[1] != "5" {
+		t.Fatalf("status, sequence = %v, want RUNNING, 5", got)
+	}
+}
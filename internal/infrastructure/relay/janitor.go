@@ -0,0 +1,64 @@
+package relay
+
+import (
+	"context"
+	"expvar"
+	"log"
+	"time"
+
+	"github.com/petri-board-arena/internal/infrastructure/persistence/postgres/outbox"
+)
+
+// Métricas expostas em /debug/vars (expvar).
+var (
+	janitorMetrics      = expvar.NewMap("outbox_janitor")
+	janitorRuns         = new(expvar.Int)
+	janitorErrors       = new(expvar.Int)
+	janitorPurged       = new(expvar.Int)
+	janitorArchived     = new(expvar.Int)
+	janitorDropped      = new(expvar.Int)
+	janitorLastDuration = new(expvar.Float)
+)
+
+func init() {
+	janitorMetrics.Set("runs_total", janitorRuns)
+	janitorMetrics.Set("errors_total", janitorErrors)
+	janitorMetrics.Set("rows_purged_total", janitorPurged)
+	janitorMetrics.Set("rows_archived_total", janitorArchived)
+	janitorMetrics.Set("partitions_dropped_total", janitorDropped)
+	janitorMetrics.Set("last_run_seconds", janitorLastDuration)
+}
+
+// RunJanitor executa o janitor a cada interval até o ctx ser cancelado.
+func RunJanitor(ctx context.Context, j *outbox.Janitor, interval time.Duration) {
+	log.Printf("[outbox-janitor] mode=%s interval=%s", j.Mode(), interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		start := time.Now()
+		res, err := j.Run(ctx)
+
+		janitorRuns.Add(1)
+		janitorPurged.Add(res.Purged)
+		janitorArchived.Add(res.Archived)
+		janitorDropped.Add(int64(len(res.DroppedPartitions)))
+		janitorLastDuration.Set(time.Since(start).Seconds())
+
+		if err != nil && ctx.Err() == nil {
+			janitorErrors.Add(1)
+			log.Printf("[outbox-janitor] %v", err)
+		}
+		if res.Purged > 0 || len(res.DroppedPartitions) > 0 {
+			log.Printf("[outbox-janitor] purged=%d archived=%d batches=%d droppedPartitions=%v",
+				res.Purged, res.Archived, res.Batches, res.DroppedPartitions)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
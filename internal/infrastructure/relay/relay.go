@@ -0,0 +1,190 @@
+package relay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/petri-board-arena/internal/application/port"
+)
+
+// Publisher entrega eventos ao broker na ordem recebida.
+// Retorna nil se todos foram publicados; caso contrário um erro por evento (nil = publicado).
+type Publisher interface {
+	Publish(ctx context.Context, events []port.OutboxEvent) []error
+}
+
+type Config struct {
+	// WorkerID identifica a instância (outbox_event.locked_by); deve ser único por processo.
+	WorkerID     string
+	BatchSize    int
+	LockTTL      time.Duration
+	PollInterval time.Duration
+	BaseBackoff  time.Duration
+}
+
+// Relay faz polling do outbox_event e publica os eventos reservados.
+// Várias instâncias podem rodar em paralelo: o LockBatch reparte as linhas por
+// WorkerID e nunca entrega a dois workers eventos do mesmo aggregate ao mesmo tempo.
+type Relay struct {
+	store port.OutboxStore
+	pub   Publisher
+	cfg   Config
+	wake  <-chan struct{}
+}
+
+func New(store port.OutboxStore, pub Publisher, cfg Config) *Relay {
+	return &Relay{store: store, pub: pub, cfg: cfg}
+}
+
+// WakeOn faz o relay drenar o outbox a cada sinal (ex.: LISTEN/NOTIFY) em vez de
+// esperar o PollInterval. O polling continua ativo: cobre conexão de LISTEN caída e
+// eventos FAILED cujo backoff venceu (esses não geram NOTIFY).
+func (r *Relay) WakeOn(ch <-chan struct{}) {
+	r.wake = ch
+}
+
+func (r *Relay) Run(ctx context.Context) error {
+	log.Printf("[outbox-relay] worker=%s batch=%d lockTTL=%s poll=%s",
+		r.cfg.WorkerID, r.cfg.BatchSize, r.cfg.LockTTL, r.cfg.PollInterval)
+
+	for {
+		n, err := r.RunOnce(ctx)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return nil
+			}
+			log.Printf("[outbox-relay] %v", err)
+		}
+
+		// lote cheio: provavelmente há mais eventos, segue sem esperar
+		if err == nil && n == r.cfg.BatchSize {
+			continue
+		}
+
+		timer := time.NewTimer(r.cfg.PollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-r.wake: // nil (sem listener) bloqueia para sempre
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// RunOnce reserva e publica um lote; retorna quantos eventos foram reservados.
+func (r *Relay) RunOnce(ctx context.Context) (int, error) {
+	batch, err := r.store.LockBatch(ctx, port.OutboxLockParams{
+		WorkerID:  r.cfg.WorkerID,
+		BatchSize: r.cfg.BatchSize,
+		LockTTL:   r.cfg.LockTTL,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(batch) == 0 {
+		return 0, nil
+	}
+
+	// não publica depois que o lock expirou (outro worker pode ter reclamado as linhas)
+	pubCtx, cancel := context.WithTimeout(ctx, r.cfg.LockTTL)
+	defer cancel()
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	for _, events := range groupByAggregate(batch) {
+		wg.Add(1)
+		go func(events []port.OutboxEvent) {
+			defer wg.Done()
+			if err := r.publishAggregate(ctx, pubCtx, events); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}(events)
+	}
+	wg.Wait()
+
+	return len(batch), errors.Join(errs...)
+}
+
+// publishAggregate publica os eventos de um aggregate (ordenados por seq) e registra o
+// resultado. O primeiro evento com falha consome uma tentativa; todos os seguintes voltam
+// para PENDING sem custo e saem depois dele, mesmo os que o broker aceitou: marcá-los
+// publicados deixaria o evento com falha chegar depois deles. A cópia já entregue é
+// descartada pelo consumidor (idempotência por eventId).
+func (r *Relay) publishAggregate(ctx, pubCtx context.Context, events []port.OutboxEvent) error {
+	perEvent := r.pub.Publish(pubCtx, events)
+
+	// o resultado precisa ser gravado mesmo em shutdown, senão o evento só volta quando o lock expirar
+	markCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+
+	var (
+		failed  bool
+		release []uuid.UUID
+		errs    []error
+	)
+	for i, ev := range events {
+		var pubErr error
+		if perEvent != nil {
+			pubErr = perEvent[i]
+		}
+
+		switch {
+		case failed:
+			release = append(release, ev.ID)
+		case pubErr == nil:
+			if err := r.store.MarkPublished(markCtx, ev.ID, r.cfg.WorkerID); err != nil {
+				errs = append(errs, fmt.Errorf("event %s: %w", ev.ID, err))
+			}
+		default:
+			failed = true
+			dead, err := r.store.MarkFailed(markCtx, port.OutboxMarkFailedParams{
+				EventID:      ev.ID,
+				WorkerID:     r.cfg.WorkerID,
+				BaseBackoff:  r.cfg.BaseBackoff,
+				LastErrorMsg: pubErr.Error(),
+			})
+			if err != nil {
+				errs = append(errs, fmt.Errorf("event %s: %w", ev.ID, err))
+			}
+			if dead {
+				log.Printf("[outbox-relay] event %s (%s) moved to dead letter: %v", ev.ID, ev.EventType, pubErr)
+			}
+			errs = append(errs, fmt.Errorf("publish %s aggregate=%s: %w", ev.ID, ev.AggregateID, pubErr))
+		}
+	}
+
+	if err := r.store.Release(markCtx, r.cfg.WorkerID, release...); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// groupByAggregate mantém a ordem de seq dentro de cada aggregate.
+func groupByAggregate(batch []port.OutboxEvent) [][]port.OutboxEvent {
+	index := make(map[string]int)
+	var groups [][]port.OutboxEvent
+	for _, ev := range batch {
+		key := ev.AggregateType + ":" + ev.AggregateID
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], ev)
+	}
+	return groups
+}
@@ -0,0 +1,109 @@
+package relay
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/petri-board-arena/internal/application/port"
+)
+
+// recordingStore registra o destino de cada evento do lote.
+type recordingStore struct {
+	port.OutboxStore
+	batch     []port.OutboxEvent
+	published []uuid.UUID
+	failed    []uuid.UUID
+	released  []uuid.UUID
+}
+
+func (s *recordingStore) LockBatch(context.Context, port.OutboxLockParams) ([]port.OutboxEvent, error) {
+	return s.batch, nil
+}
+
+func (s *recordingStore) MarkPublished(_ context.Context, id uuid.UUID, _ string) error {
+	s.published = append(s.published, id)
+	return nil
+}
+
+func (s *recordingStore) MarkFailed(_ context.Context, p port.OutboxMarkFailedParams) (bool, error) {
+	s.failed = append(s.failed, p.EventID)
+	return false, nil
+}
+
+func (s *recordingStore) Release(_ context.Context, _ string, ids ...uuid.UUID) error {
+	s.released = append(s.released, ids...)
+	return nil
+}
+
+// publisherFunc devolve o resultado por evento que o teste quiser.
+type publisherFunc func([]port.OutboxEvent) []error
+
+func (f publisherFunc) Publish(_ context.Context, events []port.OutboxEvent) []error {
+	return f(events)
+}
+
+func TestPublishAggregateStopsAtTheFirstFailure(t *testing.T) {
+	errBroker := errors.New("broker unavailable")
+	tests := []struct {
+		name          string
+		results       []error
+		wantPublished []int
+		wantFailed    []int
+		wantReleased  []int
+	}{
+		{name: "all published", results: nil, wantPublished: []int{0, 1, 2}},
+		{
+			name:          "later events accepted after a failure are released",
+			results:       []error{nil, errBroker, nil},
+			wantPublished: []int{0},
+			wantFailed:    []int{1},
+			wantReleased:  []int{2},
+		},
+		{
+			name:         "first fails",
+			results:      []error{errBroker, nil, errBroker},
+			wantFailed:   []int{0},
+			wantReleased: []int{1, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aggregate := uuid.NewString()
+			var batch []port.OutboxEvent
+			for i := 0; i < 3; i++ {
+				batch = append(batch, port.OutboxEvent{ID: uuid.New(), AggregateType: "arena", AggregateID: aggregate})
+			}
+			store := &recordingStore{batch: batch}
+			pub := publisherFunc(func([]port.OutboxEvent) []error { return tt.results })
+			r := New(store, pub, Config{WorkerID: "w1", BatchSize: 10, LockTTL: time.Second})
+
+			_, err := r.RunOnce(context.Background())
+			if (tt.wantFailed != nil) != (err != nil) {
+				t.Fatalf("RunOnce err = %v", err)
+			}
+			for _, c := range []struct {
+				what string
+				got  []uuid.UUID
+				want []int
+			}{
+				{"published", store.published, tt.wantPublished},
+				{"failed", store.failed, tt.wantFailed},
+				{"released", store.released, tt.wantReleased},
+			} {
+				if len(c.got) != len(c.want) {
+					t.Fatalf("%s = %v, want events %v", c.what, c.got, c.want)
+				}
+				for i, idx := range c.want {
+					if c.got[i] != batch[idx].ID {
+						t.Fatalf("%s[%d] = %s, want event %d", c.what, i, c.got[i], idx)
+					}
+				}
+			}
+		})
+	}
+}
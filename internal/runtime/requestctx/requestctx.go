@@ -0,0 +1,120 @@
+package requestctx
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/petri-board-arena/internal/domain/arena"
+)
+
+const (
+	// AuthorizationHeader carrega o token de sessão do joinArena ("Bearer <token>").
+	AuthorizationHeader = "Authorization"
+	// CorrelationIDHeader propaga o id de correlação entre serviços (gerado se ausente).
+	CorrelationIDHeader = "X-Correlation-Id"
+	// RequestIDHeader identifica a requisição; vira o causationId dos eventos gerados por ela.
+	RequestIDHeader = "X-Request-Id"
+	// AdminTokenHeader autentica operações de ops (ex.: dead letters do outbox).
+	AdminTokenHeader = "X-Admin-Token"
+)
+
+// Session é o jogador autenticado pelo token de sessão, válido só na arena em que entrou.
+type Session struct {
+	ArenaID  arena.ID
+	PlayerID arena.PlayerID
+}
+
+// SessionVerifier valida o token de sessão (ver adapter.SignedSessionTokens).
+type SessionVerifier interface {
+	Verify(token string) (Session, error)
+}
+
+type (
+	sessionKey     struct{}
+	correlationKey struct{}
+	causationKey   struct{}
+	adminTokenKey  struct{}
+)
+
+func WithSession(ctx context.Context, s Session) context.Context {
+	return context.WithValue(ctx, sessionKey{}, s)
+}
+
+// SessionFrom retorna a sessão já validada pelo Middleware.
+func SessionFrom(ctx context.Context) (Session, bool) {
+	s, ok := ctx.Value(sessionKey{}).(Session)
+	return s, ok
+}
+
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationKey{}, id)
+}
+
+func CorrelationIDFrom(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(correlationKey{}).(string)
+	return id, ok && id != ""
+}
+
+func WithCausationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, causationKey{}, id)
+}
+
+func CausationIDFrom(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(causationKey{}).(string)
+	return id, ok && id != ""
+}
+
+func WithAdminToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, adminTokenKey{}, token)
+}
+
+// AdminTokenFrom retorna o token como recebido; a validação fica com quem o exige.
+func AdminTokenFrom(ctx context.Context) (string, bool) {
+	token, ok := ctx.Value(adminTokenKey{}).(string)
+	return token, ok && token != ""
+}
+
+// Middleware copia os headers de identidade e rastreio para o context da requisição.
+// O jogador só vem de um token de sessão válido; token ausente ou inválido deixa a
+// requisição sem sessão e os resolvers que exigem jogador a rejeitam.
+func Middleware(sessions SessionVerifier, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		correlationID := headerOrNewID(r, CorrelationIDHeader)
+		requestID := headerOrNewID(r, RequestIDHeader)
+		ctx = WithCorrelationID(ctx, correlationID)
+		ctx = WithCausationID(ctx, requestID)
+		w.Header().Set(CorrelationIDHeader, correlationID)
+		w.Header().Set(RequestIDHeader, requestID)
+
+		if token, ok := bearerToken(r); ok {
+			if s, err := sessions.Verify(token); err == nil {
+				ctx = WithSession(ctx, s)
+			}
+		}
+		if v := strings.TrimSpace(r.Header.Get(AdminTokenHeader)); v != "" {
+			ctx = WithAdminToken(ctx, v)
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(strings.TrimSpace(r.Header.Get(AuthorizationHeader)), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+func headerOrNewID(r *http.Request, header string) string {
+	if v := strings.TrimSpace(r.Header.Get(header)); v != "" {
+		return v
+	}
+	return uuid.NewString()
+}
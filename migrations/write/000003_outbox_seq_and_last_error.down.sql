@@ -0,0 +1,14 @@
+-- 000003_outbox_seq_and_last_error.down.sql
+
+DROP INDEX IF EXISTS idx_outbox_event_poll;
+
+CREATE INDEX IF NOT EXISTS idx_outbox_event_poll
+    ON outbox_event (status, next_attempt_at, created_at);
+
+DROP INDEX IF EXISTS ux_outbox_event_seq;
+
+ALTER TABLE outbox_event DROP COLUMN IF EXISTS last_error;
+ALTER TABLE outbox_event DROP COLUMN IF EXISTS seq;
+
+ALTER TABLE IF EXISTS outbox_dead_letter
+    RENAME TO outbox_dead_letters;
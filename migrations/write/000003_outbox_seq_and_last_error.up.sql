@@ -0,0 +1,23 @@
+-- 000003_outbox_seq_and_last_error.up.sql
+
+-- A 000001 cria outbox_dead_letters mas o down dela remove outbox_dead_letter:
+-- o nome singular (o do down) passa a ser o da tabela.
+ALTER TABLE IF EXISTS outbox_dead_letters
+    RENAME TO outbox_dead_letter;
+
+-- created_at usa now() (tempo da transação): eventos do mesmo comando empatam.
+-- seq garante a ordem de inserção para o relay publicar na ordem certa.
+ALTER TABLE outbox_event
+    ADD COLUMN IF NOT EXISTS seq BIGSERIAL;
+
+-- Último erro de publicação (vai para outbox_dead_letter.last_error ao esgotar tentativas)
+ALTER TABLE outbox_event
+    ADD COLUMN IF NOT EXISTS last_error TEXT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_outbox_event_seq
+    ON outbox_event (seq);
+
+DROP INDEX IF EXISTS idx_outbox_event_poll;
+
+CREATE INDEX IF NOT EXISTS idx_outbox_event_poll
+    ON outbox_event (status, next_attempt_at, seq);
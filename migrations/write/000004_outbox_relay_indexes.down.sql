@@ -0,0 +1,4 @@
+-- 000004_outbox_relay_indexes.down.sql
+
+DROP INDEX IF EXISTS idx_outbox_event_lock_expires_at;
+DROP INDEX IF EXISTS idx_outbox_event_aggregate_pending;
@@ -0,0 +1,11 @@
+-- 000004_outbox_relay_indexes.up.sql
+
+-- LockBatch verifica se há evento anterior não publicado do mesmo aggregate (ordem por arena)
+CREATE INDEX IF NOT EXISTS idx_outbox_event_aggregate_pending
+    ON outbox_event (aggregate_type, aggregate_id, seq)
+    WHERE status <> 'PUBLISHED';
+
+-- Reclaim de locks expirados (relay que morreu com eventos em PROCESSING)
+CREATE INDEX IF NOT EXISTS idx_outbox_event_lock_expires_at
+    ON outbox_event (lock_expires_at)
+    WHERE status = 'PROCESSING';
@@ -0,0 +1,4 @@
+-- 000005_outbox_notify_trigger.down.sql
+
+DROP TRIGGER IF EXISTS trg_notify_outbox_event ON outbox_event;
+DROP FUNCTION IF EXISTS notify_outbox_event();
@@ -0,0 +1,19 @@
+-- 000005_outbox_notify_trigger.up.sql
+
+-- Acorda o outbox relay assim que eventos são gravados (LISTEN outbox_event).
+-- FOR EACH STATEMENT: um NOTIFY por INSERT; o Postgres ainda deduplica
+-- notificações iguais na mesma transação e só entrega no COMMIT.
+CREATE OR REPLACE FUNCTION notify_outbox_event()
+RETURNS TRIGGER AS $$
+BEGIN
+  PERFORM pg_notify('outbox_event', '');
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_notify_outbox_event ON outbox_event;
+
+CREATE TRIGGER trg_notify_outbox_event
+AFTER INSERT ON outbox_event
+FOR EACH STATEMENT
+EXECUTE FUNCTION notify_outbox_event();
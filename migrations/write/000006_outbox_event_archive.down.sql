@@ -0,0 +1,3 @@
+-- 000006_outbox_event_archive.down.sql
+
+DROP TABLE IF EXISTS outbox_event_archive;
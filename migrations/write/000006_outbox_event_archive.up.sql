@@ -0,0 +1,34 @@
+-- 000006_outbox_event_archive.up.sql
+
+-- Arquivo dos eventos PUBLISHED removidos de outbox_event pelo janitor.
+-- Particionado por mês de published_at: partições antigas saem com DROP (sem DELETE em massa).
+-- O janitor cria as partições mensais sob demanda (outbox_event_archive_yYYYYmMM).
+CREATE TABLE IF NOT EXISTS outbox_event_archive (
+    id                UUID        NOT NULL,
+    seq               BIGINT      NOT NULL,
+    aggregate_type    TEXT        NOT NULL,
+    aggregate_id      TEXT        NOT NULL,
+    event_type        TEXT        NOT NULL,
+    topic             TEXT        NOT NULL,
+
+    payload           JSONB       NOT NULL,
+    headers           JSONB       NOT NULL DEFAULT '{}'::jsonb,
+
+    correlation_id    TEXT        NULL,
+    causation_id      TEXT        NULL,
+    idempotency_key   TEXT        NULL,
+
+    attempts          INT         NOT NULL,
+    published_at      TIMESTAMPTZ NOT NULL,
+    created_at        TIMESTAMPTZ NOT NULL,
+    archived_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (id, published_at)
+) PARTITION BY RANGE (published_at);
+
+-- Rede de segurança caso a partição do mês não exista
+CREATE TABLE IF NOT EXISTS outbox_event_archive_default
+    PARTITION OF outbox_event_archive DEFAULT;
+
+CREATE INDEX IF NOT EXISTS idx_outbox_event_archive_aggregate
+    ON outbox_event_archive (aggregate_type, aggregate_id, seq);
@@ -0,0 +1,6 @@
+-- 000007_outbox_dead_letter_audit.down.sql
+
+DROP INDEX IF EXISTS idx_outbox_dlq_event_type;
+DROP INDEX IF EXISTS idx_outbox_dlq_aggregate;
+
+DROP TABLE IF EXISTS outbox_dead_letter_audit;
@@ -0,0 +1,39 @@
+-- 000007_outbox_dead_letter_audit.up.sql
+
+-- Trilha de auditoria das operações de admin sobre outbox_dead_letter
+CREATE TABLE IF NOT EXISTS outbox_dead_letter_audit (
+    id                BIGSERIAL   PRIMARY KEY,
+    action            TEXT        NOT NULL, -- REQUEUE | PURGE
+    dead_letter_id    UUID        NOT NULL,
+    outbox_event_id   UUID        NULL,     -- id do evento reenfileirado (REQUEUE)
+
+    aggregate_type    TEXT        NOT NULL,
+    aggregate_id      TEXT        NOT NULL,
+    event_type        TEXT        NOT NULL,
+    idempotency_key   TEXT        NULL,
+    attempts          INT         NOT NULL,
+    last_error        TEXT        NOT NULL,
+
+    actor             TEXT        NOT NULL,
+    reason            TEXT        NULL,
+    correlation_id    TEXT        NULL,
+
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+ALTER TABLE outbox_dead_letter_audit
+    ADD CONSTRAINT outbox_dead_letter_audit_action_chk
+    CHECK (action IN ('REQUEUE', 'PURGE'));
+
+CREATE INDEX IF NOT EXISTS idx_outbox_dlq_audit_dead_letter
+    ON outbox_dead_letter_audit (dead_letter_id);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_dlq_audit_created_at
+    ON outbox_dead_letter_audit (created_at);
+
+-- Filtros de listagem do admin
+CREATE INDEX IF NOT EXISTS idx_outbox_dlq_aggregate
+    ON outbox_dead_letter (aggregate_id, created_at);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_dlq_event_type
+    ON outbox_dead_letter (event_type, created_at);
@@ -0,0 +1,4 @@
+-- 000008_arena_players_and_scheduled_actions.down.sql
+
+DROP TABLE IF EXISTS arena_scheduled_action;
+DROP TABLE IF EXISTS arena_player;
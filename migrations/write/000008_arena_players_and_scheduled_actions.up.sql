@@ -0,0 +1,37 @@
+-- 000008_arena_players_and_scheduled_actions.up.sql
+
+-- Jogadores da arena (parte do aggregate; sincronizados a cada Save)
+CREATE TABLE IF NOT EXISTS arena_player (
+  arena_id      UUID        NOT NULL REFERENCES arena (id) ON DELETE CASCADE,
+  player_id     UUID        NOT NULL,
+  display_name  TEXT        NOT NULL,
+  role          TEXT        NOT NULL,
+  joined_at     TIMESTAMPTZ NOT NULL,
+
+  PRIMARY KEY (arena_id, player_id)
+);
+
+ALTER TABLE arena_player
+  ADD CONSTRAINT arena_player_role_chk
+  CHECK (role IN ('ADMIN', 'PLAYER'));
+
+-- Ações agendadas ainda não aplicadas (removidas quando o tick as consome)
+CREATE TABLE IF NOT EXISTS arena_scheduled_action (
+  id             UUID        PRIMARY KEY,
+  arena_id       UUID        NOT NULL REFERENCES arena (id) ON DELETE CASCADE,
+  player_id      UUID        NOT NULL,
+  type           TEXT        NOT NULL,
+  apply_at_tick  BIGINT      NOT NULL,
+  submitted_at   TIMESTAMPTZ NOT NULL,
+  payload        JSONB       NOT NULL,
+
+  -- ordem de submissão dentro do mesmo tick
+  seq            BIGSERIAL   NOT NULL
+);
+
+ALTER TABLE arena_scheduled_action
+  ADD CONSTRAINT arena_scheduled_action_type_chk
+  CHECK (type IN ('ADD_NUTRIENTS', 'DROP_ANTIBIOTIC', 'SET_TEMPERATURE', 'SPAWN_ORGANISM'));
+
+CREATE INDEX IF NOT EXISTS arena_scheduled_action_tick_idx
+  ON arena_scheduled_action (arena_id, apply_at_tick, seq);
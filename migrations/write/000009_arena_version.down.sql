@@ -0,0 +1,4 @@
+-- 000009_arena_version.down.sql
+
+ALTER TABLE arena
+  DROP COLUMN IF EXISTS version;
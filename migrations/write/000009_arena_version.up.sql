@@ -0,0 +1,5 @@
+-- 000009_arena_version.up.sql
+
+-- Concorrência otimista: Save faz UPDATE ... WHERE version = <esperada>
+ALTER TABLE arena
+  ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
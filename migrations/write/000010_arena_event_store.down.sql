@@ -0,0 +1,4 @@
+-- 000010_arena_event_store.down.sql
+
+DROP TABLE IF EXISTS arena_snapshot;
+DROP TABLE IF EXISTS arena_event;
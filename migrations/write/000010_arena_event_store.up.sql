@@ -0,0 +1,26 @@
+-- 000010_arena_event_store.up.sql
+
+-- Event store da arena (persistência alternativa a arena/arena_player/arena_scheduled_action).
+-- Append-only: a PK (arena_id, sequence) é o controle de concorrência otimista.
+CREATE TABLE IF NOT EXISTS arena_event (
+  arena_id     UUID        NOT NULL,
+  sequence     BIGINT      NOT NULL,
+  event_type   TEXT        NOT NULL,
+  payload      JSONB       NOT NULL,
+  occurred_at  TIMESTAMPTZ NOT NULL,
+  recorded_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  PRIMARY KEY (arena_id, sequence)
+);
+
+ALTER TABLE arena_event
+  ADD CONSTRAINT arena_event_sequence_chk
+  CHECK (sequence > 0);
+
+-- Snapshot mais recente do aggregate: o load lê o snapshot e só os eventos depois dele
+CREATE TABLE IF NOT EXISTS arena_snapshot (
+  arena_id    UUID        PRIMARY KEY,
+  sequence    BIGINT      NOT NULL,
+  state       JSONB       NOT NULL,
+  created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
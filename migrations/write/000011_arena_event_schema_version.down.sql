@@ -0,0 +1,4 @@
+-- 000011_arena_event_schema_version.down.sql
+
+ALTER TABLE arena_event
+  DROP COLUMN IF EXISTS schema_version;
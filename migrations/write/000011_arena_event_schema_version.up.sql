@@ -0,0 +1,5 @@
+-- 000011_arena_event_schema_version.up.sql
+
+-- Versão do payload (messaging.ArenaCodecs): eventos antigos passam pelos upcasters no load
+ALTER TABLE arena_event
+  ADD COLUMN IF NOT EXISTS schema_version INT NOT NULL DEFAULT 1;
@@ -0,0 +1,3 @@
+-- 000012_arena_world.down.sql
+
+DROP TABLE IF EXISTS arena_world;
@@ -0,0 +1,10 @@
+-- 000012_arena_world.up.sql
+
+-- Mundo simulado (simulation.State) de cada arena, gravado a cada tick na mesma
+-- transação do aggregate; tick é sempre o tick da arena.
+CREATE TABLE IF NOT EXISTS arena_world (
+  arena_id    UUID        PRIMARY KEY,
+  tick        BIGINT      NOT NULL,
+  state       JSONB       NOT NULL,
+  updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);